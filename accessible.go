@@ -0,0 +1,74 @@
+package main
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// out is where all terminal UI output goes
+var out io.Writer = os.Stdout
+
+// accessible reports whether screen-reader friendly output is on
+var accessible bool
+
+// setAccessible switches the terminal UI between the animated display and plain,
+// line-oriented output that screen readers can follow: no spinners, no carriage-return
+// redraws, no box drawing, no icons, and no colors.
+func setAccessible(on bool) {
+	accessible = on
+	if on {
+		out = plainWriter{w: os.Stdout}
+		return
+	}
+	out = os.Stdout
+}
+
+// ansiPattern matches ANSI color and style escape sequences
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// plainWriter strips decoration from UI output before passing it on
+type plainWriter struct {
+	w io.Writer
+}
+
+func (p plainWriter) Write(b []byte) (int, error) {
+	if _, err := io.WriteString(p.w, plainText(string(b))); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// plainText removes colors, carriage returns, icons, spinner frames, and box drawing
+// from s, and trims the padding left at the end of each line.
+func plainText(s string) string {
+	s = ansiPattern.ReplaceAllString(s, "")
+	s = strings.Map(func(r rune) rune {
+		if r == '\r' || isDecoration(r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isDecoration reports whether r is purely visual: box drawing, block and geometric
+// shapes, braille spinner frames, or a Nerd Font icon from the private use area.
+func isDecoration(r rune) bool {
+	switch {
+	case r >= 0x2500 && r <= 0x25FF: // box drawing, block elements, geometric shapes
+		return true
+	case r >= 0x2800 && r <= 0x28FF: // braille patterns
+		return true
+	case r >= 0xE000 && r <= 0xF8FF: // private use area
+		return true
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// captureAccessible turns on accessible output written to a buffer for the rest of the test.
+func captureAccessible(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	accessible, out = true, plainWriter{w: &buf}
+	t.Cleanup(func() { setAccessible(false) })
+	return &buf
+}
+
+// ===================
+// plainText tests
+// ===================
+
+func TestPlainText_StripsDecoration(t *testing.T) {
+	in := "\r" + VTOrange + Spinner[0] + Reset + " " + Green + IconCheck + Reset + " 12345 ▸ Intro to Testing          \n"
+	if got := plainText(in); got != "  12345  Intro to Testing\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestPlainText_KeepsReadableSymbols(t *testing.T) {
+	in := "Sections 3 → 4\n+ C Shaffer\n"
+	if got := plainText(in); got != in {
+		t.Errorf("got %q, want it unchanged", got)
+	}
+}
+
+// ===================
+// accessible UI tests
+// ===================
+
+func TestPrintWaitingStatus_AccessibleOncePerWait(t *testing.T) {
+	buf := captureAccessible(t)
+
+	for i := range 5 {
+		PrintWaitingStatus(i, 3, 1, 2, "30s", "10:00:00", "")
+	}
+
+	want := "Attempt #3, Found: 1/2, Next: 30s [10:00:00]\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPrintSeatAvailable_AccessibleHasNoBox(t *testing.T) {
+	buf := captureAccessible(t)
+
+	PrintSeatAvailable("Intro to Testing", "12345", false)
+
+	got := buf.String()
+	if strings.ContainsAny(got, "╭│╰\r\x1b") {
+		t.Errorf("output still has decoration: %q", got)
+	}
+	if !strings.Contains(got, "SEAT AVAILABLE!") || !strings.Contains(got, "CRN: 12345") {
+		t.Errorf("output missing the alert text: %q", got)
+	}
+}
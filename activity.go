@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SummaryPeriod is how often the activity summary is sent
+const SummaryPeriod = 7 * 24 * time.Hour
+
+// openEvent is one stretch of time a section was seen open.
+// Closed is zero while the section is still open.
+type openEvent struct {
+	Opened time.Time
+	Closed time.Time
+}
+
+// watchStats counts what happened to one CRN during a summary period
+type watchStats struct {
+	Checks int
+	Errors int
+	Opens  []openEvent
+}
+
+// activity records check results for the periodic summary
+type activity struct {
+	started     time.Time // when monitoring started
+	periodStart time.Time // when the current summary period started
+	crns        []string  // CRNs in watch order
+	stats       map[string]*watchStats
+}
+
+func newActivity(now time.Time, courses []CourseStatus) *activity {
+	a := &activity{started: now, periodStart: now, stats: make(map[string]*watchStats)}
+	for _, course := range courses {
+		a.crns = append(a.crns, course.CRN)
+		a.stats[course.CRN] = &watchStats{}
+	}
+	return a
+}
+
+// add starts tracking a CRN added while watching.
+func (a *activity) add(course CourseStatus) {
+	if _, ok := a.stats[course.CRN]; ok {
+		return
+	}
+	a.crns = append(a.crns, course.CRN)
+	a.stats[course.CRN] = &watchStats{}
+}
+
+// record counts one check of a CRN, opening or closing its open event as the section's state changes.
+func (a *activity) record(crn string, open bool, err error, now time.Time) {
+	s, ok := a.stats[crn]
+	if !ok {
+		return
+	}
+	s.Checks++
+	if err != nil {
+		s.Errors++
+		return
+	}
+
+	isOpen := len(s.Opens) > 0 && s.Opens[len(s.Opens)-1].Closed.IsZero()
+	switch {
+	case open && !isOpen:
+		s.Opens = append(s.Opens, openEvent{Opened: now})
+	case !open && isOpen:
+		s.Opens[len(s.Opens)-1].Closed = now
+	}
+}
+
+// due reports whether a full summary period has passed since the last summary.
+func (a *activity) due(now time.Time) bool {
+	return now.Sub(a.periodStart) >= SummaryPeriod
+}
+
+// summary describes the current period's activity for each CRN, along with how
+// long each took to first open and how long its openings tend to last, from
+// stats over the whole history.
+func (a *activity) summary(now time.Time, names map[string]string, stats map[string]*openingStats) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, T("summary.period", a.periodStart.Format("Jan 2 15:04"), now.Format("Jan 2 15:04")))
+	fmt.Fprintln(&b, T("summary.running", formatCountdown(now.Sub(a.started))))
+
+	for _, crn := range a.crns {
+		s := a.stats[crn]
+		fmt.Fprintf(&b, "\n%s  %s\n", crn, names[crn])
+		fmt.Fprintf(&b, "  %s\n", T("summary.checks", s.Checks, s.Errors))
+		if len(s.Opens) == 0 {
+			fmt.Fprintf(&b, "  %s\n", T("summary.no_openings"))
+		}
+		for _, e := range s.Opens {
+			if e.Closed.IsZero() {
+				fmt.Fprintf(&b, "  %s\n", T("summary.still_open", e.Opened.Format("Jan 2 15:04")))
+				continue
+			}
+			fmt.Fprintf(&b, "  %s\n", T("summary.opened_for", e.Opened.Format("Jan 2 15:04"), e.Closed.Sub(e.Opened).Round(time.Second)))
+		}
+		if st, ok := stats[crn]; ok {
+			for _, line := range st.describe(now) {
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		}
+	}
+	return b.String()
+}
+
+// reset starts a new summary period, keeping any section that is still open as an open event.
+func (a *activity) reset(now time.Time) {
+	a.periodStart = now
+	for crn, s := range a.stats {
+		next := &watchStats{}
+		if n := len(s.Opens); n > 0 && s.Opens[n-1].Closed.IsZero() {
+			next.Opens = []openEvent{s.Opens[n-1]}
+		}
+		a.stats[crn] = next
+	}
+}
+
+// sendSummary emails the activity summary if one is due, then starts a new period.
+func (c Config) sendSummary(sender EmailSender, a *activity, courses []CourseStatus, now time.Time) {
+	if !c.notifying() || !c.WeeklySummary || !a.due(now) {
+		return
+	}
+
+	names := make(map[string]string)
+	for _, course := range courses {
+		names[course.CRN] = course.Name
+	}
+	c.notify(sender, Alert{Event: AlertSummary, Subject: T("email.summary.subject"), Body: a.summary(now, names, c.openingStatsByCRN())})
+	a.reset(now)
+}
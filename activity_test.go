@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// activity tests
+// ===================
+
+func TestActivity_RecordTracksOpenEvents(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	a := newActivity(start, []CourseStatus{{CRN: "12345"}})
+
+	a.record("12345", false, nil, start)
+	a.record("12345", true, nil, start.Add(time.Hour))
+	a.record("12345", true, nil, start.Add(2*time.Hour))
+	a.record("12345", false, nil, start.Add(3*time.Hour))
+	a.record("12345", false, errors.New("timeout"), start.Add(4*time.Hour))
+
+	s := a.stats["12345"]
+	if s.Checks != 5 || s.Errors != 1 {
+		t.Errorf("checks = %d, errors = %d; want 5, 1", s.Checks, s.Errors)
+	}
+	if len(s.Opens) != 1 || s.Opens[0].Closed.Sub(s.Opens[0].Opened) != 2*time.Hour {
+		t.Errorf("opens = %+v, want one 2h opening", s.Opens)
+	}
+}
+
+func TestActivity_Summary(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	a := newActivity(start, []CourseStatus{{CRN: "12345"}, {CRN: "67890"}})
+	a.record("12345", true, nil, start.Add(time.Hour))
+	a.record("67890", false, nil, start.Add(time.Hour))
+
+	summary := a.summary(start.Add(SummaryPeriod), map[string]string{"12345": "Intro to Testing"}, nil)
+	for _, want := range []string{"Running for 7d 00:00:00", "12345  Intro to Testing", "still open", "No openings"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+}
+
+func TestActivity_ResetKeepsOngoingOpening(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	a := newActivity(start, []CourseStatus{{CRN: "12345"}})
+	a.record("12345", true, nil, start)
+
+	a.reset(start.Add(SummaryPeriod))
+
+	s := a.stats["12345"]
+	if s.Checks != 0 || len(s.Opens) != 1 {
+		t.Errorf("got %+v, want counts cleared and the ongoing opening kept", s)
+	}
+}
+
+// ===================
+// sendSummary tests
+// ===================
+
+func TestSendSummary_OnlyWhenDue(t *testing.T) {
+	start := time.Now()
+	cfg := Config{Email: "test@example.com", WeeklySummary: true}
+	courses := []CourseStatus{{CRN: "12345", Name: "Intro to Testing"}}
+	a := newActivity(start, courses)
+	sender := &MockEmailSender{}
+
+	cfg.sendSummary(sender, a, courses, start.Add(time.Hour))
+	if len(sender.Sent) != 0 {
+		t.Fatalf("sent %d emails before the period ended, want 0", len(sender.Sent))
+	}
+
+	cfg.sendSummary(sender, a, courses, start.Add(SummaryPeriod))
+	cfg.sendSummary(sender, a, courses, start.Add(SummaryPeriod+time.Hour))
+	if len(sender.Sent) != 1 {
+		t.Errorf("sent %d emails, want 1", len(sender.Sent))
+	}
+}
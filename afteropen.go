@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// What to do with a CRN once its section opens
+const (
+	AfterOpenStop = "stop" // stop watching it; exit once every CRN has opened
+	AfterOpenKeep = "keep" // keep watching, alerting again whenever it closes and reopens
+	AfterOpenSlow = "slow" // like keep, but check open sections at the low-priority cadence
+)
+
+// validateAfterOpen checks that afterOpen names a known behavior.
+func (c Config) validateAfterOpen() error {
+	switch c.AfterOpen {
+	case AfterOpenStop, AfterOpenKeep, AfterOpenSlow:
+		return nil
+	}
+	return fmt.Errorf("invalid afterOpen %q: must be stop, keep, or slow", c.AfterOpen)
+}
+
+// courseInterval returns how long to wait before checking a course again, given its
+// priority and, with afterOpen "slow", whether its section is currently open.
+func (c Config) courseInterval(course CourseStatus) time.Duration {
+	if course.Open && c.AfterOpen == AfterOpenSlow {
+		return max(c.checkInterval(course.Priority), c.checkInterval(PriorityLow))
+	}
+	return c.checkInterval(course.Priority)
+}
+
+// openCount returns how many courses were open as of their last check.
+func openCount(courses []CourseStatus) int {
+	n := 0
+	for _, course := range courses {
+		if course.Open {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// ===================
+// afterOpen tests
+// ===================
+
+func TestLoadConfig_AfterOpenDefaultsToStop(t *testing.T) {
+	cfg, err := loadConfig(createTempConfig(t, `{"crns": ["12345"]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AfterOpen != AfterOpenStop {
+		t.Errorf("AfterOpen = %q, want %q", cfg.AfterOpen, AfterOpenStop)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidAfterOpen(t *testing.T) {
+	if _, err := loadConfig(createTempConfig(t, `{"crns": ["12345"], "afterOpen": "forever"}`)); err == nil {
+		t.Error("expected error for unknown afterOpen")
+	}
+}
+
+func TestCourseInterval_SlowWhileOpen(t *testing.T) {
+	cfg := Config{CheckInterval: 30, AfterOpen: AfterOpenSlow}
+	course := CourseStatus{CRN: "12345", Priority: PriorityHigh}
+
+	if got := cfg.courseInterval(course); got != MinCheckInterval {
+		t.Errorf("closed: got %v, want %v", got, MinCheckInterval)
+	}
+	course.Open = true
+	if got := cfg.courseInterval(course); got != 90*time.Second {
+		t.Errorf("open: got %v, want %v", got, 90*time.Second)
+	}
+
+	cfg.AfterOpen = AfterOpenKeep
+	if got := cfg.courseInterval(course); got != MinCheckInterval {
+		t.Errorf("open with keep: got %v, want %v", got, MinCheckInterval)
+	}
+}
+
+func TestOpenCount(t *testing.T) {
+	courses := []CourseStatus{{CRN: "11111", Open: true}, {CRN: "22222"}, {CRN: "33333", Open: true, Found: true}}
+	if got := openCount(courses); got != 2 {
+		t.Errorf("got %d, want 2", got)
+	}
+}
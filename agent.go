@@ -0,0 +1,499 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAgentListen is where the server listens for agents when server.listen isn't set
+const DefaultAgentListen = ":8740"
+
+// agentRetry is how long an agent waits after a round fails before trying again
+const agentRetry = 30 * time.Second
+
+// maxAgentReport is the largest report the server reads from an agent
+const maxAgentReport = 1 << 20
+
+// AgentServer makes `openseat server` the central server for agents: workers on
+// other networks that run the timetable checks and report what they see, while
+// the server keeps the state, dedupes, and sends the alerts
+type AgentServer struct {
+	Listen            string   `json:"listen"`            // Address agents connect to (defaults to ":8740")
+	Token             string   `json:"token"`             // Shared secret agents send, with the report scope (optional with keys)
+	Keys              []APIKey `json:"keys"`              // Named tokens with their own scopes, for agents and scripts (optional)
+	RequestsPerMinute int      `json:"requestsPerMinute"` // Most requests each key may make a minute (defaults to 60)
+}
+
+// validate checks that agents have a key to authenticate with, defaulting the address.
+func (s *AgentServer) validate() error {
+	if err := s.validateKeys(); err != nil {
+		return err
+	}
+	if s.Listen == "" {
+		s.Listen = DefaultAgentListen
+	}
+	return nil
+}
+
+// agentWatch is one CRN an agent is asked to check, with where to check it
+type agentWatch struct {
+	CRN           string            `json:"crn"`
+	BaseURL       string            `json:"baseUrl"`
+	Backend       string            `json:"backend,omitempty"`
+	Banner9URL    string            `json:"banner9Url,omitempty"`
+	Terms         []string          `json:"terms"`
+	Campuses      []string          `json:"campuses"`
+	SessionCode   string            `json:"sessionCode,omitempty"`
+	FormOverrides map[string]string `json:"formOverrides,omitempty"`
+}
+
+// agentWork is the server's answer to an agent asking what to check
+type agentWork struct {
+	CheckInterval int          `json:"checkInterval"` // seconds between rounds
+	Watches       []agentWatch `json:"watches"`
+}
+
+// agentObservation is the result of one check by an agent
+type agentObservation struct {
+	CRN   string `json:"crn"`
+	Name  string `json:"name,omitempty"` // course title, once the agent has looked it up
+	Open  bool   `json:"open"`
+	Error string `json:"error,omitempty"` // why the check failed, leaving the state as it was
+}
+
+// agentReport is a round of checks an agent sends the server
+type agentReport struct {
+	Agent        string             `json:"agent"`
+	Observations []agentObservation `json:"observations"`
+}
+
+// agentWatch returns where an agent should check crn.
+func (c Config) agentWatch(crn string) agentWatch {
+	v := c.forCRN(crn)
+	return agentWatch{CRN: crn, BaseURL: v.getBaseURL(), Backend: v.Backend, Banner9URL: v.Banner9URL,
+		Terms: v.termList(), Campuses: v.campusList(), SessionCode: v.SessionCode, FormOverrides: v.FormOverrides}
+}
+
+// ===================
+// Server
+// ===================
+
+// agentServer hands agents the CRNs to check and turns their reports into alerts
+type agentServer struct {
+	cfg      Config
+	store    StateStore
+	sender   EmailSender
+	mux      *http.ServeMux
+	requests *rateLimiter  // requests by key, for requestsPerMinute
+	done     chan struct{} // closed once every CRN has opened, with afterOpen "stop"
+	finish   sync.Once
+
+	mu      sync.Mutex
+	courses []CourseStatus
+	agents  map[string]time.Time // when each agent last reported
+	addedBy map[string]string    // the key that added each CRN through the API, for maxWatches
+}
+
+// newAgentServer starts tracking every configured CRN as closed, or open if another
+// server sharing the state store last saw it open.
+func (c Config) newAgentServer(store StateStore, sender EmailSender) *agentServer {
+	s := &agentServer{cfg: c, store: store, sender: sender, mux: http.NewServeMux(), done: make(chan struct{}), agents: make(map[string]time.Time), addedBy: make(map[string]string)}
+	if c.Server.RequestsPerMinute > 0 {
+		s.requests = newRateLimiter(ChannelLimits{"api": {Max: c.Server.RequestsPerMinute, Minutes: 1, PerCRN: true}}, nil)
+	}
+	for _, crn := range c.CRNs {
+		s.courses = append(s.courses, CourseStatus{CRN: crn, Priority: c.priority(crn), Host: c.hostName(crn)})
+	}
+	c.loadWatchState(store, s.courses)
+	s.mux.HandleFunc("GET /v1/work", s.require(ScopeRead, s.serveWork))
+	s.mux.HandleFunc("GET /v1/status", s.require(ScopeRead, s.serveStatus))
+	s.mux.HandleFunc("POST /v1/report", s.require(ScopeReport, s.serveReport))
+	s.mux.HandleFunc("POST /v1/watches", s.require(ScopeManage, s.serveAddWatch))
+	s.mux.HandleFunc("DELETE /v1/watches/{crn}", s.require(ScopeManage, s.serveRemoveWatch))
+	s.mux.HandleFunc("GET /v1/audit", s.require(ScopeManage, s.serveAudit))
+	return s
+}
+
+// ServeHTTP answers agents and scripts. Every request must carry the token of a
+// key whose scope covers the endpoint.
+func (s *agentServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// serveWork answers GET /v1/work with what agents should check.
+func (s *agentServer) serveWork(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.work())
+}
+
+// serveReport takes an agent's round of checks from POST /v1/report.
+func (s *agentServer) serveReport(w http.ResponseWriter, r *http.Request) {
+	var report agentReport
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAgentReport)).Decode(&report); err != nil || report.Agent == "" {
+		http.Error(w, "invalid report", http.StatusBadRequest)
+		return
+	}
+	s.record(report)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serverStatus is the answer to GET /v1/status
+type serverStatus struct {
+	Watches []serverWatch     `json:"watches"`
+	Agents  map[string]string `json:"agents"` // when each agent last reported, RFC 3339
+}
+
+// serverWatch is one CRN in the server's status
+type serverWatch struct {
+	CRN      string `json:"crn"`
+	Name     string `json:"name,omitempty"`
+	Open     bool   `json:"open"`
+	Watching bool   `json:"watching"`
+}
+
+// serveStatus answers GET /v1/status with every CRN's state and the agents seen.
+func (s *agentServer) serveStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	status := serverStatus{Watches: []serverWatch{}, Agents: make(map[string]string)}
+	for _, course := range s.courses {
+		status.Watches = append(status.Watches, serverWatch{CRN: course.CRN, Name: course.Name, Open: course.Open, Watching: !course.Found})
+	}
+	for name, at := range s.agents {
+		status.Agents[name] = at.Format(time.RFC3339)
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, status)
+}
+
+// serveAddWatch starts watching the CRN in a POST /v1/watches body like
+// {"crn": "12345"}, or watches a stopped one again, unless the key is already
+// watching its maxWatches.
+func (s *agentServer) serveAddWatch(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		CRN string `json:"crn"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAgentReport)).Decode(&body); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if err := validateCRN(body.CRN); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key := requestAPIKey(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.atQuota(key, body.CRN) {
+		http.Error(w, fmt.Sprintf("key %q is already watching its maxWatches of %d", key.Name, key.MaxWatches), http.StatusForbidden)
+		return
+	}
+	if i := s.index(body.CRN); i >= 0 {
+		if s.courses[i].Found {
+			s.addedBy[body.CRN] = key.Name
+		}
+		s.courses[i].Found, s.courses[i].Open = false, false
+	} else {
+		s.courses = append(s.courses, CourseStatus{CRN: body.CRN, Priority: s.cfg.priority(body.CRN), Host: s.cfg.hostName(body.CRN)})
+		s.addedBy[body.CRN] = key.Name
+	}
+	PrintServerWatchAdded(body.CRN)
+	s.cfg.audit(AuditEntry{Action: AuditWatchAdded, CRN: body.CRN, Actor: key.Name, Via: ViaAPI})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveRemoveWatch stops watching the CRN in DELETE /v1/watches/{crn}.
+func (s *agentServer) serveRemoveWatch(w http.ResponseWriter, r *http.Request) {
+	crn := r.PathValue("crn")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.index(crn)
+	if i < 0 {
+		http.Error(w, "not watched: "+crn, http.StatusNotFound)
+		return
+	}
+	s.courses[i].Found = true
+	PrintCRNRemoved(crn)
+	s.cfg.audit(AuditEntry{Action: AuditWatchRemoved, CRN: crn, Actor: requestKeyName(r), Via: ViaAPI})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON answers with v as JSON.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// work lists the CRNs still being watched.
+func (s *agentServer) work() agentWork {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	work := agentWork{CheckInterval: s.cfg.CheckInterval, Watches: []agentWatch{}}
+	for _, course := range s.courses {
+		if !course.Found {
+			work.Watches = append(work.Watches, s.cfg.agentWatch(course.CRN))
+		}
+	}
+	return work
+}
+
+// record applies an agent's observations in the order they arrive, alerting on
+// sections that opened. With several agents, whichever reports a change first
+// triggers it, and the rest agree with the state by then.
+func (s *agentServer) record(report agentReport) {
+	now := time.Now()
+	var opened []CourseStatus
+	changed := make(map[string]bool) // CRNs whose state changed, and whether they're now open
+	s.mu.Lock()
+	if _, seen := s.agents[report.Agent]; !seen {
+		PrintAgentJoined(report.Agent)
+	}
+	s.agents[report.Agent] = now
+	for _, o := range report.Observations {
+		i := s.index(o.CRN)
+		if i < 0 || s.courses[i].Found {
+			continue
+		}
+		course := &s.courses[i]
+		if course.Name == "" {
+			course.Name = o.Name
+		}
+		switch {
+		case o.Error != "":
+			PrintAgentCheckError(now.Format("15:04:05"), report.Agent, o.CRN, o.Error)
+
+		case o.Open && !course.Open:
+			course.Open = true
+			if s.cfg.AfterOpen == AfterOpenStop {
+				course.Found = true
+			}
+			PrintSeatAvailable(course.Name, course.CRN, course.Priority == PriorityHigh)
+			s.cfg.recordHistory(EventOpen, *course, now)
+			opened = append(opened, *course)
+			changed[course.CRN] = true
+
+		case !o.Open && course.Open:
+			course.Open = false
+			PrintSeatClosed(course.Name, course.CRN)
+			s.cfg.recordHistory(EventClosed, *course, now)
+			changed[course.CRN] = false
+		}
+	}
+	remaining := 0
+	for _, course := range s.courses {
+		if !course.Found {
+			remaining++
+		}
+	}
+	s.mu.Unlock()
+
+	for crn, open := range changed {
+		s.cfg.saveWatchState(s.store, crn, open)
+	}
+	for _, course := range opened {
+		s.cfg.notifyOpen(s.store, s.sender, course)
+	}
+	if remaining == 0 {
+		s.finish.Do(func() { close(s.done) })
+	}
+}
+
+// index returns where crn is in the watched courses, or -1.
+func (s *agentServer) index(crn string) int {
+	for i, course := range s.courses {
+		if course.CRN == crn {
+			return i
+		}
+	}
+	return -1
+}
+
+// runServer parses the server command's flags and serves agents until every CRN has opened.
+func runServer(args []string) error {
+	fs := flag.NewFlagSet("server", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file`")
+	fs.Parse(args)
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+	if err := cfg.Server.validate(); err != nil {
+		return configError(err)
+	}
+	if err := cfg.resolveAutoTerms(time.Now()); err != nil {
+		return err
+	}
+	if err := cfg.resolveCampusNames(); err != nil {
+		return err
+	}
+
+	sender, err := cfg.newEmailSender()
+	if err != nil {
+		return err
+	}
+	store, err := cfg.newStateStore()
+	if err != nil {
+		return err
+	}
+	if cfg.notifiers, err = cfg.newNotifiers(sender); err != nil {
+		return err
+	}
+	if err := cfg.checkChannelNames(); err != nil {
+		return configError(err)
+	}
+	cfg.limiter = newRateLimiter(cfg.RateLimits, store)
+	defer waitForCommands()
+
+	listener, err := net.Listen("tcp", cfg.Server.Listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen for agents: %w", err)
+	}
+	srv := cfg.newAgentServer(store, sender)
+	httpServer := &http.Server{Handler: srv, ReadHeaderTimeout: 10 * time.Second}
+	go httpServer.Serve(listener)
+	defer httpServer.Close()
+
+	PrintServerListening(listener.Addr().String(), len(cfg.CRNs))
+	<-srv.done
+	PrintAllCoursesFound()
+	return nil
+}
+
+// ===================
+// Agent
+// ===================
+
+// agent checks the CRNs the server hands out from its own network and reports back
+type agent struct {
+	server, token, name string
+	client              *http.Client
+	spacing             time.Duration       // between checks, as the watch loop does
+	sessions            map[string]*session // timetable sessions by base URL, kept between rounds
+	names               map[string]string   // course titles looked up so far, by CRN
+}
+
+func newAgent(server, token, name string) *agent {
+	return &agent{server: strings.TrimSuffix(server, "/"), token: token, name: name, client: &http.Client{Timeout: 30 * time.Second},
+		spacing: 500 * time.Millisecond, sessions: make(map[string]*session), names: make(map[string]string)}
+}
+
+// round asks the server what to check, checks it, and reports the results. It
+// returns how long to wait before the next round.
+func (a *agent) round() (time.Duration, error) {
+	var work agentWork
+	if err := a.call(http.MethodGet, "/v1/work", nil, &work); err != nil {
+		return 0, err
+	}
+
+	report := agentReport{Agent: a.name, Observations: []agentObservation{}}
+	failed := 0
+	for i, w := range work.Watches {
+		if len(w.Terms) == 0 || len(w.Campuses) == 0 {
+			continue
+		}
+		if i > 0 {
+			time.Sleep(a.spacing)
+		}
+		cfg := a.configFor(w)
+		if a.names[w.CRN] == "" {
+			if name, err := cfg.getCourseName(w.CRN); err == nil {
+				a.names[w.CRN] = name
+			}
+		}
+		o := agentObservation{CRN: w.CRN, Name: a.names[w.CRN]}
+		open, err := cfg.checkSectionOpen(w.CRN)
+		o.Open = open
+		if err != nil {
+			o.Error = err.Error()
+			failed++
+		}
+		report.Observations = append(report.Observations, o)
+	}
+
+	if err := a.call(http.MethodPost, "/v1/report", report, nil); err != nil {
+		return 0, err
+	}
+	PrintAgentRound(time.Now().Format("15:04:05"), len(report.Observations), failed)
+	return time.Duration(work.CheckInterval) * time.Second, nil
+}
+
+// configFor builds the config for checking a watch, reusing the session for its timetable.
+func (a *agent) configFor(w agentWatch) Config {
+	cfg := Config{BaseURL: w.BaseURL, Backend: w.Backend, Banner9URL: w.Banner9URL, Term: w.Terms[0], Terms: w.Terms,
+		Campus: w.Campuses[0], Campuses: w.Campuses, SessionCode: w.SessionCode, FormOverrides: w.FormOverrides,
+		RequestTimeoutSeconds: int(DefaultRequestTimeout / time.Second)}
+	if a.sessions[w.BaseURL] == nil {
+		a.sessions[w.BaseURL] = cfg.newSession()
+	}
+	cfg.session = a.sessions[w.BaseURL]
+	return cfg
+}
+
+// call sends a request to the server, decoding the JSON answer into out when given.
+func (a *agent) call(method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, a.server+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("server unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return errors.New("server rejected the token")
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%w from server: %s", ErrUnexpectedStatus, resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// runAgent parses the agent command's flags and checks for the server until stopped.
+func runAgent(args []string) error {
+	fs := flag.NewFlagSet("agent", flag.ExitOnError)
+	server := fs.String("server", "", "the central server's `url`, e.g. http://watch.example.com:8740")
+	token := fs.String("token", os.Getenv("OPENSEAT_AGENT_TOKEN"), "the server's `token` (defaults to $OPENSEAT_AGENT_TOKEN)")
+	hostname, _ := os.Hostname()
+	name := fs.String("name", hostname, "what the server calls this agent (defaults to the host name)")
+	fs.Parse(args)
+	if *server == "" || *token == "" {
+		return configError(errors.New("usage: openseat agent --server <url> --token <token>"))
+	}
+
+	a := newAgent(*server, *token, *name)
+	PrintAgentStarted(*name, a.server)
+	for {
+		wait, err := a.round()
+		if err != nil {
+			PrintAgentError(err, agentRetry)
+			wait = agentRetry
+		}
+		time.Sleep(wait)
+	}
+}
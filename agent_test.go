@@ -0,0 +1,140 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ===================
+// Server config tests
+// ===================
+
+func TestAgentServer_Validate(t *testing.T) {
+	s := AgentServer{}
+	if err := s.validate(); err == nil {
+		t.Error("expected an error without a token")
+	}
+	s.Token = "secret"
+	if err := s.validate(); err != nil || s.Listen != DefaultAgentListen {
+		t.Errorf("expected the default address, got %q, %v", s.Listen, err)
+	}
+}
+
+// ===================
+// agentServer tests
+// ===================
+
+func TestAgentServer_RequiresToken(t *testing.T) {
+	cfg := Config{CRNs: []string{"12345"}, Server: AgentServer{Token: "secret"}}
+	cfg.Server.validate()
+	server := httptest.NewServer(cfg.newAgentServer(newMemoryStateStore(), &MockEmailSender{}))
+	defer server.Close()
+
+	a := newAgent(server.URL, "wrong", "laptop")
+	if _, err := a.round(); err == nil || !strings.Contains(err.Error(), "token") {
+		t.Errorf("expected the token to be rejected, got %v", err)
+	}
+}
+
+func TestAgentServer_Work(t *testing.T) {
+	cfg := Config{CRNs: []string{"12345", "12346"}, Term: "202601", Campus: "0", CheckInterval: 45, TermOverrides: map[string]string{"12346": "202609"}}
+	srv := cfg.newAgentServer(newMemoryStateStore(), &MockEmailSender{})
+	srv.courses[0].Found = true
+
+	work := srv.work()
+	if work.CheckInterval != 45 || len(work.Watches) != 1 {
+		t.Fatalf("expected only the CRN still watched, got %+v", work)
+	}
+	if w := work.Watches[0]; w.CRN != "12346" || w.Terms[0] != "202609" || w.BaseURL != DefaultTimetableURL {
+		t.Errorf("expected the CRN's own term and the timetable, got %+v", w)
+	}
+}
+
+func TestAgentServer_RecordAlertsOnce(t *testing.T) {
+	cfg := Config{CRNs: []string{"12345", "12346"}, Term: "202601", Email: "test@example.com", AfterOpen: AfterOpenKeep}
+	sender := &MockEmailSender{}
+	srv := cfg.newAgentServer(newMemoryStateStore(), sender)
+
+	srv.record(agentReport{Agent: "home", Observations: []agentObservation{{CRN: "12345", Name: "Intro to Testing", Open: true}, {CRN: "12346", Error: "timeout"}}})
+	srv.record(agentReport{Agent: "vps", Observations: []agentObservation{{CRN: "12345", Open: true}}})
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "Intro to Testing") {
+		t.Fatalf("expected one alert however many agents see the opening, got %+v", sender.Sent)
+	}
+	if srv.courses[1].Open {
+		t.Error("expected a failed check to leave the state alone")
+	}
+
+	srv.record(agentReport{Agent: "vps", Observations: []agentObservation{{CRN: "12345", Open: false}}})
+	if srv.courses[0].Open {
+		t.Error("expected the section to be closed again")
+	}
+}
+
+func TestAgentServer_SharesWatchState(t *testing.T) {
+	cfg := Config{CRNs: []string{"12345"}, Term: "202601", Email: "test@example.com", AfterOpen: AfterOpenKeep}
+	store := newMemoryStateStore()
+	first := cfg.newAgentServer(store, &MockEmailSender{})
+	first.record(agentReport{Agent: "home", Observations: []agentObservation{{CRN: "12345", Open: true}}})
+
+	sender := &MockEmailSender{}
+	second := cfg.newAgentServer(store, sender)
+	if !second.courses[0].Open {
+		t.Fatal("expected a server started later to know the section is open")
+	}
+	second.record(agentReport{Agent: "vps", Observations: []agentObservation{{CRN: "12345", Open: true}}})
+	if len(sender.Sent) != 0 {
+		t.Errorf("expected no second alert for the same opening, got %+v", sender.Sent)
+	}
+}
+
+func TestAgentServer_DoneOnceAllOpen(t *testing.T) {
+	cfg := Config{CRNs: []string{"12345"}, Term: "202601", AfterOpen: AfterOpenStop}
+	srv := cfg.newAgentServer(newMemoryStateStore(), &MockEmailSender{})
+
+	srv.record(agentReport{Agent: "home", Observations: []agentObservation{{CRN: "12345", Open: true}}})
+	select {
+	case <-srv.done:
+	default:
+		t.Error("expected the server to finish once every CRN opened")
+	}
+	if len(srv.work().Watches) != 0 {
+		t.Error("expected nothing left for agents to check")
+	}
+}
+
+// ===================
+// agent tests
+// ===================
+
+func TestAgent_RoundReportsToServer(t *testing.T) {
+	timetable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.FormValue("open_only") == "on" {
+			w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td></tr></table>`))
+			return
+		}
+		w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td><td>CS-3114</td><td>Data Structures</td></tr></table>`))
+	}))
+	defer timetable.Close()
+
+	cfg := Config{CRNs: []string{"12345"}, BaseURL: timetable.URL, Term: "202601", Campus: "0", CheckInterval: 30,
+		Email: "test@example.com", AfterOpen: AfterOpenStop, Server: AgentServer{Token: "secret"}}
+	cfg.Server.validate()
+	sender := &MockEmailSender{}
+	srv := cfg.newAgentServer(newMemoryStateStore(), sender)
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	wait, err := newAgent(server.URL, "secret", "laptop").round()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wait.Seconds() != 30 {
+		t.Errorf("expected the server's check interval, got %v", wait)
+	}
+	if len(sender.Sent) != 1 || !srv.courses[0].Found {
+		t.Errorf("expected the server to alert on the agent's report, got %+v", sender.Sent)
+	}
+}
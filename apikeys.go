@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// What a server API key may do. Each scope includes the ones before it.
+const (
+	ScopeRead   = "read"   // see the watches and agents
+	ScopeReport = "report" // also report checks, as agents do
+	ScopeManage = "manage" // also add and remove watches
+)
+
+// scopes are the API key scopes from least to most access
+var scopes = []string{ScopeRead, ScopeReport, ScopeManage}
+
+// DefaultAPIRequestsPerMinute is how many requests each key may make a minute when requestsPerMinute isn't set
+const DefaultAPIRequestsPerMinute = 60
+
+// APIKey is a named token for the server's API, with what it's allowed to do
+type APIKey struct {
+	Name  string `json:"name"`  // Names the key in errors and rate limits, e.g. "dorm-pi"
+	Token string `json:"token"` // Secret sent as "Authorization: Bearer <token>"
+	Scope string `json:"scope"` // "read" (default), "report", or "manage"
+	// Most CRNs the key may have added through the API and still be watching,
+	// so one script can't crowd the agents' checks (optional, no limit by default)
+	MaxWatches int `json:"maxWatches"`
+}
+
+// validateKeys checks the API keys, adding the plain token as an agent's key, and
+// defaults the rate limit.
+func (s *AgentServer) validateKeys() error {
+	if s.Token != "" && !slices.ContainsFunc(s.Keys, func(k APIKey) bool { return k.Token == s.Token }) {
+		s.Keys = append(s.Keys, APIKey{Name: "agents", Token: s.Token, Scope: ScopeReport})
+	}
+	if len(s.Keys) == 0 {
+		return fmt.Errorf("server needs a token or keys for agents and scripts to authenticate with")
+	}
+	for i := range s.Keys {
+		k := &s.Keys[i]
+		if k.Name == "" {
+			k.Name = fmt.Sprintf("key %d", i+1)
+		}
+		if k.Token == "" {
+			return fmt.Errorf("server key %q has no token", k.Name)
+		}
+		if slices.ContainsFunc(s.Keys[:i], func(o APIKey) bool { return o.Token == k.Token }) {
+			return fmt.Errorf("server key %q reuses another key's token", k.Name)
+		}
+		if slices.ContainsFunc(s.Keys[:i], func(o APIKey) bool { return o.Name == k.Name }) {
+			return fmt.Errorf("server has two keys named %q", k.Name)
+		}
+		if k.Scope == "" {
+			k.Scope = ScopeRead
+		}
+		if !slices.Contains(scopes, k.Scope) {
+			return fmt.Errorf("server key %q: invalid scope %q: must be read, report, or manage", k.Name, k.Scope)
+		}
+		if k.MaxWatches < 0 {
+			return fmt.Errorf("server key %q: maxWatches must be positive, got %d", k.Name, k.MaxWatches)
+		}
+	}
+	if s.RequestsPerMinute == 0 {
+		s.RequestsPerMinute = DefaultAPIRequestsPerMinute
+	}
+	if s.RequestsPerMinute < 0 {
+		return fmt.Errorf("server requestsPerMinute must be positive, got %d", s.RequestsPerMinute)
+	}
+	return nil
+}
+
+// authenticate returns the key a request's bearer token belongs to.
+func (s AgentServer) authenticate(r *http.Request) (APIKey, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return APIKey{}, false
+	}
+	for _, k := range s.Keys {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(k.Token)) == 1 {
+			return k, true
+		}
+	}
+	return APIKey{}, false
+}
+
+// allows reports whether a key's scope covers the scope an endpoint needs.
+func (k APIKey) allows(scope string) bool {
+	return slices.Index(scopes, k.Scope) >= slices.Index(scopes, scope)
+}
+
+// require wraps an endpoint so it answers only keys with the scope, and only as
+// often as requestsPerMinute allows each key.
+func (s *agentServer) require(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, ok := s.cfg.Server.authenticate(r)
+		if !ok {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		// the limiter counts each key's requests separately, as it does each CRN's alerts
+		if !s.requests.allow("api", key.Name, time.Now()) {
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		if !key.allows(scope) {
+			http.Error(w, fmt.Sprintf("key %q has %s scope, this needs %s", key.Name, key.Scope, scope), http.StatusForbidden)
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), apiKeyContext{}, key)))
+	}
+}
+
+// apiKeyContext is the request context key for the API key a request was made with
+type apiKeyContext struct{}
+
+// requestAPIKey returns the key a request was made with.
+func requestAPIKey(r *http.Request) APIKey {
+	key, _ := r.Context().Value(apiKeyContext{}).(APIKey)
+	return key
+}
+
+// requestKeyName returns the name of the key a request was made with, for the audit log.
+func requestKeyName(r *http.Request) string {
+	return requestAPIKey(r).Name
+}
+
+// atQuota reports whether adding crn would take the key past its maxWatches. Only
+// CRNs the key added and that are still watched count, so one it removes, or that
+// stopped after opening, frees a place. The server's lock must be held.
+func (s *agentServer) atQuota(key APIKey, crn string) bool {
+	if key.MaxWatches == 0 {
+		return false
+	}
+	watching := 0
+	for _, course := range s.courses {
+		if course.CRN == crn && !course.Found {
+			return false // already watched, so adding it again changes nothing
+		}
+		if s.addedBy[course.CRN] == key.Name && !course.Found {
+			watching++
+		}
+	}
+	return watching >= key.MaxWatches
+}
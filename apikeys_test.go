@@ -0,0 +1,141 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ===================
+// API key config tests
+// ===================
+
+func TestAgentServer_ValidateKeys(t *testing.T) {
+	s := AgentServer{Token: "agents-secret", Keys: []APIKey{{Token: "status-secret"}}}
+	if err := s.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Keys) != 2 || s.Keys[0].Name != "key 1" || s.Keys[0].Scope != ScopeRead || s.Keys[1].Scope != ScopeReport {
+		t.Errorf("expected a read-only key and the token as an agent key, got %+v", s.Keys)
+	}
+	if s.RequestsPerMinute != DefaultAPIRequestsPerMinute {
+		t.Errorf("expected the default rate limit, got %d", s.RequestsPerMinute)
+	}
+	if err := s.validate(); err != nil || len(s.Keys) != 2 {
+		t.Errorf("expected validating again to change nothing, got %+v, %v", s.Keys, err)
+	}
+
+	tests := map[string]AgentServer{
+		"no token":      {Keys: []APIKey{{Name: "ci"}}},
+		"unknown scope": {Keys: []APIKey{{Token: "x", Scope: "admin"}}},
+		"same token":    {Keys: []APIKey{{Token: "x"}, {Token: "x", Scope: ScopeManage}}},
+		"same name":     {Keys: []APIKey{{Name: "ci", Token: "x"}, {Name: "ci", Token: "y"}}},
+		"rate limit":    {Token: "x", RequestsPerMinute: -1},
+		"max watches":   {Keys: []APIKey{{Token: "x", MaxWatches: -1}}},
+	}
+	for name, s := range tests {
+		if err := s.validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// ===================
+// Scope and rate limit tests
+// ===================
+
+// apiRequest sends a request to the server's handler with a bearer token.
+func apiRequest(handler http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAgentServer_Scopes(t *testing.T) {
+	cfg := Config{CRNs: []string{"12345"}, Term: "202601", Server: AgentServer{Keys: []APIKey{
+		{Name: "dashboard", Token: "read-secret"},
+		{Name: "pi", Token: "report-secret", Scope: ScopeReport},
+		{Name: "admin", Token: "manage-secret", Scope: ScopeManage},
+	}}}
+	cfg.Server.validate()
+	srv := cfg.newAgentServer(newMemoryStateStore(), &MockEmailSender{})
+
+	tests := []struct {
+		method, path, token, body string
+		want                      int
+	}{
+		{"GET", "/v1/status", "nope", "", http.StatusUnauthorized},
+		{"GET", "/v1/status", "read-secret", "", http.StatusOK},
+		{"POST", "/v1/report", "read-secret", `{"agent": "pi"}`, http.StatusForbidden},
+		{"POST", "/v1/report", "report-secret", `{"agent": "pi"}`, http.StatusNoContent},
+		{"POST", "/v1/watches", "report-secret", `{"crn": "12346"}`, http.StatusForbidden},
+		{"POST", "/v1/watches", "manage-secret", `{"crn": "12346"}`, http.StatusNoContent},
+		{"POST", "/v1/watches", "manage-secret", `{"crn": "123"}`, http.StatusBadRequest},
+		{"DELETE", "/v1/watches/12345", "manage-secret", "", http.StatusNoContent},
+		{"DELETE", "/v1/watches/99999", "manage-secret", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if rec := apiRequest(srv, tt.method, tt.path, tt.token, tt.body); rec.Code != tt.want {
+			t.Errorf("%s %s with %s: got %d, want %d", tt.method, tt.path, tt.token, rec.Code, tt.want)
+		}
+	}
+
+	work := srv.work()
+	if len(work.Watches) != 1 || work.Watches[0].CRN != "12346" {
+		t.Errorf("expected 12345 removed and 12346 added, got %+v", work.Watches)
+	}
+}
+
+func TestAgentServer_RateLimit(t *testing.T) {
+	cfg := Config{CRNs: []string{"12345"}, Term: "202601", Server: AgentServer{RequestsPerMinute: 2, Keys: []APIKey{
+		{Name: "busy", Token: "busy-secret"},
+		{Name: "quiet", Token: "quiet-secret"},
+	}}}
+	cfg.Server.validate()
+	srv := cfg.newAgentServer(newMemoryStateStore(), &MockEmailSender{})
+
+	for range 2 {
+		apiRequest(srv, "GET", "/v1/status", "busy-secret", "")
+	}
+	rec := apiRequest(srv, "GET", "/v1/status", "busy-secret", "")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("expected the third request in a minute turned away, got %d", rec.Code)
+	}
+	if rec := apiRequest(srv, "GET", "/v1/status", "quiet-secret", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected other keys unaffected, got %d", rec.Code)
+	}
+}
+
+func TestAgentServer_MaxWatches(t *testing.T) {
+	cfg := Config{CRNs: []string{"12345"}, Term: "202601", Server: AgentServer{Keys: []APIKey{
+		{Name: "script", Token: "script-secret", Scope: ScopeManage, MaxWatches: 2},
+		{Name: "me", Token: "me-secret", Scope: ScopeManage},
+	}}}
+	cfg.Server.validate()
+	srv := cfg.newAgentServer(newMemoryStateStore(), &MockEmailSender{})
+
+	tests := []struct {
+		method, path, token, body string
+		want                      int
+	}{
+		{"POST", "/v1/watches", "script-secret", `{"crn": "12346"}`, http.StatusNoContent},
+		{"POST", "/v1/watches", "script-secret", `{"crn": "12345"}`, http.StatusNoContent}, // already watched, so free
+		{"POST", "/v1/watches", "script-secret", `{"crn": "12347"}`, http.StatusNoContent},
+		{"POST", "/v1/watches", "script-secret", `{"crn": "12348"}`, http.StatusForbidden},
+		{"POST", "/v1/watches", "script-secret", `{"crn": "12346"}`, http.StatusNoContent}, // its own, still free
+		{"POST", "/v1/watches", "me-secret", `{"crn": "12348"}`, http.StatusNoContent},
+		{"DELETE", "/v1/watches/12346", "me-secret", "", http.StatusNoContent},
+		{"POST", "/v1/watches", "script-secret", `{"crn": "12349"}`, http.StatusNoContent},
+	}
+	for i, tt := range tests {
+		if rec := apiRequest(srv, tt.method, tt.path, tt.token, tt.body); rec.Code != tt.want {
+			t.Errorf("%d: %s %s with %s: got %d, want %d", i, tt.method, tt.path, tt.token, rec.Code, tt.want)
+		}
+	}
+	if rec := apiRequest(srv, "POST", "/v1/watches", "script-secret", `{"crn": "12350"}`); !strings.Contains(rec.Body.String(), "maxWatches of 2") {
+		t.Errorf("expected the error to name the limit, got %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultAuditFile is the audit log name used when auditFile isn't configured.
+// It lives next to the config file, like the history file.
+const DefaultAuditFile = "audit.jsonl"
+
+// Audit log actions
+const (
+	AuditNotification = "notification"  // an alert sent, or failed, over one channel
+	AuditWatchAdded   = "watch_added"   // someone started watching a CRN
+	AuditWatchRemoved = "watch_removed" // someone stopped watching a CRN
+	AuditEnrolled     = "enrolled"      // someone marked a CRN as enrolled
+)
+
+// Interfaces a watch can be changed through
+const (
+	ViaKeyboard = "keyboard" // keys pressed in the running watcher
+	ViaCtl      = "ctl"      // openseatctl, over the control socket
+	ViaCLI      = "cli"      // a command like `openseat enrolled`
+	ViaAPI      = "api"      // the server's API
+)
+
+// AuditEntry is one line of the audit log
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	CRN    string    `json:"crn,omitempty"`
+
+	Event     string `json:"event,omitempty"`     // the alert, for notifications, e.g. "open"
+	Channel   string `json:"channel,omitempty"`   // for notifications, e.g. "email"
+	Recipient string `json:"recipient,omitempty"` // who a notification went to, when the channel has a public address
+	Result    string `json:"result,omitempty"`    // "sent", or why a notification failed
+
+	Actor string `json:"actor,omitempty"` // who changed a watch: the local user or a server key's name
+	Via   string `json:"via,omitempty"`   // how a watch was changed, e.g. ViaCtl
+}
+
+// defaultAuditFile places the audit log next to the config file unless one is configured.
+func (c *Config) defaultAuditFile(configPath string) {
+	if c.AuditFile == "" {
+		c.AuditFile = filepath.Join(filepath.Dir(configPath), DefaultAuditFile)
+	}
+}
+
+// audit appends an entry to the audit log. Failures are reported but never stop monitoring.
+func (c Config) audit(e AuditEntry) {
+	if c.AuditFile == "" {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if err := appendAudit(c.AuditFile, e); err != nil {
+		PrintAuditError(err)
+	}
+}
+
+// auditDelivery records one channel's outcome for an alert.
+func (c Config) auditDelivery(a Alert, channel, recipient string, err error) {
+	result := "sent"
+	if err != nil {
+		result = err.Error()
+	}
+	c.audit(AuditEntry{Action: AuditNotification, CRN: a.CRN, Event: a.Event, Channel: channel, Recipient: recipient, Result: result})
+}
+
+// notifierRecipient returns who a channel's alerts go to. Bark's device key,
+// Slack's webhook URL, and the ntfy topic are secrets, so their alerts are logged
+// without one, and webhooks are logged by host alone.
+func notifierRecipient(n Notifier) string {
+	switch n := n.(type) {
+	case *snsNotifier:
+		return n.topicARN
+	case *smsGatewayNotifier:
+		return n.to
+	case *whatsAppNotifier:
+		return strings.Join(n.To, ", ")
+	case *twilioSMSNotifier:
+		return strings.Join(n.To, ", ")
+	case *signalNotifier:
+		return strings.Join(n.Recipients, ", ")
+	case *webhookNotifier:
+		return n.host()
+	case *NotifyCommand:
+		return n.Path
+	}
+	return ""
+}
+
+// localActor names the user running OpenSeat, for watch changes made on this machine.
+func localActor() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// appendAudit adds an entry to the end of the audit log, creating it if needed.
+// Each entry is written as a single JSON line, so concurrent writers don't interleave.
+func appendAudit(path string, e AuditEntry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// readAudit returns every entry in the audit log, oldest first.
+// A missing file is an empty log.
+func readAudit(path string) ([]AuditEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("audit log %s line %d: %w", path, line, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// auditFilter selects audit entries; empty fields match everything
+type auditFilter struct {
+	CRN    string
+	Action string
+	Since  time.Time
+}
+
+// filter returns the entries matching f.
+func (f auditFilter) filter(entries []AuditEntry) []AuditEntry {
+	matched := []AuditEntry{}
+	for _, e := range entries {
+		if (f.CRN == "" || e.CRN == f.CRN) && (f.Action == "" || e.Action == f.Action) && !e.Time.Before(f.Since) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// serveAudit answers GET /v1/audit with the audit log's entries, filtered by the
+// optional crn, action, and since query parameters.
+func (s *agentServer) serveAudit(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	f := auditFilter{CRN: q.Get("crn"), Action: q.Get("action")}
+	if since := q.Get("since"); since != "" {
+		var err error
+		if f.Since, err = parseSince(since, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	entries, err := readAudit(s.cfg.AuditFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, f.filter(entries))
+}
+
+// runAudit prints the audit log.
+func runAudit(args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file`")
+	crn := fs.String("crn", "", "only show entries for this `CRN`")
+	action := fs.String("action", "", "only show this `action`: notification, watch_added, watch_removed, or enrolled")
+	since := fs.String("since", "", "only show entries after this long ago, like 7d or 12h, or a date like 2026-01-15")
+	asJSON := fs.Bool("json", false, "print the entries as JSON lines")
+	fs.Parse(args)
+
+	var f auditFilter
+	if *crn != "" {
+		if err := validateCRN(*crn); err != nil {
+			return configError(err)
+		}
+		f.CRN = *crn
+	}
+	f.Action = *action
+	if *since != "" {
+		var err error
+		if f.Since, err = parseSince(*since, time.Now()); err != nil {
+			return configError(err)
+		}
+	}
+
+	cfg, err := loadSearchConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+	cfg.defaultAuditFile(*configPath)
+
+	entries, err := readAudit(cfg.AuditFile)
+	if err != nil {
+		return err
+	}
+	entries = f.filter(entries)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	PrintAuditLog(cfg.AuditFile, entries)
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ===================
+// audit log tests
+// ===================
+
+func TestAudit_AppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	now := time.Date(2026, 1, 12, 9, 30, 0, 0, time.UTC)
+
+	appendAudit(path, AuditEntry{Time: now, Action: AuditWatchAdded, CRN: "12345", Actor: "alice", Via: ViaCtl})
+	appendAudit(path, AuditEntry{Time: now.Add(time.Hour), Action: AuditWatchRemoved, CRN: "12345", Actor: "alice", Via: ViaKeyboard})
+
+	entries, err := readAudit(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Action != AuditWatchAdded || entries[1].Via != ViaKeyboard {
+		t.Errorf("got %+v", entries)
+	}
+}
+
+func TestAudit_MissingFileIsEmpty(t *testing.T) {
+	entries, err := readAudit(filepath.Join(t.TempDir(), "none.jsonl"))
+	if err != nil || len(entries) != 0 {
+		t.Errorf("got %v, %v; want an empty log", entries, err)
+	}
+}
+
+func TestAuditFilter(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 30, 0, 0, time.UTC)
+	entries := []AuditEntry{
+		{Time: now.Add(-48 * time.Hour), Action: AuditNotification, CRN: "12345"},
+		{Time: now, Action: AuditNotification, CRN: "12346"},
+		{Time: now, Action: AuditWatchAdded, CRN: "12345"},
+	}
+	if got := (auditFilter{CRN: "12345"}).filter(entries); len(got) != 2 {
+		t.Errorf("expected two entries for the CRN, got %+v", got)
+	}
+	if got := (auditFilter{Action: AuditNotification, Since: now.Add(-time.Hour)}).filter(entries); len(got) != 1 || got[0].CRN != "12346" {
+		t.Errorf("expected the recent notification only, got %+v", got)
+	}
+}
+
+func TestNotify_Audited(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	cfg := Config{Email: "test@example.com", AuditFile: path, notifiers: []Notifier{&MockNotifier{ShouldError: true}}}
+	cfg.notify(&MockEmailSender{}, Alert{Event: "open", CRN: "12345", Subject: "open"})
+
+	entries, _ := readAudit(path)
+	if len(entries) != 2 {
+		t.Fatalf("expected one entry per channel, got %+v", entries)
+	}
+	if e := entries[0]; e.Channel != "email" || e.Recipient != "test@example.com" || e.Result != "sent" || e.CRN != "12345" || e.Event != "open" {
+		t.Errorf("expected the email delivery, got %+v", e)
+	}
+	if e := entries[1]; e.Channel != "Mock" || e.Result != "mock notifier error" {
+		t.Errorf("expected the failed delivery and why, got %+v", e)
+	}
+}
+
+func TestAgentServer_AuditsWatchChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	cfg := Config{CRNs: []string{"12345"}, Term: "202601", AuditFile: path, Server: AgentServer{Keys: []APIKey{
+		{Name: "admin", Token: "manage-secret", Scope: ScopeManage},
+		{Name: "dashboard", Token: "read-secret"},
+	}}}
+	cfg.Server.validate()
+	srv := cfg.newAgentServer(newMemoryStateStore(), &MockEmailSender{})
+
+	apiRequest(srv, "POST", "/v1/watches", "manage-secret", `{"crn": "12346"}`)
+	apiRequest(srv, "DELETE", "/v1/watches/12345", "manage-secret", "")
+
+	if rec := apiRequest(srv, "GET", "/v1/audit", "read-secret", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("expected the audit log to need manage scope, got %d", rec.Code)
+	}
+	rec := apiRequest(srv, "GET", "/v1/audit?action=watch_removed", "manage-secret", "")
+	var entries []AuditEntry
+	if err := json.NewDecoder(rec.Body).Decode(&entries); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].CRN != "12345" || entries[0].Actor != "admin" || entries[0].Via != ViaAPI {
+		t.Errorf("expected the removal by the admin key, got %+v", entries)
+	}
+}
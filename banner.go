@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	// bannerHidden suppresses the banner entirely
+	bannerHidden bool
+
+	// customBanner replaces the built-in ASCII art banner when set
+	customBanner string
+)
+
+// configureBanner hides the banner, or loads a custom one from a text file.
+// Without either, the banner is only shown when output goes to a terminal,
+// so logs and redirected output start straight with the useful lines.
+func configureBanner(hide bool, file string) error {
+	bannerHidden = hide || !isTerminal(os.Stdout)
+	customBanner = ""
+	if file == "" {
+		return nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read banner file: %w", err)
+	}
+	customBanner = strings.TrimRight(string(data), "\n")
+	return nil
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// disableIcons removes the Nerd Font icons from all output, for terminals
+// without a Nerd Font and for logs.
+func disableIcons() {
+	IconSearch, IconEmail, IconClock, IconCheck, IconX, IconBook = "", "", "", "", "", ""
+	IconTarget, IconBell, IconArrow, IconCalendar, IconGrad = "", "", "", "", ""
+}
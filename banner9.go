@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Data sources for section information
+const (
+	BackendHTML    = "html"    // scrape the legacy timetable search page (default)
+	BackendBanner9 = "banner9" // the Banner 9 StudentRegistrationSsb JSON API, falling back to the HTML timetable
+)
+
+// banner9PageSize is how many sections one Banner 9 search returns, enough for any single subject
+const banner9PageSize = 500
+
+// validateBackend checks the data source and that banner9 has an API to talk to.
+func (c Config) validateBackend() error {
+	switch c.Backend {
+	case BackendHTML:
+	case BackendBanner9:
+		if c.Banner9URL == "" {
+			return fmt.Errorf(`backend "banner9" needs banner9Url, e.g. https://<host>/StudentRegistrationSsb`)
+		}
+		if _, err := url.Parse(c.Banner9URL); err != nil {
+			return fmt.Errorf("invalid banner9Url: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid backend %q: must be %q or %q", c.Backend, BackendHTML, BackendBanner9)
+	}
+	return nil
+}
+
+// banner9Term is a Banner 9 session with a term selected. Banner 9 remembers the
+// selected term and the last search on the server, so each term gets its own
+// cookies and searches within a term run one at a time.
+type banner9Term struct {
+	mu     sync.Mutex
+	client *http.Client
+}
+
+// banner9Term returns the session for a term, selecting the term the first time.
+func (c Config) banner9Term(term string) (*banner9Term, error) {
+	if c.session == nil {
+		return c.selectBanner9Term(term)
+	}
+
+	s := c.session
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.banner9[term]; ok {
+		return t, nil
+	}
+	t, err := c.selectBanner9Term(term)
+	if err != nil {
+		return nil, err
+	}
+	if s.banner9 == nil {
+		s.banner9 = make(map[string]*banner9Term)
+	}
+	s.banner9[term] = t
+	return t, nil
+}
+
+// selectBanner9Term starts a fresh Banner 9 session and selects the term for class search.
+func (c Config) selectBanner9Term(term string) (*banner9Term, error) {
+	t := &banner9Term{client: c.newHTTPClient()}
+	endpoint := strings.TrimSuffix(c.Banner9URL, "/") + "/ssb/term/search?mode=search"
+	if _, err := t.do(http.MethodPost, endpoint, url.Values{"term": {term}}); err != nil {
+		return nil, fmt.Errorf("failed to select term %s: %w", term, err)
+	}
+	return t, nil
+}
+
+// do sends a Banner 9 request and returns the response body.
+// Form values are posted for POST and sent as the query string otherwise.
+func (t *banner9Term) do(method, endpoint string, values url.Values) ([]byte, error) {
+	var req *http.Request
+	var err error
+	if method == http.MethodPost {
+		req, err = http.NewRequest(method, endpoint, strings.NewReader(values.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequest(method, endpoint+"?"+values.Encode(), nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := readResponse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if isChallenge(raw) {
+		return nil, fmt.Errorf("%w (status %d): open the registration site in a browser and complete the check", ErrChallenge, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, resp.StatusCode, resp.Status)
+	}
+	return raw, nil
+}
+
+// banner9Results is the searchResults response
+type banner9Results struct {
+	Success bool             `json:"success"`
+	Data    []banner9Section `json:"data"`
+}
+
+// banner9Section is one section in Banner 9 search results. Only the fields OpenSeat uses are listed.
+type banner9Section struct {
+	CRN             string           `json:"courseReferenceNumber"`
+	Subject         string           `json:"subject"`
+	CourseNumber    string           `json:"courseNumber"`
+	Title           string           `json:"courseTitle"`
+	ScheduleType    string           `json:"scheduleTypeDescription"`
+	Modality        string           `json:"instructionalMethodDescription"`
+	CreditHours     *float64         `json:"creditHours"`
+	CreditHourLow   *float64         `json:"creditHourLow"`
+	SeatsAvailable  int              `json:"seatsAvailable"`
+	Capacity        int              `json:"maximumEnrollment"`
+	Faculty         []banner9Faculty `json:"faculty"`
+	MeetingsFaculty []struct {
+		MeetingTime banner9Meeting `json:"meetingTime"`
+	} `json:"meetingsFaculty"`
+}
+
+type banner9Faculty struct {
+	DisplayName string `json:"displayName"`
+	Primary     bool   `json:"primaryIndicator"`
+}
+
+type banner9Meeting struct {
+	BeginTime string `json:"beginTime"` // e.g. "1010"
+	EndTime   string `json:"endTime"`
+	Building  string `json:"building"`
+	Room      string `json:"room"`
+	Monday    bool   `json:"monday"`
+	Tuesday   bool   `json:"tuesday"`
+	Wednesday bool   `json:"wednesday"`
+	Thursday  bool   `json:"thursday"`
+	Friday    bool   `json:"friday"`
+	Saturday  bool   `json:"saturday"`
+	Sunday    bool   `json:"sunday"`
+}
+
+// days lists the meeting days the way the HTML timetable does, e.g. "M W F".
+func (m banner9Meeting) days() string {
+	var days []string
+	for _, d := range []struct {
+		meets  bool
+		letter string
+	}{{m.Monday, "M"}, {m.Tuesday, "T"}, {m.Wednesday, "W"}, {m.Thursday, "R"}, {m.Friday, "F"}, {m.Saturday, "S"}, {m.Sunday, "U"}} {
+		if d.meets {
+			days = append(days, d.letter)
+		}
+	}
+	if len(days) == 0 {
+		return "(ARR)"
+	}
+	return strings.Join(days, " ")
+}
+
+// clockTime converts Banner 9's "1400" to the HTML timetable's "2:00PM".
+func clockTime(hhmm string) string {
+	t, err := time.Parse("1504", hhmm)
+	if err != nil {
+		return hhmm
+	}
+	return t.Format("3:04PM")
+}
+
+// section converts the result to the Section the HTML timetable would have produced.
+func (s banner9Section) section() Section {
+	section := Section{
+		CRN:      s.CRN,
+		Course:   s.Subject + "-" + s.CourseNumber,
+		Title:    s.Title,
+		Type:     s.ScheduleType,
+		Modality: s.Modality,
+		Seats:    strconv.Itoa(s.SeatsAvailable),
+		Capacity: strconv.Itoa(s.Capacity),
+	}
+	credits := s.CreditHours
+	if credits == nil {
+		credits = s.CreditHourLow // variable-credit sections only list a range
+	}
+	if credits != nil {
+		section.Credits = strconv.FormatFloat(*credits, 'f', -1, 64)
+	}
+	for _, f := range s.Faculty {
+		if f.Primary || section.Instructor == "" {
+			section.Instructor = f.DisplayName
+		}
+	}
+	if len(s.MeetingsFaculty) > 0 {
+		m := s.MeetingsFaculty[0].MeetingTime
+		section.Days = m.days()
+		if m.BeginTime != "" {
+			section.Begin, section.End = clockTime(m.BeginTime), clockTime(m.EndTime)
+		}
+		section.Location = strings.TrimSpace(m.Building + " " + m.Room)
+	}
+	return section
+}
+
+// banner9Sections runs a search against the Banner 9 API for the config's term.
+// Campus codes differ between Banner 9 and the HTML timetable, so sections from
+// every campus are returned. The response to an identical search made within
+// cacheSeconds is reused.
+func (c Config) banner9Sections(q searchQuery) ([]Section, error) {
+	params := url.Values{
+		"txt_term":      {c.Term},
+		"pageOffset":    {"0"},
+		"pageMaxSize":   {strconv.Itoa(banner9PageSize)},
+		"sortColumn":    {"subjectDescription"},
+		"sortDirection": {"asc"},
+	}
+	if q.CRN != "" {
+		params.Set("txt_courseReferenceNumber", q.CRN)
+	}
+	if q.Subject != "" {
+		params.Set("txt_subject", q.Subject)
+	}
+	if q.Number != "" {
+		params.Set("txt_courseNumber", q.Number)
+	}
+	if session := c.getSessionCode(); session != AllSessions {
+		params.Set("txt_partOfTerm", session)
+	}
+	if q.OpenOnly {
+		params.Set("chk_open_only", "true")
+	}
+	return cachedSearch(c, "banner9 "+c.Banner9URL+"?"+params.Encode(), func() ([]Section, error) {
+		c.session.pace()
+		return c.fetchBanner9(q, params)
+	})
+}
+
+// fetchBanner9 sends a Banner 9 search, resetting the search form first.
+func (c Config) fetchBanner9(q searchQuery, params url.Values) (_ []Section, err error) {
+	c, span := c.startSpan("search", attribute.String("term", c.Term), attribute.String("backend", BackendBanner9),
+		attribute.String("crn", q.CRN), attribute.String("subject", q.Subject))
+	defer func(start time.Time) {
+		endSpan(span, err)
+		c.recordSearch(start, err)
+		c.latency.record(c.Term, "", time.Since(start), err) // Banner 9 searches every campus at once
+	}(time.Now())
+
+	t, err := c.banner9Term(c.Term)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	base := strings.TrimSuffix(c.Banner9URL, "/") + "/ssb"
+	// Banner 9 answers a repeated search with the previous results unless the form is reset
+	if _, err := t.do(http.MethodPost, base+"/classSearch/resetDataForm", nil); err != nil {
+		return nil, err
+	}
+
+	raw, err := t.do(http.MethodGet, base+"/searchResults/searchResults", params)
+	if err != nil {
+		return nil, err
+	}
+	var results banner9Results
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(&results); err != nil {
+		return nil, fmt.Errorf("%w from Banner 9: %v", ErrUnexpectedPage, err)
+	}
+	if !results.Success {
+		return nil, fmt.Errorf("%w from Banner 9: search was not successful", ErrUnexpectedPage)
+	}
+
+	var sections []Section
+	for _, s := range results.Data {
+		if q.OpenOnly && s.SeatsAvailable <= 0 {
+			continue
+		}
+		sections = append(sections, s.section())
+	}
+	return sections, nil
+}
+
+// searchBanner9 runs the search against Banner 9 when it's the configured backend.
+// ok is false when the HTML timetable should be searched instead: either the
+// backend is html or the Banner 9 API failed, which is reported once per run.
+func (c Config) searchBanner9(q searchQuery) ([]Section, bool) {
+	if c.Backend != BackendBanner9 {
+		return nil, false
+	}
+	sections, err := c.banner9Sections(q)
+	if err != nil {
+		if c.session != nil {
+			c.session.banner9Fallback.Do(func() { PrintBanner9Fallback(err) })
+			// the session may have expired, so the next search starts a new one
+			c.session.mu.Lock()
+			delete(c.session.banner9, c.Term)
+			c.session.mu.Unlock()
+		}
+		return nil, false
+	}
+	return sections, true
+}
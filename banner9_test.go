@@ -0,0 +1,111 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// banner9Server fakes the Banner 9 class search API, listing sections as JSON once a term is selected.
+// Requests to anything else get the HTML timetable's results table.
+func banner9Server(t *testing.T, results string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/StudentRegistrationSsb/ssb/term/search":
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "abc", Path: "/StudentRegistrationSsb"})
+			w.Write([]byte(`{"fwdURL": "/StudentRegistrationSsb/ssb/classSearch/classSearch"}`))
+		case "/StudentRegistrationSsb/ssb/classSearch/resetDataForm":
+			w.Write([]byte(`true`))
+		case "/StudentRegistrationSsb/ssb/searchResults/searchResults":
+			if _, err := r.Cookie("JSESSIONID"); err != nil || r.URL.Query().Get("txt_term") != "202601" {
+				w.Write([]byte(`{"success": false}`))
+				return
+			}
+			w.Write([]byte(results))
+		default:
+			w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td><td>CS-3114</td><td>Data Structures (HTML)</td></tr></table>`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+const banner9Results12345 = `{"success": true, "totalCount": 1, "data": [{
+	"courseReferenceNumber": "12345", "subject": "CS", "courseNumber": "3114",
+	"courseTitle": "Data Structures", "scheduleTypeDescription": "Lecture",
+	"creditHours": null, "creditHourLow": 3, "seatsAvailable": 2, "maximumEnrollment": 100,
+	"faculty": [{"displayName": "Shaffer, Clifford", "primaryIndicator": true}],
+	"meetingsFaculty": [{"meetingTime": {"beginTime": "1400", "endTime": "1515", "building": "MCB", "room": "100", "tuesday": true, "thursday": true}}]
+}]}`
+
+func banner9Config(t *testing.T, server *httptest.Server) Config {
+	t.Helper()
+	cfg := Config{CRNs: []string{"12345"}, Term: "202601", BaseURL: server.URL, Backend: BackendBanner9, Banner9URL: server.URL + "/StudentRegistrationSsb"}
+	if err := cfg.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return cfg
+}
+
+// ===================
+// banner9 backend tests
+// ===================
+
+func TestBanner9_Sections(t *testing.T) {
+	cfg := banner9Config(t, banner9Server(t, banner9Results12345))
+
+	sections, err := cfg.banner9Sections(searchQuery{CRN: "12345"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Section{CRN: "12345", Course: "CS-3114", Title: "Data Structures", Type: "Lecture", Credits: "3", Seats: "2", Capacity: "100",
+		Instructor: "Shaffer, Clifford", Days: "T R", Begin: "2:00PM", End: "3:15PM", Location: "MCB 100"}
+	if len(sections) != 1 || sections[0] != want {
+		t.Errorf("got %+v, want %+v", sections, want)
+	}
+}
+
+func TestBanner9_CheckSectionOpen(t *testing.T) {
+	cfg := banner9Config(t, banner9Server(t, banner9Results12345))
+
+	name, err := cfg.getCourseName("12345")
+	if err != nil || name != "Data Structures" {
+		t.Errorf("getCourseName = %q, %v; want the Banner 9 title", name, err)
+	}
+	open, err := cfg.checkSectionOpen("12345")
+	if err != nil || !open {
+		t.Errorf("checkSectionOpen = %v, %v; want open", open, err)
+	}
+}
+
+func TestBanner9_FullSectionIsNotOpen(t *testing.T) {
+	cfg := banner9Config(t, banner9Server(t, `{"success": true, "data": [{"courseReferenceNumber": "12345", "seatsAvailable": 0}]}`))
+
+	open, err := cfg.checkSectionOpen("12345")
+	if err != nil || open {
+		t.Errorf("checkSectionOpen = %v, %v; want closed", open, err)
+	}
+}
+
+func TestBanner9_FallsBackToHTML(t *testing.T) {
+	cfg := banner9Config(t, banner9Server(t, `<html>Service Unavailable</html>`))
+
+	name, err := cfg.getCourseName("12345")
+	if err != nil || name != "Data Structures (HTML)" {
+		t.Errorf("getCourseName = %q, %v; want the HTML timetable's title", name, err)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidBackend(t *testing.T) {
+	tests := map[string]string{
+		"unknown backend": `{"crns": ["12345"], "backend": "graphql"}`,
+		"no banner9Url":   `{"crns": ["12345"], "backend": "banner9"}`,
+	}
+
+	for name, content := range tests {
+		if _, err := loadConfig(createTempConfig(t, content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
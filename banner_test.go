@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ===================
+// banner tests
+// ===================
+
+func TestConfigureBanner_CustomFile(t *testing.T) {
+	defer configureBanner(false, "")
+	path := filepath.Join(t.TempDir(), "banner.txt")
+	os.WriteFile(path, []byte("GO HOKIES\n\n"), 0o644)
+
+	if err := configureBanner(false, path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bannerHidden = false // test output isn't a terminal
+
+	var buf bytes.Buffer
+	out = &buf
+	defer func() { out = os.Stdout }()
+	PrintBanner()
+
+	if !strings.Contains(buf.String(), "GO HOKIES") || strings.Contains(buf.String(), "██") {
+		t.Errorf("got %q, want only the custom banner", buf.String())
+	}
+}
+
+func TestConfigureBanner_MissingFile(t *testing.T) {
+	defer configureBanner(false, "")
+	if err := configureBanner(false, filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected error for a missing banner file")
+	}
+}
+
+func TestConfigureBanner_HiddenWhenNotATerminal(t *testing.T) {
+	defer configureBanner(false, "")
+	configureBanner(false, "")
+
+	// go test captures stdout, so it isn't a terminal
+	if !bannerHidden {
+		t.Error("expected the banner to be hidden when output isn't a terminal")
+	}
+}
+
+func TestDisableIcons(t *testing.T) {
+	saved := []string{IconSearch, IconEmail, IconClock, IconCheck, IconX, IconBook, IconTarget, IconBell, IconArrow, IconCalendar, IconGrad}
+	defer func() {
+		IconSearch, IconEmail, IconClock, IconCheck, IconX, IconBook = saved[0], saved[1], saved[2], saved[3], saved[4], saved[5]
+		IconTarget, IconBell, IconArrow, IconCalendar, IconGrad = saved[6], saved[7], saved[8], saved[9], saved[10]
+	}()
+
+	disableIcons()
+	for _, icon := range []string{IconSearch, IconEmail, IconClock, IconCheck, IconX, IconBook, IconTarget, IconBell, IconArrow, IconCalendar, IconGrad} {
+		if icon != "" {
+			t.Errorf("icon %q still set", icon)
+		}
+	}
+}
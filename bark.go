@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBarkServer is the public Bark server
+const DefaultBarkServer = "https://api.day.app"
+
+// Bark configures iOS push alerts through the Bark app (github.com/Finb/Bark),
+// which shows them on the lock screen without a custom APNs integration.
+type Bark struct {
+	Server    string `json:"server"`    // Bark server (defaults to https://api.day.app)
+	DeviceKey string `json:"deviceKey"` // key shown in the Bark app
+	Critical  bool   `json:"critical"`  // critical alerts, such as seat openings, ring even in silent mode or Focus
+	Sound     string `json:"sound"`     // alert sound from the Bark app's list, e.g. "alarm" (optional)
+}
+
+// applyDefaults fills in the server and checks its URL.
+func (b *Bark) applyDefaults() error {
+	if b.DeviceKey == "" {
+		return nil
+	}
+	if b.Server == "" {
+		b.Server = DefaultBarkServer
+	}
+	u, err := url.Parse(b.Server)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid bark server %q: expected a URL like %s", b.Server, DefaultBarkServer)
+	}
+	b.Server = strings.TrimSuffix(b.Server, "/")
+	return nil
+}
+
+// barkNotifier pushes alerts to an iPhone through Bark
+type barkNotifier struct {
+	Bark
+}
+
+func (n *barkNotifier) Name() string { return "Bark" }
+
+// Notify pushes the alert. Critical alerts, such as seat openings, break through
+// Focus modes as time-sensitive, or as critical alerts when configured; everything
+// else is a normal notification.
+func (n *barkNotifier) Notify(a Alert) error {
+	level := "active"
+	if a.severity() == SeverityCritical {
+		level = "timeSensitive"
+		if n.Critical {
+			level = "critical"
+		}
+	}
+
+	payload, err := json.Marshal(struct {
+		DeviceKey string `json:"device_key"`
+		Title     string `json:"title"`
+		Body      string `json:"body"`
+		Level     string `json:"level"`
+		Sound     string `json:"sound,omitempty"`
+		Group     string `json:"group"`
+	}{n.DeviceKey, a.Subject, a.Body, level, n.Sound, "OpenSeat"})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.Server+"/push", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	return sendAPIRequest("bark", req)
+}
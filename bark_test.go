@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// barkPush is what Bark receives
+type barkPush struct {
+	DeviceKey string `json:"device_key"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	Level     string `json:"level"`
+	Sound     string `json:"sound"`
+}
+
+// barkServer records every push sent to it
+func barkServer(t *testing.T) (string, *[]barkPush) {
+	var pushes []barkPush
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/push" {
+			t.Errorf("path = %q, want /push", r.URL.Path)
+		}
+		var p barkPush
+		json.NewDecoder(r.Body).Decode(&p)
+		pushes = append(pushes, p)
+		w.Write([]byte(`{"code":200,"message":"success"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &pushes
+}
+
+// ===================
+// Bark config tests
+// ===================
+
+func TestBarkApplyDefaults(t *testing.T) {
+	b := Bark{DeviceKey: "key"}
+	if err := b.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Server != DefaultBarkServer {
+		t.Errorf("Server = %q, want %q", b.Server, DefaultBarkServer)
+	}
+
+	b = Bark{DeviceKey: "key", Server: "bark.example.com"}
+	if err := b.applyDefaults(); err == nil {
+		t.Error("expected error for a server without a scheme")
+	}
+}
+
+// ===================
+// barkNotifier tests
+// ===================
+
+func TestBarkNotify(t *testing.T) {
+	server, pushes := barkServer(t)
+	n := &barkNotifier{Bark{Server: server, DeviceKey: "key", Sound: "alarm"}}
+
+	if err := n.Notify(Alert{Event: EventOpen, Subject: "VT Course Section Open!", Body: "OPEN SEAT: Intro to Testing (CRN: 12345)", CRN: "12345"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := n.Notify(Alert{Event: AlertSummary, Subject: "OpenSeat weekly summary", Body: "..."}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*pushes) != 2 {
+		t.Fatalf("expected 2 pushes, got %d", len(*pushes))
+	}
+	open := (*pushes)[0]
+	if open.DeviceKey != "key" || open.Title != "VT Course Section Open!" || open.Body != "OPEN SEAT: Intro to Testing (CRN: 12345)" || open.Sound != "alarm" {
+		t.Errorf("unexpected push: %+v", open)
+	}
+	if open.Level != "timeSensitive" {
+		t.Errorf("opening level = %q, want timeSensitive", open.Level)
+	}
+	if level := (*pushes)[1].Level; level != "active" {
+		t.Errorf("summary level = %q, want active", level)
+	}
+}
+
+func TestBarkNotify_Critical(t *testing.T) {
+	server, pushes := barkServer(t)
+	n := &barkNotifier{Bark{Server: server, DeviceKey: "key", Critical: true}}
+
+	n.Notify(Alert{Event: EventOpen, Subject: "VT Course Section Open!"})
+	n.Notify(Alert{Event: AlertHint, Subject: "CRN 12345 may open soon"})
+	n.Notify(Alert{Event: AlertGroupOpen, Subject: "Intro to Algorithms + Lab open"})
+	n.Notify(Alert{Event: AlertChallenge, Subject: "Timetable is showing a bot check"})
+
+	if (*pushes)[0].Level != "critical" || (*pushes)[1].Level != "active" || (*pushes)[2].Level != "critical" || (*pushes)[3].Level != "active" {
+		t.Errorf("expected only the critical alerts to be critical, got %+v", *pushes)
+	}
+}
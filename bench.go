@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Defaults for `openseat bench`
+const (
+	DefaultBenchWatches = 100
+	DefaultBenchRounds  = 5
+	DefaultBenchRows    = 50
+	benchTerm           = "202601"
+)
+
+// benchTimetable is a mock timetable for `openseat bench`. Every search returns a
+// full page of sections, built once up front so the mock's own work stays small
+// next to OpenSeat's. Sections with an even CRN are open.
+type benchTimetable struct {
+	rows []string // result rows after the searched section's
+}
+
+// newBenchTimetable builds the rows served after each searched section.
+func newBenchTimetable(rows int) *benchTimetable {
+	b := &benchTimetable{}
+	for i := 1; i < rows; i++ {
+		b.rows = append(b.rows, benchRow(strconv.Itoa(90000+i)))
+	}
+	return b
+}
+
+// benchRow renders one results row with every column OpenSeat reads.
+func benchRow(crn string) string {
+	return fmt.Sprintf(`<tr><td class="dedefault">%s</td><td class="dedefault">BNCH-%s</td><td class="dedefault">Benchmark Section %s</td>`+
+		`<td class="dedefault">L</td><td class="dedefault">Face-to-Face Instruction</td><td class="dedefault">3</td>`+
+		`<td class="dedefault">Full</td><td class="dedefault">40</td><td class="dedefault">0</td><td class="dedefault">Hokie Bird</td>`+
+		`<td class="dedefault">M W F</td><td class="dedefault">10:10AM</td><td class="dedefault">11:00AM</td>`+
+		`<td class="dedefault">TORG 1020</td><td class="dedefault">07M</td></tr>`+"\n", crn, crn[1:], crn)
+}
+
+func (b *benchTimetable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		fmt.Fprintf(w, `<html><form><select name="TERMYEAR"><option value="%s">Benchmark Term</option></select></form></html>`, benchTerm)
+		return
+	}
+
+	r.ParseForm()
+	crn := r.FormValue("crn")
+	if validateCRN(crn) != nil || (r.FormValue("open_only") == "on" && (crn[len(crn)-1]-'0')%2 == 1) {
+		fmt.Fprint(w, `<html><b>NO SECTIONS FOUND FOR THIS INQUIRY.</b></html>`)
+		return
+	}
+
+	fmt.Fprint(w, `<html><body><table class="dataentrytable">
+<tr><td>CRN</td><td>Course</td><td>Title</td><td>Schedule Type</td><td>Modality</td><td>Cr Hrs</td><td>Seats</td>`+
+		`<td>Capacity</td><td>Waitlist</td><td>Instructor</td><td>Days</td><td>Begin</td><td>End</td><td>Location</td><td>Exam</td></tr>
+`)
+	fmt.Fprint(w, benchRow(crn))
+	for _, row := range b.rows {
+		fmt.Fprint(w, row)
+	}
+	fmt.Fprint(w, "</table></body></html>")
+}
+
+// benchResult is what `openseat bench` measured
+type benchResult struct {
+	Watches       int     `json:"watches"`
+	Rounds        int     `json:"rounds"`
+	Rows          int     `json:"rows"`          // sections on each results page
+	Checks        int     `json:"checks"`        // open-seat checks run end to end
+	Open          int     `json:"open"`          // checks that found a seat, as a sanity check
+	ChecksPerSec  float64 `json:"checksPerSec"`  // end-to-end throughput, mock timetable included
+	CheckAllocs   uint64  `json:"checkAllocs"`   // heap allocations per check, mock timetable included
+	CheckBytes    uint64  `json:"checkBytes"`    // bytes allocated per check, mock timetable included
+	Pages         int     `json:"pages"`         // results pages parsed
+	ParseMicros   float64 `json:"parseMicros"`   // time to parse one results page
+	ParseAllocs   uint64  `json:"parseAllocs"`   // heap allocations per parsed page
+	ParseBytes    uint64  `json:"parseBytes"`    // bytes allocated per parsed page
+	ParseSections int     `json:"parseSections"` // sections found per page, as a sanity check
+}
+
+// runBench measures check throughput, parse time, and allocations against a mock timetable.
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	watches := fs.Int("watches", DefaultBenchWatches, "number of synthetic CRNs to watch")
+	rounds := fs.Int("rounds", DefaultBenchRounds, "times each CRN is checked and its page parsed")
+	rows := fs.Int("rows", DefaultBenchRows, "sections on each results page")
+	asJSON := fs.Bool("json", false, "print the results as JSON, for comparing runs")
+	fs.Parse(args)
+
+	if *watches < 1 || *watches > 90000 || *rounds < 1 || *rows < 1 {
+		return configError(fmt.Errorf("--watches must be 1 to 90000, and --rounds and --rows at least 1"))
+	}
+
+	searchURL, stop, err := serveTimetable(newBenchTimetable(*rows))
+	if err != nil {
+		return fmt.Errorf("failed to start benchmark timetable: %w", err)
+	}
+	defer stop()
+
+	// a fresh config, so the user's settings don't skew the numbers
+	cfg := Config{Term: benchTerm, BaseURL: searchURL}
+	if err := cfg.applyDefaults(); err != nil {
+		return err
+	}
+
+	result, err := cfg.bench(*watches, *rounds, *rows)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	PrintBenchResult(result)
+	return nil
+}
+
+// bench checks every synthetic CRN rounds times, then parses each CRN's full
+// results page rounds times, measuring both.
+func (c Config) bench(watches, rounds, rows int) (benchResult, error) {
+	r := benchResult{Watches: watches, Rounds: rounds, Rows: rows}
+	crns := make([]string, watches)
+	for i := range crns {
+		crns[i] = strconv.Itoa(10000 + i)
+	}
+
+	var err error
+	elapsed, allocs, allocated := measure(func() {
+		for range rounds {
+			for _, crn := range crns {
+				var open bool
+				if open, err = c.checkSectionOpen(crn); err != nil {
+					return
+				}
+				r.Checks++
+				if open {
+					r.Open++
+				}
+			}
+		}
+	})
+	if err != nil {
+		return r, fmt.Errorf("benchmark check failed: %w", err)
+	}
+	r.ChecksPerSec = float64(r.Checks) / elapsed.Seconds()
+	r.CheckAllocs, r.CheckBytes = allocs/uint64(r.Checks), allocated/uint64(r.Checks)
+
+	// fetch the pages first so only parsing is measured
+	pages := make([][]byte, watches)
+	for i, crn := range crns {
+		doc, err := c.search(crn, false)
+		if err != nil {
+			return r, fmt.Errorf("benchmark search failed: %w", err)
+		}
+		pages[i] = doc.raw
+	}
+	elapsed, allocs, allocated = measure(func() {
+		for range rounds {
+			for _, raw := range pages {
+				var doc *goquery.Document
+				if doc, err = goquery.NewDocumentFromReader(bytes.NewReader(raw)); err != nil {
+					return
+				}
+				r.ParseSections = len(parseSections(doc))
+				r.Pages++
+			}
+		}
+	})
+	if err != nil {
+		return r, fmt.Errorf("benchmark parse failed: %w", err)
+	}
+	r.ParseMicros = float64(elapsed.Microseconds()) / float64(r.Pages)
+	r.ParseAllocs, r.ParseBytes = allocs/uint64(r.Pages), allocated/uint64(r.Pages)
+	return r, nil
+}
+
+// measure runs f, returning how long it took and the heap allocations and bytes
+// allocated meanwhile. The counts are process-wide, so they include anything else
+// running at the time.
+func measure(f func()) (elapsed time.Duration, allocs, allocated uint64) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	f()
+	elapsed = time.Since(start)
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, after.TotalAlloc - before.TotalAlloc
+}
+
+// benchSize formats a byte count for the bench report.
+func benchSize(n uint64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+)
+
+// ===================
+// bench tests
+// ===================
+
+func TestBench(t *testing.T) {
+	searchURL, stop, err := serveTimetable(newBenchTimetable(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	cfg := Config{Term: benchTerm, BaseURL: searchURL}
+	if err := cfg.applyDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := cfg.bench(4, 2, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.Checks != 8 || r.Open != 4 {
+		t.Errorf("expected 8 checks with every even CRN open, got %d checks, %d open", r.Checks, r.Open)
+	}
+	if r.Pages != 8 || r.ParseSections != 10 {
+		t.Errorf("expected 8 pages of 10 sections, got %d pages of %d", r.Pages, r.ParseSections)
+	}
+	if r.ChecksPerSec <= 0 || r.CheckAllocs == 0 || r.ParseAllocs == 0 {
+		t.Errorf("expected measurements, got %+v", r)
+	}
+}
+
+func TestBenchTimetable_ParsesEveryColumn(t *testing.T) {
+	searchURL, stop, err := serveTimetable(newBenchTimetable(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	cfg := Config{Term: benchTerm, BaseURL: searchURL}
+	if err := cfg.applyDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	section, err := cfg.getSection("10001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if section.Title != "Benchmark Section 10001" || section.Instructor == "" || section.Location == "" || section.Exam == "" {
+		t.Errorf("expected every column filled in, got %+v", section)
+	}
+}
+
+func TestBenchSize(t *testing.T) {
+	tests := map[uint64]string{512: "512 B", 2048: "2.0 KiB", 3 << 20: "3.0 MiB"}
+	for n, want := range tests {
+		if got := benchSize(n); got != want {
+			t.Errorf("benchSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"strings"
+)
+
+// browseStage is which list the course browser is showing
+type browseStage int
+
+const (
+	browseSubject  browseStage = iota // a subject code is being typed
+	browseCourses                     // picking a course in the subject
+	browseSections                    // picking a section of the course
+)
+
+// browseCourse is one course in the browsed subject, with its sections
+type browseCourse struct {
+	Code     string
+	Title    string
+	Sections []Section
+	Open     int // sections with open seats
+}
+
+// browser is the state of the course browser opened with b while watching. It
+// lists a subject's courses, then a course's sections with their seats, and adds
+// the picked section to the watch.
+type browser struct {
+	stage   browseStage
+	input   string // subject code typed so far
+	subject string // subject whose courses are listed
+	courses []browseCourse
+	open    map[string]bool // CRNs with open seats
+	course  int             // highlighted course
+	section int             // highlighted section of the course
+}
+
+// handleBrowseKey applies a keypress while the browser is open. keyBrowse asks
+// the watch loop to search the returned subject, keyList to list what the
+// browser now shows, and keyAdd to watch the returned CRN.
+func (ctl *controls) handleBrowseKey(k byte) (keyAction, string) {
+	b := ctl.browsing
+	if k == 27 { // Esc
+		ctl.browsing = nil
+		return keyNone, ""
+	}
+	back := k == 127 || k == '\b' || k == 'h'
+
+	switch b.stage {
+	case browseSubject:
+		switch {
+		case (k >= 'a' && k <= 'z' || k >= 'A' && k <= 'Z') && len(b.input) < 5:
+			b.input += strings.ToUpper(string(k))
+		case (k == 127 || k == '\b') && b.input != "":
+			b.input = b.input[:len(b.input)-1]
+		case (k == '\r' || k == '\n') && subjectPattern.MatchString(b.input):
+			return keyBrowse, b.input
+		}
+
+	case browseCourses:
+		switch {
+		case k == 'j':
+			b.course = min(b.course+1, len(b.courses)-1)
+		case k == 'k':
+			b.course = max(b.course-1, 0)
+		case k == '\r' || k == '\n' || k == 'l':
+			b.stage, b.section = browseSections, 0
+			return keyList, ""
+		case k == 'r':
+			return keyBrowse, b.subject
+		case back:
+			b.stage = browseSubject
+		}
+
+	case browseSections:
+		sections := b.courses[b.course].Sections
+		switch {
+		case k == 'j':
+			b.section = min(b.section+1, len(sections)-1)
+		case k == 'k':
+			b.section = max(b.section-1, 0)
+		case k == '\r' || k == '\n' || k == 'a':
+			return keyAdd, sections[b.section].CRN
+		case k == 'r':
+			return keyBrowse, b.subject
+		case back:
+			b.stage = browseCourses
+			return keyList, ""
+		}
+	}
+	return keyNone, ""
+}
+
+// load searches a subject for every section and which have open seats, and lists
+// its courses. Refreshing the same subject keeps the browser where it was.
+func (b *browser) load(c Config, subject string) error {
+	all, err := c.findSections(searchQuery{Subject: subject}, "subject "+subject)
+	if err != nil {
+		return err
+	}
+	open, err := c.findSections(searchQuery{Subject: subject, OpenOnly: true}, "subject "+subject)
+	if err != nil {
+		return err
+	}
+	b.open = make(map[string]bool)
+	seats := make(map[string]string)
+	for _, s := range open {
+		b.open[s.CRN], seats[s.CRN] = true, s.Seats
+	}
+
+	var courses []browseCourse
+	for _, s := range filterLevel(all, c.Level) {
+		if s.Seats == "" {
+			s.Seats = seats[s.CRN]
+		}
+		if n := len(courses); n == 0 || courses[n-1].Code != s.Course {
+			courses = append(courses, browseCourse{Code: s.Course, Title: s.Title})
+		}
+		course := &courses[len(courses)-1]
+		course.Sections = append(course.Sections, s)
+		if b.open[s.CRN] {
+			course.Open++
+		}
+	}
+
+	if subject != b.subject {
+		b.course, b.section = 0, 0
+	}
+	b.subject, b.courses = subject, courses
+	if len(courses) == 0 {
+		b.stage = browseSubject
+		return nil
+	}
+	if b.stage == browseSubject {
+		b.stage = browseCourses
+	}
+	b.course = min(b.course, len(courses)-1)
+	b.section = min(b.section, len(courses[b.course].Sections)-1)
+	return nil
+}
+
+// status describes the browser for the status line: what's being typed, or the
+// highlighted course or section.
+func (b *browser) status() string {
+	switch b.stage {
+	case browseCourses:
+		course := b.courses[b.course]
+		return T("browse.course_status", course.Code, truncateString(course.Title, 30), len(course.Sections), course.Open, b.course+1, len(b.courses))
+	case browseSections:
+		sections := b.courses[b.course].Sections
+		s := sections[b.section]
+		return T("browse.section_status", s.CRN, b.seats(s), b.section+1, len(sections))
+	}
+	return T("browse.subject", b.input)
+}
+
+// seats describes a section's seats, e.g. "3 seats" or "full".
+func (b *browser) seats(s Section) string {
+	switch {
+	case !b.open[s.CRN]:
+		return T("browse.full")
+	case s.Seats != "":
+		return T("open.seats", s.Seats)
+	}
+	return T("browse.open")
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// subjectHTML lists three sections of two CS courses
+const subjectHTML = `
+<table class="dataentrytable">
+	<tr><td>CRN</td><td>Course</td><td>Title</td><td>Instructor</td><td>Days</td><td>Begin</td><td>End</td></tr>
+	<tr><td>13466</td><td>CS-3114</td><td>Data Structures</td><td>C Shaffer</td><td>M W F</td><td>10:10AM</td><td>11:00AM</td></tr>
+	<tr><td>13467</td><td>CS-3114</td><td>Data Structures</td><td>C Shaffer</td><td>T R</td><td>2:00PM</td><td>3:15PM</td></tr>
+	<tr><td>13470</td><td>CS-3214</td><td>Computer Systems</td><td>G Back</td><td>M W</td><td>4:00PM</td><td>5:15PM</td></tr>
+</table>`
+
+// subjectOpenHTML lists the one open section, with its seats
+const subjectOpenHTML = `
+<table class="dataentrytable">
+	<tr><td>CRN</td><td>Course</td><td>Title</td><td>Seats</td></tr>
+	<tr><td>13467</td><td>CS-3114</td><td>Data Structures</td><td>3</td></tr>
+</table>`
+
+func subjectServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.FormValue("open_only") == "on" {
+			w.Write([]byte(subjectOpenHTML))
+			return
+		}
+		w.Write([]byte(subjectHTML))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// typeKeys presses each key in turn, returning the last action and value.
+func typeKeys(ctl *controls, keys string) (keyAction, string) {
+	var action keyAction
+	var value string
+	for _, k := range []byte(keys) {
+		action, value = ctl.handleKey(k, nil)
+	}
+	return action, value
+}
+
+// ===================
+// browser tests
+// ===================
+
+func TestBrowser_TypeSubject(t *testing.T) {
+	ctl := &controls{}
+	if action, subject := typeKeys(ctl, "bcs\r"); action != keyBrowse || subject != "CS" {
+		t.Errorf("expected a search for CS, got %v, %q", action, subject)
+	}
+	if !strings.Contains(ctl.status(nil), "CS_") {
+		t.Errorf("expected the typed subject in the status line, got %q", ctl.status(nil))
+	}
+	typeKeys(ctl, "\x1b")
+	if ctl.browsing != nil {
+		t.Error("expected Esc to close the browser")
+	}
+}
+
+func TestBrowser_LoadGroupsCourses(t *testing.T) {
+	server := subjectServer(t)
+	cfg := Config{BaseURL: server.URL, Term: "202601", Campus: "0"}
+
+	b := &browser{}
+	if err := b.load(cfg, "CS"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.stage != browseCourses || len(b.courses) != 2 {
+		t.Fatalf("expected two courses listed, got %+v", b.courses)
+	}
+	if c := b.courses[0]; c.Code != "CS-3114" || len(c.Sections) != 2 || c.Open != 1 {
+		t.Errorf("expected CS-3114's two sections, one open, got %+v", c)
+	}
+	if got := b.seats(b.courses[0].Sections[1]); got != T("open.seats", "3") {
+		t.Errorf("expected the open section's seats, got %q", got)
+	}
+	if got := b.seats(b.courses[0].Sections[0]); got != T("browse.full") {
+		t.Errorf("expected the other section full, got %q", got)
+	}
+}
+
+func TestBrowser_PickSectionToWatch(t *testing.T) {
+	server := subjectServer(t)
+	cfg := Config{BaseURL: server.URL, Term: "202601", Campus: "0"}
+
+	ctl := &controls{browsing: &browser{}}
+	if err := ctl.browsing.load(cfg, "CS"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if action, _ := typeKeys(ctl, "\r"); action != keyList || ctl.browsing.stage != browseSections {
+		t.Fatalf("expected Enter to open the course, got %v", action)
+	}
+	if action, crn := typeKeys(ctl, "j\r"); action != keyAdd || crn != "13467" {
+		t.Errorf("expected the second section added, got %v, %q", action, crn)
+	}
+	if action, _ := typeKeys(ctl, "hj"); action != keyNone || ctl.browsing.course != 1 {
+		t.Errorf("expected to be back on the course list, on the next course, got %v, %+v", action, ctl.browsing)
+	}
+
+	// refreshing keeps the browser on the same course
+	if action, subject := typeKeys(ctl, "r"); action != keyBrowse || subject != "CS" {
+		t.Fatalf("expected a refresh of CS, got %v, %q", action, subject)
+	}
+	ctl.browsing.load(cfg, "CS")
+	if ctl.browsing.course != 1 {
+		t.Errorf("expected the highlight kept after refreshing, got %d", ctl.browsing.course)
+	}
+}
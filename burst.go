@@ -0,0 +1,40 @@
+package main
+
+import "time"
+
+// DefaultBurstMinutes is how long a burst lasts when burstMinutes isn't configured
+const DefaultBurstMinutes = 10
+
+// burst tracks a temporary switch to checking every CRN as fast as allowed,
+// e.g. right after hearing that a classmate dropped the section.
+type burst struct {
+	until time.Time
+}
+
+// start begins a burst lasting d from now, replacing any burst already running.
+func (b *burst) start(now time.Time, d time.Duration) {
+	b.until = now.Add(d)
+}
+
+// active reports whether a burst is running at the given time.
+func (b burst) active(now time.Time) bool {
+	return now.Before(b.until)
+}
+
+// interval returns the check interval to use at the given time: normal outside a
+// burst, and MinCheckInterval (or normal, if that is already faster) during one.
+func (b burst) interval(now time.Time, normal time.Duration) time.Duration {
+	if b.active(now) {
+		return min(normal, MinCheckInterval)
+	}
+	return normal
+}
+
+// burstDuration returns how long a burst lasts.
+func (c Config) burstDuration() time.Duration {
+	minutes := c.BurstMinutes
+	if minutes == 0 {
+		minutes = DefaultBurstMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// notifyBurst is a no-op where SIGUSR1 doesn't exist; bursts can't be triggered there.
+func notifyBurst(c chan<- os.Signal) {}
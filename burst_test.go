@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// ===================
+// burst tests
+// ===================
+
+func TestBurst_IntervalDuringAndAfter(t *testing.T) {
+	now := time.Now()
+	var b burst
+	b.start(now, 10*time.Minute)
+
+	if got := b.interval(now.Add(time.Minute), time.Minute); got != MinCheckInterval {
+		t.Errorf("during burst: got %v, want %v", got, MinCheckInterval)
+	}
+	if got := b.interval(now.Add(11*time.Minute), time.Minute); got != time.Minute {
+		t.Errorf("after burst: got %v, want %v", got, time.Minute)
+	}
+}
+
+func TestBurst_NeverSlowsFasterInterval(t *testing.T) {
+	now := time.Now()
+	var b burst
+	b.start(now, time.Minute)
+
+	if got := b.interval(now, 5*time.Second); got != 5*time.Second {
+		t.Errorf("got %v, want %v", got, 5*time.Second)
+	}
+}
+
+func TestBurstDuration(t *testing.T) {
+	if got := (Config{}).burstDuration(); got != DefaultBurstMinutes*time.Minute {
+		t.Errorf("default: got %v", got)
+	}
+	if got := (Config{BurstMinutes: 3}).burstDuration(); got != 3*time.Minute {
+		t.Errorf("configured: got %v, want 3m", got)
+	}
+}
+
+func TestLoadConfig_ErrorNegativeBurstMinutes(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "burstMinutes": -1}`)
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error for negative burstMinutes")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyBurst relays SIGUSR1 to c, so `kill -USR1 <pid>` starts a burst.
+func notifyBurst(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGUSR1)
+}
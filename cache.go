@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSeconds is how long a timetable response is reused when cacheSeconds isn't set
+const DefaultCacheSeconds = 5
+
+// responseCache holds recent timetable responses so identical searches made close
+// together share one request: a CRN watched directly and through a course watch,
+// or the same search from several configs in one process. A search already in
+// flight is waited on rather than sent again.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry // by backend, URL, and full search payload
+}
+
+// cacheEntry is one response, or one still being fetched
+type cacheEntry struct {
+	done    chan struct{} // closed once the response is in
+	value   any
+	err     error
+	expires time.Time // zero while the response is being fetched
+}
+
+// searchCache is shared by every config in the process
+var searchCache = &responseCache{entries: make(map[string]*cacheEntry)}
+
+// validateCache checks cacheSeconds, defaulting it; -1 turns the cache off.
+func (c *Config) validateCache() error {
+	switch {
+	case c.CacheSeconds == 0:
+		c.CacheSeconds = DefaultCacheSeconds
+	case c.CacheSeconds < -1:
+		return fmt.Errorf("cacheSeconds must be positive, or -1 to turn the cache off, got %d", c.CacheSeconds)
+	}
+	return nil
+}
+
+// cacheTTL returns how long responses are reused, zero when they aren't.
+func (c Config) cacheTTL() time.Duration {
+	return time.Duration(max(c.CacheSeconds, 0)) * time.Second
+}
+
+// cachedSearch returns the response for key if an identical search was made
+// within the config's cacheSeconds, and otherwise fetches it. Failed searches
+// aren't kept, so the next check tries again.
+func cachedSearch[T any](c Config, key string, fetch func() (T, error)) (T, error) {
+	ttl := c.cacheTTL()
+	if ttl <= 0 {
+		return fetch()
+	}
+	v, err := searchCache.get(key, ttl, func() (any, error) { return fetch() })
+	result, _ := v.(T)
+	return result, err
+}
+
+// get returns the cached or in-flight response for key, or fetches it.
+func (r *responseCache) get(key string, ttl time.Duration, fetch func() (any, error)) (any, error) {
+	r.mu.Lock()
+	r.prune(time.Now())
+	if e, ok := r.entries[key]; ok {
+		r.mu.Unlock()
+		<-e.done
+		return e.value, e.err
+	}
+	e := &cacheEntry{done: make(chan struct{})}
+	r.entries[key] = e
+	r.mu.Unlock()
+
+	defer close(e.done)
+	e.value, e.err = fetch()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e.err != nil {
+		delete(r.entries, key)
+	} else {
+		e.expires = time.Now().Add(ttl)
+	}
+	return e.value, e.err
+}
+
+// prune drops expired responses. The caller holds the lock.
+func (r *responseCache) prune(now time.Time) {
+	for key, e := range r.entries {
+		if !e.expires.IsZero() && now.After(e.expires) {
+			delete(r.entries, key)
+		}
+	}
+}
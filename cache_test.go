@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ===================
+// cacheSeconds config tests
+// ===================
+
+func TestLoadConfig_CacheSeconds(t *testing.T) {
+	cfg, err := loadConfig(createTempConfig(t, `{"crns": ["12345"]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.CacheSeconds != DefaultCacheSeconds {
+		t.Errorf("expected cacheSeconds to default to %d, got %d", DefaultCacheSeconds, cfg.CacheSeconds)
+	}
+
+	cfg, err = loadConfig(createTempConfig(t, `{"crns": ["12345"], "cacheSeconds": -1}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.cacheTTL() != 0 {
+		t.Errorf("expected -1 to turn the cache off, got %v", cfg.cacheTTL())
+	}
+
+	if _, err := loadConfig(createTempConfig(t, `{"crns": ["12345"], "cacheSeconds": -5}`)); err == nil {
+		t.Error("expected an error for cacheSeconds below -1")
+	}
+}
+
+// ===================
+// responseCache tests
+// ===================
+
+func TestResponseCache_ReusesWithinTTL(t *testing.T) {
+	r := &responseCache{entries: make(map[string]*cacheEntry)}
+	calls := 0
+	fetch := func() (any, error) { calls++; return calls, nil }
+
+	r.get("a", time.Minute, fetch)
+	v, _ := r.get("a", time.Minute, fetch)
+	if calls != 1 || v != 1 {
+		t.Errorf("expected the second search to reuse the first, got %d calls and %v", calls, v)
+	}
+	r.get("b", time.Minute, fetch)
+	if calls != 2 {
+		t.Errorf("expected a different payload to be fetched, got %d calls", calls)
+	}
+
+	r.entries["a"].expires = time.Now().Add(-time.Second)
+	if v, _ := r.get("a", time.Minute, fetch); v != 3 {
+		t.Errorf("expected an expired response to be fetched again, got %v", v)
+	}
+}
+
+func TestResponseCache_DropsErrors(t *testing.T) {
+	r := &responseCache{entries: make(map[string]*cacheEntry)}
+	calls := 0
+	r.get("a", time.Minute, func() (any, error) { calls++; return nil, errors.New("timeout") })
+	if _, err := r.get("a", time.Minute, func() (any, error) { calls++; return "ok", nil }); err != nil || calls != 2 {
+		t.Errorf("expected a failed search to be retried, got %d calls and %v", calls, err)
+	}
+}
+
+func TestResponseCache_SharesInFlight(t *testing.T) {
+	r := &responseCache{entries: make(map[string]*cacheEntry)}
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func() (any, error) {
+		calls.Add(1)
+		<-release
+		return "page", nil
+	}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, _ := r.get("a", time.Minute, fetch); v != "page" {
+				t.Errorf("expected every caller to get the response, got %v", v)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("expected one request for identical searches in flight, got %d", calls.Load())
+	}
+}
+
+// ===================
+// Cached search tests
+// ===================
+
+func TestSearch_CachesIdenticalPayloads(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td></tr></table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", CacheSeconds: 60}
+	other := cfg // e.g. another config watching the same CRN
+	for _, c := range []Config{cfg, other, cfg} {
+		if _, err := c.search("12345", false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected identical searches to share one request, got %d", requests.Load())
+	}
+
+	cfg.search("12346", false)
+	cfg.search("12345", true)
+	if requests.Load() != 3 {
+		t.Errorf("expected different payloads to be sent, got %d requests", requests.Load())
+	}
+
+	cfg.CacheSeconds = -1
+	cfg.search("12345", false)
+	if requests.Load() != 4 {
+		t.Errorf("expected no caching with cacheSeconds -1, got %d requests", requests.Load())
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// calendarDateLayout is the format of the dates in the calendar config
+const calendarDateLayout = "2006-01-02"
+
+// calendarTimeLayout is the format of the time ticket, which starts at a specific time
+const calendarTimeLayout = "2006-01-02 15:04"
+
+// Calendar holds the academic calendar dates OpenSeat plans around.
+// Dates are YYYY-MM-DD in local time; any of them may be left empty.
+type Calendar struct {
+	RegistrationOpens string            `json:"registrationOpens"` // First day of registration for the watched term
+	TimeTicket        string            `json:"timeTicket"`        // When your own registration window opens, YYYY-MM-DD HH:MM
+	DropAddOpens      string            `json:"dropAddOpens"`      // First day of drop/add
+	DropAddEnds       string            `json:"dropAddEnds"`       // Last day of drop/add; monitoring stops after it
+	ClassesBegin      string            `json:"classesBegin"`      // First day of classes, where exported meetings start
+	ClassesEnd        string            `json:"classesEnd"`        // Last day of classes, where exported meetings end
+	Exams             map[string]string `json:"exams"`             // Final exam slot for each exam code, e.g. "15T": "2026-05-08 7:45AM-9:45AM"
+}
+
+// validate checks that every configured date parses.
+func (cal Calendar) validate() error {
+	dates := map[string]string{
+		"registrationOpens": cal.RegistrationOpens,
+		"dropAddOpens":      cal.DropAddOpens,
+		"dropAddEnds":       cal.DropAddEnds,
+		"classesBegin":      cal.ClassesBegin,
+		"classesEnd":        cal.ClassesEnd,
+	}
+	for name, value := range dates {
+		if value == "" {
+			continue
+		}
+		if _, err := time.ParseInLocation(calendarDateLayout, value, time.Local); err != nil {
+			return fmt.Errorf("invalid calendar.%s %q: must be YYYY-MM-DD", name, value)
+		}
+	}
+	if cal.TimeTicket != "" {
+		if _, err := time.ParseInLocation(calendarTimeLayout, cal.TimeTicket, time.Local); err != nil {
+			return fmt.Errorf("invalid calendar.timeTicket %q: must be YYYY-MM-DD HH:MM", cal.TimeTicket)
+		}
+	}
+	for code, slot := range cal.Exams {
+		if _, ok := parseExamSlot(slot); !ok {
+			return fmt.Errorf("invalid calendar.exams %q slot %q: use a date and times like \"2026-05-08 7:45AM-9:45AM\"", code, slot)
+		}
+	}
+	return nil
+}
+
+// day parses a calendar date as midnight local time. ok is false for an empty date.
+func (cal Calendar) day(value string) (t time.Time, ok bool) {
+	t, err := time.ParseInLocation(calendarDateLayout, value, time.Local)
+	return t, err == nil
+}
+
+// beforeRegistration reports whether now is before registration opens.
+func (cal Calendar) beforeRegistration(now time.Time) bool {
+	opens, ok := cal.day(cal.RegistrationOpens)
+	return ok && now.Before(opens)
+}
+
+// dropAddOver reports whether the last day of drop/add has passed.
+func (cal Calendar) dropAddOver(now time.Time) bool {
+	ends, ok := cal.day(cal.DropAddEnds)
+	return ok && !now.Before(ends.AddDate(0, 0, 1))
+}
+
+// daysUntil counts calendar days from now's date to the given date.
+func daysUntil(now, date time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, date.Location())
+	// round to absorb the hour gained or lost across a DST change
+	return int(date.Sub(today).Round(24*time.Hour) / (24 * time.Hour))
+}
+
+// dropAddCountdown describes how long is left in drop/add, e.g. "drop/add T-minus 2 days".
+// Returns "" when no deadline is configured or it has passed.
+func (cal Calendar) dropAddCountdown(now time.Time) string {
+	ends, ok := cal.day(cal.DropAddEnds)
+	if !ok || cal.dropAddOver(now) {
+		return ""
+	}
+	switch days := daysUntil(now, ends); days {
+	case 0:
+		return T("calendar.drop_add_today")
+	case 1:
+		return T("calendar.drop_add_one_day")
+	default:
+		return T("calendar.drop_add_days", days)
+	}
+}
+
+// calendarEvent is a registration milestone worth counting down to
+type calendarEvent struct {
+	Name string
+	At   time.Time
+}
+
+// nextEvent returns the soonest registration milestone that hasn't happened yet.
+// The drop/add deadline isn't included; dropAddCountdown covers it.
+func (cal Calendar) nextEvent(now time.Time) (calendarEvent, bool) {
+	var events []calendarEvent
+	if t, ok := cal.day(cal.RegistrationOpens); ok {
+		events = append(events, calendarEvent{T("calendar.registration_opens"), t})
+	}
+	if t, err := time.ParseInLocation(calendarTimeLayout, cal.TimeTicket, time.Local); err == nil {
+		events = append(events, calendarEvent{T("calendar.time_ticket"), t})
+	}
+	if t, ok := cal.day(cal.DropAddOpens); ok {
+		events = append(events, calendarEvent{T("calendar.drop_add_opens"), t})
+	}
+
+	var next calendarEvent
+	for _, e := range events {
+		if e.At.After(now) && (next.At.IsZero() || e.At.Before(next.At)) {
+			next = e
+		}
+	}
+	return next, !next.At.IsZero()
+}
+
+// formatCountdown renders a duration as a clock, e.g. "04:12:09" or "2d 04:12:09".
+func formatCountdown(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	clock := fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+	if days > 0 {
+		return fmt.Sprintf("%dd %s", days, clock)
+	}
+	return clock
+}
+
+// status describes the upcoming registration milestones for the status line,
+// e.g. "time ticket in 2d 04:12:09 · drop/add T-minus 40 days".
+func (cal Calendar) status(now time.Time) string {
+	var parts []string
+	if e, ok := cal.nextEvent(now); ok {
+		parts = append(parts, T("calendar.event_in", e.Name, formatCountdown(e.At.Sub(now))))
+	}
+	if countdown := cal.dropAddCountdown(now); countdown != "" {
+		parts = append(parts, countdown)
+	}
+	return strings.Join(parts, " · ")
+}
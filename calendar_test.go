@@ -0,0 +1,117 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func localDate(t *testing.T, value string) time.Time {
+	t.Helper()
+	d, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// ===================
+// Calendar tests
+// ===================
+
+func TestCalendar_BeforeRegistration(t *testing.T) {
+	cal := Calendar{RegistrationOpens: "2026-03-30"}
+
+	if !cal.beforeRegistration(localDate(t, "2026-03-29 23:59")) {
+		t.Error("expected the day before to be before registration")
+	}
+	if cal.beforeRegistration(localDate(t, "2026-03-30 00:00")) {
+		t.Error("expected opening day not to be before registration")
+	}
+	if (Calendar{}).beforeRegistration(time.Now()) {
+		t.Error("expected no warning without a configured date")
+	}
+}
+
+func TestCalendar_DropAddOverAfterLastDay(t *testing.T) {
+	cal := Calendar{DropAddEnds: "2026-01-23"}
+
+	if cal.dropAddOver(localDate(t, "2026-01-23 23:59")) {
+		t.Error("expected drop/add to still be open on its last day")
+	}
+	if !cal.dropAddOver(localDate(t, "2026-01-24 00:00")) {
+		t.Error("expected drop/add to be over the day after")
+	}
+}
+
+func TestCalendar_DropAddCountdown(t *testing.T) {
+	cal := Calendar{DropAddEnds: "2026-01-23"}
+	tests := []struct {
+		now  string
+		want string
+	}{
+		{"2026-01-21 09:00", "drop/add T-minus 2 days"},
+		{"2026-01-22 23:00", "drop/add T-minus 1 day"},
+		{"2026-01-23 08:00", "drop/add ends today"},
+		{"2026-01-24 08:00", ""},
+	}
+
+	for _, tt := range tests {
+		if got := cal.dropAddCountdown(localDate(t, tt.now)); got != tt.want {
+			t.Errorf("at %s: got %q, want %q", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_ErrorInvalidCalendarDate(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "calendar": {"dropAddEnds": "01/23/2026"}}`)
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error for a non-ISO calendar date")
+	}
+}
+
+func TestCalendar_NextEvent(t *testing.T) {
+	cal := Calendar{
+		RegistrationOpens: "2025-10-27",
+		TimeTicket:        "2025-10-29 07:30",
+		DropAddOpens:      "2026-01-12",
+	}
+
+	e, ok := cal.nextEvent(localDate(t, "2025-10-28 12:00"))
+	if !ok || e.Name != "time ticket" || !e.At.Equal(localDate(t, "2025-10-29 07:30")) {
+		t.Errorf("got %+v, %v; want the time ticket", e, ok)
+	}
+	if _, ok := cal.nextEvent(localDate(t, "2026-01-12 00:00")); ok {
+		t.Error("expected no event once every milestone has passed")
+	}
+}
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{4*time.Hour + 12*time.Minute + 9*time.Second + 500*time.Millisecond, "04:12:09"},
+		{50*time.Hour + 5*time.Second, "2d 02:00:05"},
+	}
+
+	for _, tt := range tests {
+		if got := formatCountdown(tt.d); got != tt.want {
+			t.Errorf("formatCountdown(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestCalendar_Status(t *testing.T) {
+	cal := Calendar{TimeTicket: "2026-01-10 08:00", DropAddEnds: "2026-01-23"}
+	want := "time ticket in 1d 02:00:00 · drop/add T-minus 14 days"
+	if got := cal.status(localDate(t, "2026-01-09 06:00")); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidTimeTicket(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "calendar": {"timeTicket": "2026-01-10"}}`)
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error for a time ticket without a time")
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// campusCodePattern matches a timetable campus code, e.g. "0" for Blacksburg
+var campusCodePattern = regexp.MustCompile(`^\d+$`)
+
+// campusOption is one entry in the timetable's campus dropdown
+type campusOption struct {
+	Code string // e.g. "0"
+	Name string // e.g. "Blacksburg"
+}
+
+// fetchCampusOptions retrieves the campuses offered in the timetable's campus dropdown.
+func (c Config) fetchCampusOptions() ([]campusOption, error) {
+	doc, err := c.fetchRequestForm()
+	if err != nil {
+		return nil, err
+	}
+
+	var campuses []campusOption
+	doc.Find(`select[name="CAMPUS"] option`).Each(func(i int, opt *goquery.Selection) {
+		value := strings.TrimSpace(opt.AttrOr("value", ""))
+		if campusCodePattern.MatchString(value) {
+			campuses = append(campuses, campusOption{Code: value, Name: strings.Join(strings.Fields(opt.Text()), " ")})
+		}
+	})
+
+	if len(campuses) == 0 {
+		return nil, fmt.Errorf("no campuses found in timetable campus dropdown")
+	}
+	return campuses, nil
+}
+
+// matchCampus returns the code of the campus called name, ignoring case.
+// A campus whose full name matches wins; otherwise name may be part of exactly
+// one campus's name, so "virtual" finds "Virtual Campus".
+func matchCampus(options []campusOption, name string) (string, error) {
+	want := strings.ToLower(strings.TrimSpace(name))
+	var matches []campusOption
+	for _, opt := range options {
+		have := strings.ToLower(opt.Name)
+		if have == want {
+			return opt.Code, nil
+		}
+		if strings.Contains(have, want) {
+			matches = append(matches, opt)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0].Code, nil
+	case 0:
+		names := make([]string, len(options))
+		for i, opt := range options {
+			names[i] = opt.Name
+		}
+		return "", fmt.Errorf("unknown campus %q (available: %s)", name, strings.Join(names, ", "))
+	default:
+		names := make([]string, len(matches))
+		for i, opt := range matches {
+			names[i] = opt.Name
+		}
+		return "", fmt.Errorf("campus %q is ambiguous: it matches %s", name, strings.Join(names, ", "))
+	}
+}
+
+// validateCampusOverrides checks that every campus override names a watched CRN and a campus.
+func (c Config) validateCampusOverrides() error {
+	for crn, campus := range c.CampusOverrides {
+		if strings.TrimSpace(campus) == "" {
+			return fmt.Errorf("campusOverrides has an empty campus for CRN %s", crn)
+		}
+		if !slices.Contains(c.CRNs, crn) {
+			return fmt.Errorf("campusOverrides lists CRN %s, which is not in crns", crn)
+		}
+	}
+	return nil
+}
+
+// resolveCampusNames replaces campus names in campus, campuses, and campusOverrides
+// with the codes the timetable uses, looked up in its campus dropdown.
+// The dropdown is only fetched when a name is used.
+func (c *Config) resolveCampusNames() error {
+	var options []campusOption
+	resolve := func(campus *string) error {
+		if campusCodePattern.MatchString(*campus) {
+			return nil
+		}
+		if options == nil {
+			var err error
+			if options, err = c.fetchCampusOptions(); err != nil {
+				return fmt.Errorf("failed to look up campus names: %w", err)
+			}
+		}
+		code, err := matchCampus(options, *campus)
+		if err != nil {
+			return configError(err)
+		}
+		*campus = code
+		return nil
+	}
+
+	if err := resolve(&c.Campus); err != nil {
+		return err
+	}
+	for i := range c.Campuses {
+		if err := resolve(&c.Campuses[i]); err != nil {
+			return err
+		}
+	}
+	for _, crn := range slices.Sorted(maps.Keys(c.CampusOverrides)) {
+		campus := c.CampusOverrides[crn]
+		if err := resolve(&campus); err != nil {
+			return err
+		}
+		c.CampusOverrides[crn] = campus
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ===================
+// matchCampus tests
+// ===================
+
+var testCampuses = []campusOption{
+	{Code: "0", Name: "Blacksburg"},
+	{Code: "10", Name: "Virtual Campus"},
+	{Code: "14", Name: "National Capital Region"},
+	{Code: "15", Name: "Capital Region Online"},
+}
+
+func TestMatchCampus(t *testing.T) {
+	tests := map[string]string{
+		"Blacksburg":              "0",
+		"virtual":                 "10",
+		"NATIONAL CAPITAL REGION": "14",
+		"online":                  "15",
+	}
+
+	for name, want := range tests {
+		got, err := matchCampus(testCampuses, name)
+		if err != nil {
+			t.Errorf("matchCampus(%q): unexpected error: %v", name, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("matchCampus(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestMatchCampus_Errors(t *testing.T) {
+	if _, err := matchCampus(testCampuses, "Roanoke"); err == nil || !strings.Contains(err.Error(), "Virtual Campus") {
+		t.Errorf("unknown campus: got %v, want an error listing the campuses", err)
+	}
+	if _, err := matchCampus(testCampuses, "capital"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("ambiguous campus: got %v, want an ambiguity error", err)
+	}
+}
+
+// ===================
+// campusOverrides tests
+// ===================
+
+func TestLoadConfig_ErrorInvalidCampusOverride(t *testing.T) {
+	tests := map[string]string{
+		"unwatched CRN": `{"crns": ["12345"], "campusOverrides": {"67890": "10"}}`,
+		"empty campus":  `{"crns": ["12345"], "campusOverrides": {"12345": " "}}`,
+	}
+
+	for name, content := range tests {
+		if _, err := loadConfig(createTempConfig(t, content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestForCRN(t *testing.T) {
+	cfg := Config{Campus: "0", Campuses: []string{"0", "2"}, CampusOverrides: map[string]string{"12345": "10"}}
+
+	v := cfg.forCRN("12345").variants()
+	if len(v) != 1 || v[0].Campus != "10" {
+		t.Errorf("override: got %d variants, want one on campus 10", len(v))
+	}
+	if v := cfg.forCRN("67890").variants(); len(v) != 2 {
+		t.Errorf("no override: got %d variants, want 2", len(v))
+	}
+}
+
+// ===================
+// resolveCampusNames tests
+// ===================
+
+const campusDropdownHTML = `<html><form>
+	<select name="CAMPUS">
+		<option value="0">Blacksburg</option>
+		<option value="10">Virtual Campus</option>
+	</select>
+</form></html>`
+
+func TestResolveCampusNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(campusDropdownHTML))
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		BaseURL:         server.URL,
+		Campus:          "blacksburg",
+		Campuses:        []string{"0", "Virtual"},
+		CampusOverrides: map[string]string{"12345": "virtual"},
+	}
+	if err := cfg.resolveCampusNames(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Campus != "0" || cfg.Campuses[1] != "10" || cfg.CampusOverrides["12345"] != "10" {
+		t.Errorf("got campus %q, campuses %v, overrides %v", cfg.Campus, cfg.Campuses, cfg.CampusOverrides)
+	}
+}
+
+func TestResolveCampusNames_CodesSkipRequest(t *testing.T) {
+	cfg := Config{BaseURL: "http://localhost:99999", Campus: "0", CampusOverrides: map[string]string{"12345": "10"}}
+	if err := cfg.resolveCampusNames(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"time"
+)
+
+// ErrChallenge indicates the timetable answered with a bot check or CAPTCHA instead of
+// search results. Only a person in a browser can get past it, so monitoring pauses.
+var ErrChallenge = errors.New("timetable answered with a bot check or CAPTCHA")
+
+// ChallengePause is how long checks stay paused after a bot check before OpenSeat tries again
+const ChallengePause = 30 * time.Minute
+
+// challengeMarkers are lowercase snippets found in the bot checks and CAPTCHAs
+// that sit in front of university sites
+var challengeMarkers = [][]byte{
+	[]byte("g-recaptcha"),
+	[]byte("h-captcha"),
+	[]byte("cf-turnstile"),
+	[]byte("challenge-platform"), // Cloudflare
+	[]byte("cf-chl-"),            // Cloudflare
+	[]byte("_incapsula_resource"),
+	[]byte("px-captcha"), // PerimeterX
+	[]byte("<title>just a moment...</title>"),
+	[]byte("<title>attention required!"),
+	[]byte("verify you are human"),
+	[]byte("are you a robot"),
+}
+
+// isChallenge reports whether a response body looks like a bot check or CAPTCHA.
+// Pages with a results table never count, however they're decorated.
+func isChallenge(raw []byte) bool {
+	body := bytes.ToLower(raw)
+	if bytes.Contains(body, []byte("dataentrytable")) {
+		return false
+	}
+	for _, marker := range challengeMarkers {
+		if bytes.Contains(body, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyChallenge sends an urgent email asking the operator to get past the bot check,
+// since OpenSeat can't see any openings until someone does.
+func (c Config) notifyChallenge(sender EmailSender, err error) {
+	if !c.notifying() {
+		return
+	}
+	subject := T("email.open.urgent", T("email.challenge.subject"))
+	c.notify(sender, Alert{Event: AlertChallenge, Subject: subject, Body: T("email.challenge.body", err, c.BaseURL, ChallengePause)})
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// isChallenge tests
+// ===================
+
+func TestIsChallenge(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		want bool
+	}{
+		"cloudflare":      {`<html><head><title>Just a moment...</title></head><body><script src="/cdn-cgi/challenge-platform/h/g/orchestrate"></script></body></html>`, true},
+		"recaptcha":       {`<form><div class="g-recaptcha" data-sitekey="x"></div></form>`, true},
+		"hcaptcha":        {`<div class="h-captcha"></div>`, true},
+		"results":         {`<table class="dataentrytable"><tr><td>12345</td></tr></table>`, false},
+		"results w/ ads":  {`<script src="g-recaptcha.js"></script><table class="dataentrytable"></table>`, false},
+		"search form":     {`<html><title>Timetable of Classes</title><form><select name="TERMYEAR"></select></form></html>`, false},
+		"no results text": {`<p>NO SECTIONS FOUND FOR THIS INQUIRY.</p>`, false},
+	}
+
+	for name, tt := range tests {
+		if got := isChallenge([]byte(tt.body)); got != tt.want {
+			t.Errorf("%s: isChallenge = %v, want %v", name, got, tt.want)
+		}
+	}
+}
+
+func TestFetchDocument_Challenge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<html><head><title>Attention Required! | Cloudflare</title></head></html>`))
+	}))
+	defer server.Close()
+
+	_, err := fetchDocument(http.DefaultClient, server.URL, nil)
+	if !errors.Is(err, ErrChallenge) {
+		t.Fatalf("expected ErrChallenge, got %v", err)
+	}
+	if exitCode(err) != ExitNetwork {
+		t.Errorf("exitCode = %d, want %d", exitCode(err), ExitNetwork)
+	}
+}
+
+// ===================
+// challenge pause tests
+// ===================
+
+func TestControls_ChallengePauseEndsOnItsOwn(t *testing.T) {
+	now := time.Now()
+	var ctl controls
+	ctl.pauseUntil(now.Add(ChallengePause))
+
+	if !ctl.holding(now) {
+		t.Error("expected checks on hold during the pause")
+	}
+	if !strings.Contains(ctl.status(nil), "paused until") {
+		t.Errorf("status = %q, want the resume time", ctl.status(nil))
+	}
+	if ctl.holding(now.Add(ChallengePause)) {
+		t.Error("expected checks to resume once the pause runs out")
+	}
+}
+
+func TestControls_PauseKeyEndsChallengePause(t *testing.T) {
+	var ctl controls
+	ctl.pauseUntil(time.Now().Add(ChallengePause))
+
+	ctl.handleKey('p', nil)
+	if ctl.paused || !ctl.resumeAt.IsZero() {
+		t.Errorf("expected p to resume checks, got %+v", ctl)
+	}
+
+	// a pause from the keyboard has no end time
+	ctl.handleKey('p', nil)
+	if !ctl.holding(time.Now().Add(24 * time.Hour)) {
+		t.Error("expected a keyboard pause to hold until resumed")
+	}
+}
+
+// ===================
+// notifyChallenge tests
+// ===================
+
+func TestNotifyChallenge_SendsUrgentEmail(t *testing.T) {
+	cfg := Config{Email: "test@example.com", BaseURL: DefaultTimetableURL}
+	sender := &MockEmailSender{}
+
+	cfg.notifyChallenge(sender, ErrChallenge)
+
+	if len(sender.Sent) != 1 || !strings.HasPrefix(sender.Sent[0].Subject, "[URGENT]") {
+		t.Fatalf("expected one urgent email, got %+v", sender.Sent)
+	}
+	if !strings.Contains(sender.Sent[0].Body, DefaultTimetableURL) {
+		t.Errorf("body should say where to complete the check: %q", sender.Sent[0].Body)
+	}
+}
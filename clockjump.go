@@ -0,0 +1,59 @@
+package main
+
+import "time"
+
+// ClockJumpThreshold is how far the wall clock can move beyond what the watch loop
+// expects before it counts as a jump, like a laptop waking from sleep or a VM resuming.
+const ClockJumpThreshold = time.Minute
+
+// clockWatch notices wall-clock jumps between ticks of the wait loop.
+// Timers keep Go's monotonic clock reading, which stops while the machine is
+// suspended, so without this the loop would resume as if no time had passed.
+type clockWatch struct {
+	last time.Time
+}
+
+// reset starts measuring from now, after work the wait loop expects to take a while.
+func (w *clockWatch) reset(now time.Time) {
+	w.last = now
+}
+
+// tick records the time and, if the wall clock moved more than ClockJumpThreshold
+// further than expected since the last tick (either way), returns how far it moved.
+func (w *clockWatch) tick(now time.Time, expected time.Duration) (time.Duration, bool) {
+	last := w.last
+	w.last = now
+	if last.IsZero() {
+		return 0, false
+	}
+	gap := now.Round(0).Sub(last.Round(0)) // wall clock only
+	if gap-expected > ClockJumpThreshold || gap < -ClockJumpThreshold {
+		return gap, true
+	}
+	return 0, false
+}
+
+// wallClock drops t's monotonic reading, so comparisons against it go by the wall
+// clock and count time spent suspended.
+func wallClock(t time.Time) time.Time {
+	return t.Round(0)
+}
+
+// resumeAfterJump makes every CRN, course, and term check due right away and moves the
+// bot check pause, burst, and summary period onto the wall clock, so they end when
+// they would have if the machine had stayed awake.
+func resumeAfterJump(courses []CourseStatus, watches []*courseWatch, terms *termWatch, ctl *controls, fast *burst, act *activity) {
+	checkNow(courses)
+	for i := range courses {
+		courses[i].nextObserve = time.Time{}
+	}
+	for _, w := range watches {
+		w.nextCheck = time.Time{}
+	}
+	if terms != nil {
+		terms.nextCheck = time.Time{}
+	}
+	ctl.resumeAt = wallClock(ctl.resumeAt)
+	fast.until = wallClock(fast.until)
+	act.started, act.periodStart = wallClock(act.started), wallClock(act.periodStart)
+}
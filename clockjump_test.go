@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// ===================
+// clockWatch tests
+// ===================
+
+func TestClockWatchTick(t *testing.T) {
+	start := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		jumped  bool
+	}{
+		{"normal tick", 100 * time.Millisecond, false},
+		{"slow tick", 30 * time.Second, false},
+		{"slept overnight", 8 * time.Hour, true},
+		{"clock set back", -2 * time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		var w clockWatch
+		w.reset(start)
+		gap, jumped := w.tick(start.Add(tt.elapsed), 100*time.Millisecond)
+		if jumped != tt.jumped {
+			t.Errorf("%s: jumped = %v, want %v", tt.name, jumped, tt.jumped)
+		}
+		if jumped && gap != tt.elapsed {
+			t.Errorf("%s: gap = %v, want %v", tt.name, gap, tt.elapsed)
+		}
+	}
+}
+
+func TestClockWatchTick_FirstTickNeverJumps(t *testing.T) {
+	var w clockWatch
+	if _, jumped := w.tick(time.Now(), 100*time.Millisecond); jumped {
+		t.Error("expected no jump without a previous tick")
+	}
+}
+
+// ===================
+// resumeAfterJump tests
+// ===================
+
+func TestResumeAfterJump(t *testing.T) {
+	now := time.Now()
+	courses := []CourseStatus{{CRN: "12345", nextCheck: now.Add(time.Hour), nextObserve: now.Add(time.Hour)}}
+	watches := []*courseWatch{{Code: "CS-3114", nextCheck: now.Add(time.Hour)}}
+	terms := &termWatch{nextCheck: now.Add(time.Hour)}
+	ctl := controls{}
+	ctl.pauseUntil(now.Add(30 * time.Minute))
+	fast := burst{until: now.Add(5 * time.Minute)}
+	act := newActivity(now, courses)
+
+	resumeAfterJump(courses, watches, terms, &ctl, &fast, act)
+
+	if !courses[0].nextCheck.IsZero() || !courses[0].nextObserve.IsZero() || !watches[0].nextCheck.IsZero() || !terms.nextCheck.IsZero() {
+		t.Error("expected every check to be due right away")
+	}
+	// wallClock(t) == t only once t has no monotonic reading
+	for name, tm := range map[string]time.Time{"resumeAt": ctl.resumeAt, "burst": fast.until, "summary": act.periodStart} {
+		if tm != wallClock(tm) {
+			t.Errorf("%s still uses the monotonic clock: %s", name, tm)
+		}
+	}
+	if !ctl.resumeAt.Equal(now.Add(30 * time.Minute)) {
+		t.Errorf("resumeAt moved to %v", ctl.resumeAt)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// commandEventVersion is bumped whenever a field is removed from or changes meaning
+// in the JSON given to notify commands. New fields may be added without a bump.
+const commandEventVersion = 1
+
+// Defaults for notify commands
+const (
+	DefaultCommandRetries = 2
+	DefaultCommandTimeout = 30 * time.Second
+)
+
+// commandRetryDelay is how long to wait before the first retry; each later retry waits longer
+var commandRetryDelay = 2 * time.Second
+
+// backgroundCommands tracks notify commands still running, with their retries,
+// alongside the watch loop, and backgroundDeadline is when the last of them will
+// have used up all its attempts.
+var (
+	backgroundCommands sync.WaitGroup
+	backgroundMu       sync.Mutex
+	backgroundDeadline time.Time
+)
+
+// inBackground runs deliver, which sends an alert through the command, alongside
+// the watch loop, pushing back how long waitForCommands waits if need be.
+func (n *NotifyCommand) inBackground(deliver func()) {
+	backgroundMu.Lock()
+	if deadline := time.Now().Add(n.longest()); deadline.After(backgroundDeadline) {
+		backgroundDeadline = deadline
+	}
+	backgroundMu.Unlock()
+
+	backgroundCommands.Add(1)
+	go func() {
+		defer backgroundCommands.Done()
+		deliver()
+	}()
+}
+
+// waitForCommands gives notify commands still retrying as long as their attempts
+// and the delays between them can take, plus a second to record the outcome, so an
+// alert isn't dropped just because OpenSeat is stopping.
+func waitForCommands() {
+	done := make(chan struct{})
+	go func() {
+		backgroundCommands.Wait()
+		close(done)
+	}()
+	backgroundMu.Lock()
+	wait := time.Until(backgroundDeadline) + time.Second
+	backgroundMu.Unlock()
+	select {
+	case <-done:
+	case <-time.After(wait):
+	}
+}
+
+// NotifyCommand is an external program run for every alert, for channels OpenSeat
+// doesn't support itself. The alert is written to its stdin as JSON, and a non-zero
+// exit is a failed delivery.
+type NotifyCommand struct {
+	Path           string   `json:"path"`           // executable to run
+	Args           []string `json:"args"`           // arguments to pass it (optional)
+	Retries        int      `json:"retries"`        // extra attempts after a failure (defaults to 2, -1 for none)
+	TimeoutSeconds int      `json:"timeoutSeconds"` // how long one attempt may run (defaults to 30)
+}
+
+// applyDefaults fills in the retries and timeout, and checks the executable exists.
+func (n *NotifyCommand) applyDefaults() error {
+	if n.Path == "" {
+		return fmt.Errorf("notifyCommands: path is required")
+	}
+	if _, err := exec.LookPath(n.Path); err != nil {
+		return fmt.Errorf("notifyCommands: %w", err)
+	}
+	switch {
+	case n.Retries == 0:
+		n.Retries = DefaultCommandRetries
+	case n.Retries < 0:
+		n.Retries = 0
+	}
+	if n.TimeoutSeconds < 0 {
+		return fmt.Errorf("notifyCommands: timeoutSeconds must be positive, got %d", n.TimeoutSeconds)
+	}
+	return nil
+}
+
+// timeout returns how long one attempt may run.
+func (n NotifyCommand) timeout() time.Duration {
+	if n.TimeoutSeconds > 0 {
+		return time.Duration(n.TimeoutSeconds) * time.Second
+	}
+	return DefaultCommandTimeout
+}
+
+// longest returns the most time Notify can take: every attempt timing out, and
+// the growing delays between them.
+func (n NotifyCommand) longest() time.Duration {
+	retries := time.Duration(n.Retries)
+	return (retries+1)*n.timeout() + commandRetryDelay*retries*(retries+1)/2
+}
+
+// commandEvent is the JSON a notify command reads from stdin
+type commandEvent struct {
+	Version  int       `json:"version"`
+	Time     time.Time `json:"time"`
+	Severity Severity  `json:"severity"`
+	Alert
+}
+
+func (n *NotifyCommand) Name() string { return filepath.Base(n.Path) }
+
+// Notify runs the command with the alert on stdin, retrying with a growing delay
+// while it fails. Every attempt gets the same event, so a command can tell a retry
+// from a new alert by its time.
+func (n *NotifyCommand) Notify(a Alert) error {
+	event, err := json.Marshal(commandEvent{Version: commandEventVersion, Time: time.Now(), Severity: a.severity(), Alert: a})
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = n.run(event)
+		if err == nil || attempt == n.Retries {
+			return err
+		}
+		time.Sleep(commandRetryDelay * time.Duration(attempt+1))
+	}
+}
+
+// run runs the command once, returning its stderr in the error if it fails.
+func (n *NotifyCommand) run(event []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout())
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, n.Path, n.Args...)
+	cmd.Stdin = bytes.NewReader(event)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s timed out after %v", n.Name(), n.timeout())
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s failed: %w: %s", n.Name(), err, msg)
+		}
+		return fmt.Errorf("%s failed: %w", n.Name(), err)
+	}
+	return nil
+}
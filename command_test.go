@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TestNotifyCommandHelper isn't a real test: it's the notify command the tests run,
+// by running the test binary again with OPENSEAT_COMMAND_DIR set. It saves stdin
+// to event.json, and fails until OPENSEAT_COMMAND_FAILURES attempts have been made.
+func TestNotifyCommandHelper(t *testing.T) {
+	dir := os.Getenv("OPENSEAT_COMMAND_DIR")
+	if dir == "" {
+		return
+	}
+	event, _ := io.ReadAll(os.Stdin)
+	os.WriteFile(filepath.Join(dir, "event.json"), event, 0o644)
+
+	attempts, _ := os.ReadFile(filepath.Join(dir, "attempts"))
+	n, _ := strconv.Atoi(string(attempts))
+	os.WriteFile(filepath.Join(dir, "attempts"), []byte(strconv.Itoa(n+1)), 0o644)
+
+	if failures, _ := strconv.Atoi(os.Getenv("OPENSEAT_COMMAND_FAILURES")); n < failures {
+		fmt.Fprintln(os.Stderr, "webhook unreachable")
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// helperCommand returns a notify command that runs TestNotifyCommandHelper, failing
+// the given number of times, and the directory it records to.
+func helperCommand(t *testing.T, failures int) (*NotifyCommand, string) {
+	dir := t.TempDir()
+	t.Setenv("OPENSEAT_COMMAND_DIR", dir)
+	t.Setenv("OPENSEAT_COMMAND_FAILURES", strconv.Itoa(failures))
+	old := commandRetryDelay
+	commandRetryDelay = 0
+	t.Cleanup(func() { commandRetryDelay = old })
+
+	cmd := &NotifyCommand{Path: os.Args[0], Args: []string{"-test.run=^TestNotifyCommandHelper$"}}
+	if err := cmd.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return cmd, dir
+}
+
+// attempts returns how many times the helper command ran.
+func attempts(t *testing.T, dir string) int {
+	b, _ := os.ReadFile(filepath.Join(dir, "attempts"))
+	n, _ := strconv.Atoi(string(b))
+	return n
+}
+
+// ===================
+// NotifyCommand config tests
+// ===================
+
+func TestNotifyCommandApplyDefaults(t *testing.T) {
+	cmd := NotifyCommand{Path: os.Args[0]}
+	if err := cmd.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Retries != DefaultCommandRetries || cmd.timeout() != DefaultCommandTimeout {
+		t.Errorf("got %d retries and a %v timeout", cmd.Retries, cmd.timeout())
+	}
+
+	cmd = NotifyCommand{Path: os.Args[0], Retries: -1}
+	cmd.applyDefaults()
+	if cmd.Retries != 0 {
+		t.Errorf("Retries = %d, want 0 for -1", cmd.Retries)
+	}
+}
+
+func TestNotifyCommand_Longest(t *testing.T) {
+	cmd := NotifyCommand{Retries: 2, TimeoutSeconds: 30}
+	// three attempts of 30s, and delays of 2s and 4s between them
+	if got, want := cmd.longest(), 96*time.Second; got != want {
+		t.Errorf("longest() = %v, want %v", got, want)
+	}
+	if got := (NotifyCommand{TimeoutSeconds: 5}).longest(); got != 5*time.Second {
+		t.Errorf("longest() = %v without retries, want 5s", got)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidNotifyCommand(t *testing.T) {
+	for _, cmd := range []string{`{}`, `{"path": "/no/such/openseat-notifier"}`} {
+		path := createTempConfig(t, `{"crns": ["12345"], "notifyCommands": [`+cmd+`]}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", cmd)
+		}
+	}
+}
+
+// ===================
+// NotifyCommand tests
+// ===================
+
+func TestNotifyCommand_EventOnStdin(t *testing.T) {
+	cmd, dir := helperCommand(t, 0)
+
+	alert := Alert{Event: EventOpen, Subject: "VT Course Section Open!", Body: "OPEN SEAT", CRN: "12345", Name: "Intro to Testing", Term: "202601"}
+	if err := cmd.Notify(alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "event.json"))
+	if err != nil {
+		t.Fatalf("command didn't get the event: %v", err)
+	}
+	var got commandEvent
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", raw, err)
+	}
+	if got.Version != commandEventVersion || got.Time.IsZero() || got.Severity != SeverityCritical || got.Alert != alert {
+		t.Errorf("unexpected event: %s", raw)
+	}
+	if !strings.Contains(string(raw), `"crn":"12345"`) || !strings.Contains(string(raw), `"event":"open"`) {
+		t.Errorf("expected flat alert fields, got %s", raw)
+	}
+}
+
+func TestNotifyCommand_RetriesFailures(t *testing.T) {
+	cmd, dir := helperCommand(t, 2)
+
+	if err := cmd.Notify(Alert{Subject: "Subject"}); err != nil {
+		t.Fatalf("expected success on the third attempt, got %v", err)
+	}
+	if n := attempts(t, dir); n != 3 {
+		t.Errorf("ran %d times, want 3", n)
+	}
+}
+
+func TestNotifyCommand_GivesUp(t *testing.T) {
+	cmd, dir := helperCommand(t, 10)
+
+	err := cmd.Notify(Alert{Subject: "Subject"})
+	if err == nil || !strings.Contains(err.Error(), "webhook unreachable") {
+		t.Errorf("expected the command's stderr in the error, got %v", err)
+	}
+	if n := attempts(t, dir); n != DefaultCommandRetries+1 {
+		t.Errorf("ran %d times, want %d", n, DefaultCommandRetries+1)
+	}
+}
+
+func TestNotify_CommandRetriesInBackground(t *testing.T) {
+	cmd, dir := helperCommand(t, 1)
+	commandRetryDelay = time.Second
+	other := &MockNotifier{}
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	cfg := Config{AuditFile: path, notifiers: []Notifier{cmd, other}}
+
+	start := time.Now()
+	cfg.notify(&MockEmailSender{}, Alert{Event: EventOpen, CRN: "12345", Subject: "Open"})
+	if elapsed := time.Since(start); elapsed >= commandRetryDelay {
+		t.Errorf("notify waited %v for the command's retry", elapsed)
+	}
+	if len(other.Sent) != 1 {
+		t.Errorf("expected the other channel sent right away, got %d", len(other.Sent))
+	}
+
+	waitForCommands()
+	if n := attempts(t, dir); n != 2 {
+		t.Errorf("ran %d times, want 2", n)
+	}
+	entries, _ := readAudit(path)
+	if len(entries) != 2 || entries[1].Channel != cmd.Name() || entries[1].Result != "sent" {
+		t.Errorf("expected the command's outcome audited once it finished, got %+v", entries)
+	}
+}
+
+func TestNotify_CommandInBackgroundHasItsOwnSpan(t *testing.T) {
+	recorder := recordSpans()
+
+	cmd, _ := helperCommand(t, 10)
+	cfg := Config{notifiers: []Notifier{cmd}}
+	cfg.notify(&MockEmailSender{}, Alert{Event: EventOpen, CRN: "13579", Subject: "Open"})
+	waitForCommands()
+
+	var notify sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "notify" && slices.Contains(s.Attributes(), attribute.String("crn", "13579")) {
+			notify = s
+		}
+	}
+	if notify == nil {
+		t.Fatal("no notify span recorded")
+	}
+	if len(notify.Events()) != 0 {
+		t.Errorf("expected nothing recorded on the ended notify span, got %v", notify.Events())
+	}
+	for _, s := range recorder.Ended() {
+		if s.Name() == "notify.command" && s.Parent().SpanID() == notify.SpanContext().SpanID() {
+			if len(s.Events()) != 1 {
+				t.Errorf("expected the command's failure recorded on its own span, got %v", s.Events())
+			}
+			return
+		}
+	}
+	t.Error("no notify.command span recorded beneath the notify span")
+}
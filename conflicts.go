@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// What to do when an open section clashes with the schedule
+const (
+	ConflictsFlag     = "flag"     // alert as usual, noting the clash
+	ConflictsSuppress = "suppress" // don't alert, since a seat you can't attend is noise
+)
+
+// meetingTime is when a section or commitment meets each week
+type meetingTime struct {
+	days       string // day letters, e.g. "MWF"
+	start, end int    // minutes after midnight
+}
+
+// busyTime is one entry in the schedule watched sections are compared against
+type busyTime struct {
+	label string // e.g. "CS-3114 (12345) M W F 10:10AM-11:00AM"
+	meetingTime
+}
+
+// parseMeeting reads days and times like "M W F 10:10AM-11:00AM", as the
+// timetable lists them. Sections without set times, like online or arranged
+// ones, have none and never clash.
+func parseMeeting(s string) (meetingTime, bool) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return meetingTime{}, false
+	}
+	begin, end, ok := strings.Cut(fields[len(fields)-1], "-")
+	if !ok {
+		return meetingTime{}, false
+	}
+	start, err1 := time.Parse("3:04PM", strings.ToUpper(begin))
+	finish, err2 := time.Parse("3:04PM", strings.ToUpper(end))
+	if err1 != nil || err2 != nil || !finish.After(start) {
+		return meetingTime{}, false
+	}
+
+	days := strings.Join(fields[:len(fields)-1], "")
+	if days == "" || strings.Trim(days, "MTWRFSU") != "" {
+		return meetingTime{}, false
+	}
+	return meetingTime{days: days, start: start.Hour()*60 + start.Minute(), end: finish.Hour()*60 + finish.Minute()}, true
+}
+
+// overlaps reports whether two meetings share a day and overlap in time.
+func (m meetingTime) overlaps(o meetingTime) bool {
+	return strings.ContainsAny(m.days, o.days) && m.start < o.end && o.start < m.end
+}
+
+// validateSchedule checks the schedule CRNs, busy times, and conflicts setting.
+func (c *Config) validateSchedule() error {
+	if c.Conflicts == "" {
+		c.Conflicts = ConflictsFlag
+	}
+	if c.Conflicts != ConflictsFlag && c.Conflicts != ConflictsSuppress {
+		return fmt.Errorf("invalid conflicts %q: must be flag or suppress", c.Conflicts)
+	}
+	for _, crn := range c.Schedule {
+		if err := validateCRN(crn); err != nil {
+			return fmt.Errorf("schedule: %w", err)
+		}
+	}
+	for _, busy := range c.BusyTimes {
+		if _, ok := parseMeeting(busy); !ok {
+			return fmt.Errorf("invalid busyTimes entry %q: use days and times like \"M W F 10:10AM-11:00AM\"", busy)
+		}
+	}
+	return nil
+}
+
+// loadSchedule looks up the meeting times of the schedule's CRNs and adds the
+// busy times, for comparing against sections that open. A CRN that can't be
+// looked up is reported and left out.
+func (c *Config) loadSchedule() error {
+	var busy []busyTime
+	for _, crn := range c.Schedule {
+		section, err := c.getSection(crn)
+		if err != nil {
+			if errors.Is(err, ErrLoginPage) || errors.Is(err, ErrChallenge) {
+				return err
+			}
+			PrintScheduleNotFound(crn, err)
+			continue
+		}
+		if m, ok := parseMeeting(section.Meeting()); ok {
+			busy = append(busy, busyTime{label: fmt.Sprintf("%s (%s) %s", section.Course, crn, section.Meeting()), meetingTime: m})
+		}
+	}
+	for _, s := range c.BusyTimes {
+		m, _ := parseMeeting(s)
+		busy = append(busy, busyTime{label: s, meetingTime: m})
+	}
+	c.busy = busy
+	return nil
+}
+
+// conflict returns what in the schedule a course's meeting time clashes with.
+func (c Config) conflict(course CourseStatus) (string, bool) {
+	m, ok := parseMeeting(course.Meeting)
+	if !ok {
+		return "", false
+	}
+	var clashes []string
+	for _, b := range c.busy {
+		if m.overlaps(b.meetingTime) {
+			clashes = append(clashes, b.label)
+		}
+	}
+	return strings.Join(clashes, "; "), len(clashes) > 0
+}
+
+// suppressed reports whether a course's opening isn't alerted because it clashes
+// with the schedule.
+func (c Config) suppressed(course CourseStatus) bool {
+	_, clashes := c.conflict(course)
+	return clashes && c.Conflicts == ConflictsSuppress
+}
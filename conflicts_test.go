@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// ===================
+// parseMeeting tests
+// ===================
+
+func TestParseMeeting(t *testing.T) {
+	m, ok := parseMeeting("M W F 10:10AM-11:00AM")
+	if !ok || m.days != "MWF" || m.start != 10*60+10 || m.end != 11*60 {
+		t.Errorf("got %+v, %v", m, ok)
+	}
+	if m, ok := parseMeeting("T R 2:00PM-3:15PM"); !ok || m.start != 14*60 || m.end != 15*60+15 {
+		t.Errorf("got %+v, %v for an afternoon meeting", m, ok)
+	}
+	for _, s := range []string{"", "(ARR)", "ONLINE", "M W F", "M W F 11:00AM-10:10AM", "X 10:10AM-11:00AM"} {
+		if _, ok := parseMeeting(s); ok {
+			t.Errorf("expected %q to have no meeting time", s)
+		}
+	}
+}
+
+func TestMeetingTime_Overlaps(t *testing.T) {
+	mwf, _ := parseMeeting("M W F 10:10AM-11:00AM")
+	tests := map[string]bool{
+		"M W F 10:30AM-11:20AM": true,
+		"W 9:00AM-10:15AM":      true,
+		"M W F 11:00AM-11:50AM": false, // back to back
+		"T R 10:10AM-11:00AM":   false, // other days
+	}
+	for s, want := range tests {
+		other, _ := parseMeeting(s)
+		if got := mwf.overlaps(other); got != want {
+			t.Errorf("overlaps(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+// ===================
+// Schedule config tests
+// ===================
+
+func TestLoadConfig_ConflictsDefault(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "busyTimes": ["T R 2:00PM-3:15PM"]}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Conflicts != ConflictsFlag {
+		t.Errorf("expected conflicts to default to flag, got %q", cfg.Conflicts)
+	}
+}
+
+func TestLoadConfig_ScheduleErrors(t *testing.T) {
+	tests := map[string]string{
+		"conflicts": `{"crns": ["12345"], "conflicts": "ignore"}`,
+		"schedule":  `{"crns": ["12345"], "schedule": ["1234"]}`,
+		"busyTimes": `{"crns": ["12345"], "busyTimes": ["Tuesday afternoons"]}`,
+	}
+	for name, config := range tests {
+		if _, err := loadConfig(createTempConfig(t, config)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// ===================
+// conflict tests
+// ===================
+
+func TestConflict(t *testing.T) {
+	cfg := Config{BusyTimes: []string{"T R 2:00PM-3:15PM"}, Conflicts: ConflictsFlag}
+	if err := cfg.loadSchedule(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clash, ok := cfg.conflict(CourseStatus{Meeting: "T R 3:00PM-4:15PM"})
+	if !ok || clash != "T R 2:00PM-3:15PM" {
+		t.Errorf("got %q, %v; want the busy time", clash, ok)
+	}
+	if _, ok := cfg.conflict(CourseStatus{Meeting: "M W F 3:00PM-3:50PM"}); ok {
+		t.Error("expected no clash on other days")
+	}
+	if cfg.suppressed(CourseStatus{Meeting: "T R 3:00PM-4:15PM"}) {
+		t.Error("expected flag to alert anyway")
+	}
+	cfg.Conflicts = ConflictsSuppress
+	if !cfg.suppressed(CourseStatus{Meeting: "T R 3:00PM-4:15PM"}) {
+		t.Error("expected suppress to skip the alert")
+	}
+}
+
+func TestNotifyOpen_FlagsConflict(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601", BusyTimes: []string{"M W F 10:10AM-11:00AM"}}
+	cfg.loadSchedule()
+	sender := &MockEmailSender{}
+
+	cfg.notifyOpen(newMemoryStateStore(), sender, CourseStatus{CRN: "12345", Name: "Intro to Testing", Meeting: "M W F 10:10AM-11:00AM"})
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Subject, "schedule clash") || !strings.Contains(sender.Sent[0].Body, "M W F 10:10AM-11:00AM in your schedule") {
+		t.Errorf("expected the clash in the alert, got %+v", sender.Sent)
+	}
+}
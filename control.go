@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ControlTimeout is how long openseatctl waits for an answer. A running watcher
+// only answers between rounds of checks, which can take a while with many CRNs.
+const ControlTimeout = 2 * time.Minute
+
+// controlCommands are the commands the control socket accepts
+var controlCommands = []string{"status", "add", "remove", "pause", "resume", "check", "stop"}
+
+// controlRequest is one command sent over the control socket
+type controlRequest struct {
+	Command string `json:"command"`
+	CRN     string `json:"crn,omitempty"` // for add and remove
+}
+
+// controlResponse is the answer to a controlRequest: the watcher's status after the command
+type controlResponse struct {
+	OK     bool        `json:"ok"`
+	Error  string      `json:"error,omitempty"`
+	Status watchStatus `json:"status"`
+}
+
+// controlCall is a request waiting for the watch loop to handle it
+type controlCall struct {
+	Request controlRequest
+	reply   chan controlResponse
+	sent    chan struct{} // closed once the response is written
+}
+
+// respond answers the call with the outcome of the command and the status after it,
+// returning once the answer is written so that it isn't lost if the watcher exits next.
+func (c controlCall) respond(err error, status watchStatus) {
+	resp := controlResponse{OK: err == nil, Status: status}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	c.reply <- resp
+	<-c.sent
+}
+
+// controlSocketPath returns the control socket for a config, kept next to it like the lock file.
+func controlSocketPath(configPath string) string {
+	return configPath + ".sock"
+}
+
+// startControl listens on a Unix socket that only the current user can use and passes
+// each request to the returned channel for the watch loop to handle. If the socket
+// can't be created, a warning is shown and watching goes on without it.
+// The returned function closes the socket.
+func startControl(path string) (<-chan controlCall, func()) {
+	listener, err := listenControl(path)
+	if err != nil {
+		PrintControlUnavailable(err)
+		return nil, func() {}
+	}
+
+	calls := make(chan controlCall)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveControl(conn, calls)
+		}
+	}()
+	return calls, func() {
+		listener.Close()
+		os.Remove(path)
+	}
+}
+
+// listenControl creates the control socket where only the current user can reach
+// it from the start: it's bound inside a new directory only they can enter, limited
+// to them, and only then renamed into place. Changing the umask instead would
+// affect every file the watcher creates meanwhile, since it's process-wide.
+func listenControl(path string) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".sock-*") // created 0700
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	private := filepath.Join(dir, "s") // short, since socket paths are limited to about 100 bytes
+	listener, err := net.Listen("unix", private)
+	if err != nil {
+		return nil, err
+	}
+	// the socket is renamed, so closing it mustn't remove whatever then has its old name
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(private, 0o600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	// the lock file guarantees no other watcher is using the socket, so one already
+	// there is left over from a crash, and the rename replaces it
+	if err := os.Rename(private, path); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// serveControl reads one request from a connection, waits for the watch loop to handle it, and writes the response.
+func serveControl(conn net.Conn, calls chan<- controlCall) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(ControlTimeout))
+
+	var req controlRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		json.NewEncoder(conn).Encode(controlResponse{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+	call := controlCall{Request: req, reply: make(chan controlResponse, 1), sent: make(chan struct{})}
+	defer close(call.sent)
+	select {
+	case calls <- call:
+	case <-time.After(ControlTimeout):
+		return
+	}
+	json.NewEncoder(conn).Encode(<-call.reply) // bounded by the connection deadline
+}
+
+// handleControl applies a control socket command and returns what the watch loop
+// should do about it, the same way handleKey does for a keypress.
+func (ctl *controls) handleControl(req controlRequest, courses []CourseStatus) (keyAction, string, error) {
+	switch req.Command {
+	case "status":
+	case "pause":
+		ctl.paused = true
+	case "resume":
+		ctl.resume()
+	case "check":
+		return keyCheckNow, "", nil
+	case "add":
+		if err := validateCRN(req.CRN); err != nil {
+			return keyNone, "", err
+		}
+		return keyAdd, req.CRN, nil
+	case "remove":
+		i := slices.IndexFunc(courses, func(c CourseStatus) bool { return c.CRN == req.CRN && !c.Found })
+		if i < 0 {
+			return keyNone, "", fmt.Errorf("CRN %s is not being watched", req.CRN)
+		}
+		ctl.highlight = i
+		return keyRemove, "", nil
+	case "stop":
+		return keyQuit, "", nil
+	default:
+		return keyNone, "", fmt.Errorf("unknown command %q: must be one of %s", req.Command, strings.Join(controlCommands, ", "))
+	}
+	return keyNone, "", nil
+}
+
+// sendControl sends a request to the watcher listening on the socket and returns its response.
+func sendControl(path string, req controlRequest) (controlResponse, error) {
+	conn, err := net.DialTimeout("unix", path, 5*time.Second)
+	if err != nil {
+		return controlResponse{}, fmt.Errorf("no running watcher found at %s: %w", path, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(ControlTimeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return controlResponse{}, err
+	}
+	var resp controlResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return controlResponse{}, fmt.Errorf("no answer from the watcher: %w", err)
+	}
+	if !resp.OK {
+		return resp, errors.New(resp.Error)
+	}
+	return resp, nil
+}
+
+// runCtl sends one command to a running watcher and prints its status afterwards.
+// It runs as `openseat ctl <command>`, or as `openseatctl <command>` through a symlink.
+func runCtl(args []string) error {
+	fs := flag.NewFlagSet("ctl", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "config `file` the watcher was started with")
+	asJSON := fs.Bool("json", false, "print the status as JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: openseatctl [--config file] [--json] <%s> [crn]\n", strings.Join(controlCommands, "|"))
+		fs.PrintDefaults()
+	}
+	// flags may come before, between, or after the command and CRN
+	var words []string
+	for fs.Parse(args); fs.NArg() > 0; fs.Parse(fs.Args()[1:]) {
+		words = append(words, fs.Arg(0))
+	}
+	words = append(words, "", "")
+
+	req := controlRequest{Command: words[0]}
+	if req.Command == "" {
+		req.Command = "status"
+	}
+	if req.Command == "add" || req.Command == "remove" {
+		req.CRN = words[1]
+		if req.CRN == "" {
+			return configError(fmt.Errorf("%s needs a CRN, e.g. openseatctl %s 12345", req.Command, req.Command))
+		}
+	}
+
+	resp, err := sendControl(controlSocketPath(*configPath), req)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(resp.Status)
+	}
+	PrintControlStatus(resp.Status)
+	return nil
+}
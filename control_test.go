@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// handleControl tests
+// ===================
+
+func TestHandleControl(t *testing.T) {
+	courses := []CourseStatus{{CRN: "11111"}, {CRN: "22222", Found: true}, {CRN: "33333"}}
+	tests := []struct {
+		req    controlRequest
+		action keyAction
+		typed  string
+	}{
+		{controlRequest{Command: "status"}, keyNone, ""},
+		{controlRequest{Command: "check"}, keyCheckNow, ""},
+		{controlRequest{Command: "add", CRN: "44444"}, keyAdd, "44444"},
+		{controlRequest{Command: "remove", CRN: "33333"}, keyRemove, ""},
+		{controlRequest{Command: "stop"}, keyQuit, ""},
+	}
+
+	for _, tt := range tests {
+		var ctl controls
+		action, typed, err := ctl.handleControl(tt.req, courses)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.req.Command, err)
+		}
+		if action != tt.action || typed != tt.typed {
+			t.Errorf("%s: got (%v, %q), want (%v, %q)", tt.req.Command, action, typed, tt.action, tt.typed)
+		}
+	}
+}
+
+func TestHandleControl_RemoveHighlightsCRN(t *testing.T) {
+	courses := []CourseStatus{{CRN: "11111"}, {CRN: "33333"}}
+	var ctl controls
+
+	ctl.handleControl(controlRequest{Command: "remove", CRN: "33333"}, courses)
+	if h, _ := ctl.highlighted(courses); courses[h].CRN != "33333" {
+		t.Errorf("highlighted %s, want 33333", courses[h].CRN)
+	}
+}
+
+func TestHandleControl_PauseAndResume(t *testing.T) {
+	var ctl controls
+
+	ctl.handleControl(controlRequest{Command: "pause"}, nil)
+	if !ctl.paused {
+		t.Error("expected pause to hold checks")
+	}
+	ctl.handleControl(controlRequest{Command: "resume"}, nil)
+	if ctl.paused {
+		t.Error("expected resume to let checks continue")
+	}
+}
+
+func TestHandleControl_Errors(t *testing.T) {
+	courses := []CourseStatus{{CRN: "11111"}, {CRN: "22222", Found: true}}
+	tests := map[string]controlRequest{
+		"unknown command":   {Command: "restart"},
+		"invalid CRN":       {Command: "add", CRN: "12"},
+		"unwatched CRN":     {Command: "remove", CRN: "99999"},
+		"already found CRN": {Command: "remove", CRN: "22222"},
+	}
+
+	for name, req := range tests {
+		var ctl controls
+		if _, _, err := ctl.handleControl(req, courses); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// ===================
+// control socket tests
+// ===================
+
+func TestControlSocket_RoundTrip(t *testing.T) {
+	path := controlSocketPath(filepath.Join(t.TempDir(), "config.json"))
+	calls, stop := startControl(path)
+	if calls == nil {
+		t.Fatal("control socket not started")
+	}
+	defer stop()
+
+	// stand in for the watch loop
+	go func() {
+		var ctl controls
+		courses := []CourseStatus{{CRN: "12345", Name: "Intro to Testing"}}
+		w := Config{}.newStatusWriter(time.Now())
+		for call := range calls {
+			_, _, err := ctl.handleControl(call.Request, courses)
+			call.respond(err, w.status(ctl.statusState(time.Now()), time.Now(), courses, nil))
+		}
+	}()
+
+	resp, err := sendControl(path, controlRequest{Command: "pause"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status.State != StatusPaused || len(resp.Status.CRNs) != 1 || resp.Status.CRNs[0].CRN != "12345" {
+		t.Errorf("unexpected status: %+v", resp.Status)
+	}
+
+	_, err = sendControl(path, controlRequest{Command: "remove", CRN: "99999"})
+	if err == nil || !strings.Contains(err.Error(), "99999") {
+		t.Errorf("expected the watcher's error, got %v", err)
+	}
+
+	stop()
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the socket removed once stopped, got %v", err)
+	}
+}
+
+func TestSendControl_NoWatcher(t *testing.T) {
+	path := controlSocketPath(filepath.Join(t.TempDir(), "config.json"))
+	if _, err := sendControl(path, controlRequest{Command: "status"}); err == nil {
+		t.Error("expected error with no watcher running")
+	}
+}
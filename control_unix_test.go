@@ -0,0 +1,42 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestListenControl_OnlyCurrentUser(t *testing.T) {
+	// a permissive umask would leave the socket open to everyone if it were created in place
+	mask := syscall.Umask(0)
+	defer syscall.Umask(mask)
+
+	dir := t.TempDir()
+	path := controlSocketPath(filepath.Join(dir, "config.json"))
+	os.WriteFile(path, nil, 0o666) // left over from a crash
+	listener, err := listenControl(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer listener.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Mode().Type() != os.ModeSocket {
+		t.Errorf("expected the leftover file replaced by the socket, got mode %v", info.Mode())
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		t.Errorf("socket created with mode %v, want no access for other users", perm)
+	}
+	if syscall.Umask(0) != 0 {
+		t.Error("expected the umask left alone, since it's shared by the whole process")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only the socket left next to the config, got %v", entries)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readCRNFile reads CRNs to watch from a file, or from stdin when path is "-".
+func readCRNFile(path string) ([]string, error) {
+	if path == "-" {
+		return parseCRNList(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CRN file: %w", err)
+	}
+	defer f.Close()
+	return parseCRNList(f)
+}
+
+// parseCRNList reads CRNs separated by newlines, commas, semicolons, or whitespace,
+// so a column or row pasted from a spreadsheet works as is. Blank lines and
+// anything after a # are ignored. Every CRN is validated, and errors name the line.
+func parseCRNList(r io.Reader) ([]string, error) {
+	var crns []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.FieldsFunc(text, func(r rune) bool {
+			return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\r'
+		})
+		for _, crn := range fields {
+			crn = strings.Trim(crn, `"'`)
+			if err := validateCRN(crn); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			crns = append(crns, crn)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CRNs: %w", err)
+	}
+	return crns, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ===================
+// parseCRNList tests
+// ===================
+
+func TestParseCRNList_Separators(t *testing.T) {
+	input := "# pasted from my spreadsheet\n12345\n67890, 13579;24680  # backups\n\n\"11111\"\t22222\r\n"
+
+	got, err := parseCRNList(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"12345", "67890", "13579", "24680", "11111", "22222"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseCRNList_ErrorNamesLine(t *testing.T) {
+	_, err := parseCRNList(strings.NewReader("12345\n6789\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("got %v, want an error naming line 2", err)
+	}
+}
+
+// ===================
+// loadConfigWithCRNs tests
+// ===================
+
+func TestLoadConfigWithCRNs_MergesAndDedupes(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"]}`)
+	crnFile := filepath.Join(t.TempDir(), "crns.txt")
+	if err := os.WriteFile(crnFile, []byte("67890\n12345\n67890\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	extra, err := readCRNFile(crnFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, err := loadConfigWithCRNs(path, extra)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(cfg.CRNs, " ") != "12345 67890" {
+		t.Errorf("CRNs = %v, want [12345 67890]", cfg.CRNs)
+	}
+	if strings.Join(cfg.duplicateCRNs, " ") != "12345 67890" {
+		t.Errorf("duplicateCRNs = %v, want [12345 67890]", cfg.duplicateCRNs)
+	}
+}
+
+func TestLoadConfigWithCRNs_ConfigNeedsNoCRNs(t *testing.T) {
+	if _, err := loadConfigWithCRNs(createTempConfig(t, `{}`), []string{"12345"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReadCRNFile_Missing(t *testing.T) {
+	if _, err := readCRNFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
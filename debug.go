@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrUnexpectedPage indicates a timetable response that didn't parse the way OpenSeat expects,
+// usually because the timetable's HTML changed. The response is saved for diagnosis.
+var ErrUnexpectedPage = errors.New("unexpected timetable response")
+
+// noResultsMarker is the message the timetable shows instead of a table when a search matches nothing
+const noResultsMarker = "NO SECTIONS FOUND"
+
+// debugDir returns where unexpected responses are saved.
+func (c Config) debugDir() string {
+	if c.DebugDir != "" {
+		return c.DebugDir
+	}
+	return filepath.Join(os.TempDir(), "openseat-debug")
+}
+
+// checkResults verifies the parsing assumptions behind a negative result: the response
+// either has a results table or says no sections matched, and any CRN rows in the
+// table have the required columns. Problems are reported via unexpectedPage.
+// what describes the search, e.g. "CRN 12345".
+func (c Config) checkResults(p *page, what string, required ...string) error {
+	table := p.Find(".dataentrytable")
+	if table.Length() == 0 {
+		if strings.Contains(strings.ToUpper(p.Text()), noResultsMarker) {
+			return nil
+		}
+		return c.unexpectedPage(p, what, "no results table")
+	}
+
+	if len(parseSections(p.Document)) == 0 {
+		return nil
+	}
+	cols := detectColumns(table)
+	for _, col := range required {
+		if _, ok := cols[col]; !ok {
+			return c.unexpectedPage(p, what, fmt.Sprintf("results table has no %s column", col))
+		}
+	}
+	return nil
+}
+
+// unexpectedPage saves the raw response to the debug directory and returns an
+// ErrUnexpectedPage error describing the problem and where the response was saved.
+func (c Config) unexpectedPage(p *page, what, problem string) error {
+	path, err := saveSnapshot(c.debugDir(), what, p.raw)
+	if err != nil {
+		return fmt.Errorf("%w for %s: %s (could not save response: %v)", ErrUnexpectedPage, what, problem, err)
+	}
+	return fmt.Errorf("%w for %s: %s (response saved to %s)", ErrUnexpectedPage, what, problem, path)
+}
+
+// saveSnapshot writes a raw response to a timestamped file in dir and returns its path.
+func saveSnapshot(dir, what string, raw []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s.html", time.Now().Format("20060102-150405.000"), strings.ReplaceAll(what, " ", "-"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func newPage(t *testing.T, html string) *page {
+	t.Helper()
+	return &page{Document: parseHTML(t, html), raw: []byte(html)}
+}
+
+// ===================
+// checkResults tests
+// ===================
+
+func TestCheckResults_NoTableSavesSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{DebugDir: dir}
+	html := `<html><body>Something else entirely</body></html>`
+
+	err := cfg.checkResults(newPage(t, html), "12345")
+	if !errors.Is(err, ErrUnexpectedPage) {
+		t.Fatalf("expected ErrUnexpectedPage, got %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 snapshot, got %d", len(entries))
+	}
+	if !strings.Contains(err.Error(), entries[0].Name()) {
+		t.Errorf("error %q should reference the snapshot %s", err, entries[0].Name())
+	}
+	saved, _ := os.ReadFile(dir + "/" + entries[0].Name())
+	if string(saved) != html {
+		t.Errorf("snapshot = %q, want raw response", saved)
+	}
+}
+
+func TestCheckResults_NoSectionsFoundIsExpected(t *testing.T) {
+	cfg := Config{DebugDir: t.TempDir()}
+	err := cfg.checkResults(newPage(t, `<html><b>NO SECTIONS FOUND FOR THIS INQUIRY.</b></html>`), "12345")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckResults_EmptyTableIsExpected(t *testing.T) {
+	cfg := Config{DebugDir: t.TempDir()}
+	err := cfg.checkResults(newPage(t, `<table class="dataentrytable"></table>`), "12345", "seats")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckResults_MissingRequiredColumn(t *testing.T) {
+	cfg := Config{DebugDir: t.TempDir()}
+	err := cfg.checkResults(newPage(t, resultsHTML), "12345", "seats")
+	if !errors.Is(err, ErrUnexpectedPage) {
+		t.Errorf("expected ErrUnexpectedPage for missing seats column, got %v", err)
+	}
+}
+
+// ===================
+// snapshot integration tests
+// ===================
+
+func TestGetCourseName_UnexpectedPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body>Maintenance in progress</body></html>`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", DebugDir: dir}
+	_, err := cfg.getCourseName("12345")
+	if !errors.Is(err, ErrUnexpectedPage) {
+		t.Fatalf("expected ErrUnexpectedPage, got %v", err)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected 1 snapshot, got %d", len(entries))
+	}
+}
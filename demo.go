@@ -15,13 +15,13 @@ func RunDemo() {
 
 	// Display banner and config
 	PrintBanner()
-	PrintConfigBox(len(courses), demoEmail, 30, "202601")
+	PrintConfigBox(len(courses), demoEmail, 30, "202601", "")
 
 	// Simulate fetching courses
 	PrintFetchingHeader()
 	time.Sleep(500 * time.Millisecond)
 	for _, course := range courses {
-		PrintCourseFound(course.CRN, course.Name)
+		PrintCourseFound(course.CRN, course.Name, "")
 		time.Sleep(400 * time.Millisecond)
 	}
 
@@ -57,7 +57,7 @@ func RunDemo() {
 				courses[i].Found = true
 				remaining--
 
-				PrintSeatAvailable(courses[i].Name, courses[i].CRN)
+				PrintSeatAvailable(courses[i].Name, courses[i].CRN, false)
 				time.Sleep(300 * time.Millisecond)
 				PrintEmailSent(demoEmail)
 				time.Sleep(500 * time.Millisecond)
@@ -75,7 +75,7 @@ func RunDemo() {
 		for time.Now().Before(waitUntil) {
 			timeLeft := time.Until(waitUntil).Round(time.Second)
 			found := len(courses) - remaining
-			PrintWaitingStatus(spin, attempt, found, len(courses), timeLeft.String(), checkTime)
+			PrintWaitingStatus(spin, attempt, found, len(courses), timeLeft.String(), checkTime, "")
 			time.Sleep(100 * time.Millisecond)
 			spin++
 		}
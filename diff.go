@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// courseArgPattern matches a course given on the command line: "CS-3114", "CS 3114", or "cs3114"
+var courseArgPattern = regexp.MustCompile(`^([A-Za-z]{2,5})[\s-]?(\d{4})$`)
+
+// parseCourseCode splits a course such as "CS-3114" into its subject and number.
+func parseCourseCode(s string) (subject, number string, err error) {
+	m := courseArgPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return "", "", fmt.Errorf("invalid course %q: expected subject and number like CS-3114", s)
+	}
+	return strings.ToUpper(m[1]), m[2], nil
+}
+
+// termDiff summarizes how a course's sections changed between two terms
+type termDiff struct {
+	From, To []Section
+
+	FromCapacity, ToCapacity int
+
+	InstructorsAdded, InstructorsRemoved []string
+	MeetingsAdded, MeetingsRemoved       []string
+}
+
+// runDiff compares a course's sections between two terms.
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file` (optional, for campus)")
+	course := fs.String("course", "", "course to compare, e.g. CS-3114")
+	from := fs.String("from", "", "earlier term `code`, e.g. 202509")
+	to := fs.String("to", "", "later term `code` (defaults to the config's term)")
+	fs.Parse(args)
+
+	subject, number, err := parseCourseCode(*course)
+	if err != nil {
+		return configError(err)
+	}
+
+	cfg, err := loadSearchConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+	if err := cfg.resolveAutoTerms(time.Now()); err != nil {
+		return err
+	}
+	if err := cfg.resolveCampusNames(); err != nil {
+		return err
+	}
+	if *to == "" {
+		*to = cfg.Term
+	}
+	for _, term := range []string{*from, *to} {
+		if !isTermCode(term) {
+			return configError(fmt.Errorf("--from and --to must be term codes like 202601, got %q", term))
+		}
+	}
+
+	code := subject + "-" + number
+	fromSections, err := cfg.courseSections(*from, subject, number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s for %s: %w", code, *from, err)
+	}
+	toSections, err := cfg.courseSections(*to, subject, number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s for %s: %w", code, *to, err)
+	}
+
+	PrintTermDiff(code, *from, *to, compareTerms(fromSections, toSections))
+	return nil
+}
+
+// courseSections returns every section of a course in the given term, across the configured campuses.
+func (c Config) courseSections(term, subject, number string) ([]Section, error) {
+	c.Term, c.Terms = term, nil
+	sections, err := c.findSections(searchQuery{Subject: subject, Number: number}, fmt.Sprintf("course %s-%s", subject, number))
+	if err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// compareTerms summarizes the differences between a course's sections in two terms.
+func compareTerms(from, to []Section) termDiff {
+	d := termDiff{
+		From:         from,
+		To:           to,
+		FromCapacity: totalCapacity(from),
+		ToCapacity:   totalCapacity(to),
+	}
+
+	fromInstructors := uniqueValues(from, func(s Section) string { return s.Instructor })
+	toInstructors := uniqueValues(to, func(s Section) string { return s.Instructor })
+	d.InstructorsAdded = missingFrom(toInstructors, fromInstructors)
+	d.InstructorsRemoved = missingFrom(fromInstructors, toInstructors)
+
+	fromMeetings := uniqueValues(from, Section.Meeting)
+	toMeetings := uniqueValues(to, Section.Meeting)
+	d.MeetingsAdded = missingFrom(toMeetings, fromMeetings)
+	d.MeetingsRemoved = missingFrom(fromMeetings, toMeetings)
+
+	return d
+}
+
+// totalCapacity sums the capacity of every section with a numeric capacity.
+func totalCapacity(sections []Section) int {
+	total := 0
+	for _, s := range sections {
+		if n, err := strconv.Atoi(s.Capacity); err == nil {
+			total += n
+		}
+	}
+	return total
+}
+
+// uniqueValues returns the distinct non-empty values of field across sections, sorted.
+func uniqueValues(sections []Section, field func(Section) string) []string {
+	var values []string
+	for _, s := range sections {
+		if v := field(s); v != "" && !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	slices.Sort(values)
+	return values
+}
+
+// missingFrom returns the values in a that are not in b.
+func missingFrom(a, b []string) []string {
+	var out []string
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// ===================
+// parseCourseCode tests
+// ===================
+
+func TestParseCourseCode(t *testing.T) {
+	for _, in := range []string{"CS-3114", "CS 3114", "cs3114", " CS-3114 "} {
+		subject, number, err := parseCourseCode(in)
+		if err != nil {
+			t.Errorf("parseCourseCode(%q) unexpected error: %v", in, err)
+			continue
+		}
+		if subject != "CS" || number != "3114" {
+			t.Errorf("parseCourseCode(%q) = %s, %s; want CS, 3114", in, subject, number)
+		}
+	}
+
+	for _, in := range []string{"", "CS", "3114", "CS-311", "COMPSCI-3114"} {
+		if _, _, err := parseCourseCode(in); err == nil {
+			t.Errorf("parseCourseCode(%q) expected error", in)
+		}
+	}
+}
+
+// ===================
+// compareTerms tests
+// ===================
+
+func TestCompareTerms(t *testing.T) {
+	from := []Section{
+		{CRN: "11111", Capacity: "100", Instructor: "C Shaffer", Days: "M W F", Begin: "10:10AM", End: "11:00AM"},
+		{CRN: "11112", Capacity: "50", Instructor: "Staff", Days: "T R", Begin: "2:00PM", End: "3:15PM"},
+	}
+	to := []Section{
+		{CRN: "22221", Capacity: "120", Instructor: "C Shaffer", Days: "M W F", Begin: "10:10AM", End: "11:00AM"},
+		{CRN: "22222", Capacity: "60", Instructor: "M Ellis", Days: "T R", Begin: "9:30AM", End: "10:45AM"},
+		{CRN: "22223", Capacity: "Full", Instructor: "M Ellis", Days: "(ARR)"},
+	}
+
+	d := compareTerms(from, to)
+
+	if d.FromCapacity != 150 || d.ToCapacity != 180 {
+		t.Errorf("capacity = %d → %d, want 150 → 180", d.FromCapacity, d.ToCapacity)
+	}
+	if !slices.Equal(d.InstructorsAdded, []string{"M Ellis"}) || !slices.Equal(d.InstructorsRemoved, []string{"Staff"}) {
+		t.Errorf("instructors +%v -%v, want +[M Ellis] -[Staff]", d.InstructorsAdded, d.InstructorsRemoved)
+	}
+	if !slices.Equal(d.MeetingsAdded, []string{"(ARR)", "T R 9:30AM-10:45AM"}) {
+		t.Errorf("meetings added = %v", d.MeetingsAdded)
+	}
+	if !slices.Equal(d.MeetingsRemoved, []string{"T R 2:00PM-3:15PM"}) {
+		t.Errorf("meetings removed = %v", d.MeetingsRemoved)
+	}
+}
+
+// ===================
+// courseSections tests
+// ===================
+
+func TestCourseSections_SearchesTermAndCourse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.FormValue("TERMYEAR") != "202509" || r.FormValue("subj_code") != "CS" || r.FormValue("CRSE_NUMBER") != "3114" {
+			t.Errorf("unexpected search: %v", r.Form)
+		}
+		if r.FormValue("open_only") != "" {
+			t.Error("course search should include full sections")
+		}
+		w.Write([]byte(resultsHTML))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", Terms: []string{"202601", "202606"}}
+	sections, err := cfg.courseSections("202509", "CS", "3114")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sections) != 1 || sections[0].CRN != "13466" {
+		t.Errorf("got %+v, want section 13466", sections)
+	}
+}
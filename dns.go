@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DNS configures how timetable hostnames are looked up. Flaky resolvers, common
+// on dorm and café Wi-Fi, otherwise turn into failed checks at short intervals.
+type DNS struct {
+	Server       string `json:"server"`       // resolver to use instead of the system's, e.g. "1.1.1.1" or "9.9.9.9:53" (optional)
+	CacheMinutes int    `json:"cacheMinutes"` // how long looked-up addresses are reused (optional)
+}
+
+// applyDefaults adds the DNS port to the server and checks it is an IP address.
+func (d *DNS) applyDefaults() error {
+	if d.Server != "" {
+		if _, _, err := net.SplitHostPort(d.Server); err != nil {
+			d.Server = net.JoinHostPort(d.Server, "53")
+		}
+		host, _, _ := net.SplitHostPort(d.Server)
+		if net.ParseIP(host) == nil {
+			return fmt.Errorf("invalid dns server %q: expected an IP address, e.g. 1.1.1.1", d.Server)
+		}
+	}
+	if d.CacheMinutes < 0 {
+		return fmt.Errorf("dns: cacheMinutes must be positive, got %d", d.CacheMinutes)
+	}
+	return nil
+}
+
+// enabled reports whether lookups need anything beyond the system resolver.
+func (d DNS) enabled() bool {
+	return d.Server != "" || d.CacheMinutes > 0
+}
+
+// dnsCache looks up hostnames with the configured resolver and remembers the
+// answers. When a lookup fails, an expired answer is used rather than failing the
+// request, since the timetable's addresses rarely change.
+type dnsCache struct {
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	ttl        time.Duration
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry // by hostname
+}
+
+// dnsEntry is one remembered lookup
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// newDNSCache returns a cache for the settings, or nil when the system resolver is used as is.
+func (d DNS) newDNSCache() *dnsCache {
+	if !d.enabled() {
+		return nil
+	}
+	resolver := net.DefaultResolver
+	if d.Server != "" {
+		var dialer net.Dialer
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, d.Server)
+			},
+		}
+	}
+	return &dnsCache{
+		lookupHost: resolver.LookupHost,
+		ttl:        time.Duration(d.CacheMinutes) * time.Minute,
+		entries:    make(map[string]dnsEntry),
+	}
+}
+
+// lookup returns the addresses for host, from the cache while they're fresh.
+func (d *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	d.mu.Lock()
+	cached, ok := d.entries[host]
+	d.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.addrs, nil
+	}
+
+	addrs, err := d.lookupHost(ctx, host)
+	if err != nil {
+		if ok {
+			return cached.addrs, nil
+		}
+		return nil, err
+	}
+	if d.ttl > 0 {
+		d.mu.Lock()
+		d.entries[host] = dnsEntry{addrs: addrs, expires: time.Now().Add(d.ttl)}
+		d.mu.Unlock()
+	}
+	return addrs, nil
+}
+
+// dialer opens timetable connections, limited to one IP version and looking
+// hostnames up through the DNS cache when either is configured
+type dialer struct {
+	net.Dialer
+	ipVersion string
+	dns       *dnsCache // nil to let the system resolve
+}
+
+// newDialer returns the dialer for the config's ipVersion and dns settings.
+func (c Config) newDialer() *dialer {
+	return &dialer{
+		Dialer:    net.Dialer{Timeout: DefaultRequestTimeout, KeepAlive: DefaultRequestTimeout},
+		ipVersion: c.IPVersion,
+		dns:       c.DNS.newDNSCache(),
+	}
+}
+
+// dial connects to addr, trying each of its addresses in turn.
+func (d *dialer) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	if strings.HasPrefix(network, "tcp") && d.ipVersion != "" {
+		network = "tcp" + d.ipVersion // e.g. tcp4, so a broken IPv6 route is never tried
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if d.dns == nil || err != nil || net.ParseIP(host) != nil {
+		return d.DialContext(ctx, network, addr)
+	}
+
+	addrs, err := d.dns.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, ip := range addrs {
+		if !d.allows(net.ParseIP(ip)) {
+			continue
+		}
+		conn, err := d.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no IPv%s address for %s", d.ipVersion, host)
+	}
+	return nil, errs[0]
+}
+
+// allows reports whether ip is of the dialer's IP version.
+func (d *dialer) allows(ip net.IP) bool {
+	switch d.ipVersion {
+	case IPv4:
+		return ip.To4() != nil
+	case IPv6:
+		return ip.To4() == nil
+	}
+	return ip != nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// ===================
+// DNS config tests
+// ===================
+
+func TestDNS_ApplyDefaults(t *testing.T) {
+	d := DNS{Server: "1.1.1.1"}
+	if err := d.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Server != "1.1.1.1:53" {
+		t.Errorf("expected the DNS port to be added, got %q", d.Server)
+	}
+
+	d = DNS{Server: "[2606:4700:4700::1111]:5353"}
+	if err := d.applyDefaults(); err != nil || d.Server != "[2606:4700:4700::1111]:5353" {
+		t.Errorf("expected the server kept as is, got %q, %v", d.Server, err)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidDNS(t *testing.T) {
+	for _, dns := range []string{`{"server": "dns.example.com"}`, `{"cacheMinutes": -1}`} {
+		path := createTempConfig(t, `{"crns": ["12345"], "dns": `+dns+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", dns)
+		}
+	}
+}
+
+// ===================
+// dnsCache tests
+// ===================
+
+// fakeLookup answers lookups with addrs, or fails once fail is set, counting calls.
+type fakeLookup struct {
+	addrs []string
+	fail  bool
+	calls int
+}
+
+func (f *fakeLookup) lookupHost(ctx context.Context, host string) ([]string, error) {
+	f.calls++
+	if f.fail {
+		return nil, errors.New("server misbehaving")
+	}
+	return f.addrs, nil
+}
+
+func TestDNSCache_ReusesAnswers(t *testing.T) {
+	f := &fakeLookup{addrs: []string{"192.0.2.1"}}
+	d := &dnsCache{lookupHost: f.lookupHost, ttl: time.Minute, entries: make(map[string]dnsEntry)}
+
+	for range 3 {
+		if addrs, err := d.lookup(context.Background(), "banner.example.edu"); err != nil || addrs[0] != "192.0.2.1" {
+			t.Fatalf("unexpected lookup result: %v, %v", addrs, err)
+		}
+	}
+	if f.calls != 1 {
+		t.Errorf("expected one lookup, got %d", f.calls)
+	}
+}
+
+func TestDNSCache_StaleAnswerOnFailure(t *testing.T) {
+	f := &fakeLookup{addrs: []string{"192.0.2.1"}}
+	d := &dnsCache{lookupHost: f.lookupHost, ttl: time.Minute, entries: make(map[string]dnsEntry)}
+	d.lookup(context.Background(), "banner.example.edu")
+
+	d.entries["banner.example.edu"] = dnsEntry{addrs: f.addrs, expires: time.Now().Add(-time.Second)}
+	f.fail = true
+	addrs, err := d.lookup(context.Background(), "banner.example.edu")
+	if err != nil || len(addrs) != 1 {
+		t.Errorf("expected the expired answer when the resolver fails, got %v, %v", addrs, err)
+	}
+	if f.calls != 2 {
+		t.Errorf("expected an expired answer to be looked up again, got %d lookups", f.calls)
+	}
+
+	if _, err := d.lookup(context.Background(), "other.example.edu"); err == nil {
+		t.Error("expected an error for a host never looked up")
+	}
+}
+
+func TestDNSCache_NoCaching(t *testing.T) {
+	f := &fakeLookup{addrs: []string{"192.0.2.1"}}
+	d := &dnsCache{lookupHost: f.lookupHost, entries: make(map[string]dnsEntry)}
+	d.lookup(context.Background(), "banner.example.edu")
+	d.lookup(context.Background(), "banner.example.edu")
+	if f.calls != 2 {
+		t.Errorf("expected every request looked up without cacheMinutes, got %d lookups", f.calls)
+	}
+}
+
+// ===================
+// dialer tests
+// ===================
+
+func TestDialer_UsesCachedAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	f := &fakeLookup{addrs: []string{"::1", "127.0.0.1"}}
+	d := Config{IPVersion: IPv4}.newDialer()
+	d.dns = &dnsCache{lookupHost: f.lookupHost, ttl: time.Minute, entries: make(map[string]dnsEntry)}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = d.dial
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get("http://banner.example.edu:" + port)
+	if err != nil {
+		t.Fatalf("expected the looked-up IPv4 address to be dialed: %v", err)
+	}
+	resp.Body.Close()
+
+	f.addrs = []string{"::1"}
+	d.dns.entries = make(map[string]dnsEntry)
+	if _, err := d.dial(context.Background(), "tcp", "banner.example.edu:"+port); err == nil {
+		t.Error("expected an error when the host has no IPv4 address")
+	}
+}
+
+func TestBaseTransport_DNS(t *testing.T) {
+	if (Config{DNS: DNS{CacheMinutes: 5}}).baseTransport() == http.DefaultTransport {
+		t.Error("expected a custom transport with DNS caching")
+	}
+}
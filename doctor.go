@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DoctorSubject is the subject searched by `openseat doctor`; it's large and offered every term
+const DoctorSubject = "MATH"
+
+// doctorColumns are the results columns OpenSeat relies on
+var doctorColumns = []string{"crn", "course", "title", "capacity", "instructor", "days", "begin", "end", "location"}
+
+// doctorCheck is the outcome of verifying one parsing assumption
+type doctorCheck struct {
+	Name   string
+	Err    error // nil when the assumption holds
+	Config bool  // a failure points at the user's config or session rather than a Banner change
+}
+
+// runDoctor verifies every parsing assumption against the live timetable.
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file` (optional)")
+	subject := fs.String("subject", DoctorSubject, "subject `code` to search for the test query")
+	fs.Parse(args)
+
+	cfg, err := loadSearchConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+
+	checks := cfg.doctorChecks(strings.ToUpper(*subject), time.Now())
+	for _, check := range checks {
+		PrintDoctorCheck(check.Name, check.Err)
+	}
+
+	failed := slices.IndexFunc(checks, func(c doctorCheck) bool { return c.Err != nil })
+	if failed < 0 {
+		PrintDoctorVerdict(true, T("doctor.healthy"))
+		return nil
+	}
+	err = fmt.Errorf("doctor: %s failed", checks[failed].Name)
+	if checks[failed].Config {
+		PrintDoctorVerdict(false, T("doctor.config_problem"))
+		return configError(err)
+	}
+	PrintDoctorVerdict(false, T("doctor.banner_changed"))
+	return exitError{code: ExitNetwork, err: err}
+}
+
+// doctorChecks runs a known query against the timetable and checks each assumption
+// the parser relies on. Checks stop at the first failure, since later checks depend
+// on earlier ones.
+func (c Config) doctorChecks(subject string, now time.Time) []doctorCheck {
+	var checks []doctorCheck
+	pass := func(name string, err error, config bool) bool {
+		checks = append(checks, doctorCheck{Name: name, Err: err, Config: config})
+		return err == nil
+	}
+
+	terms, err := c.fetchTerms()
+	if !pass(T("doctor.terms_listed"), err, false) {
+		return checks
+	}
+
+	if c.Term == AutoTerm {
+		c.Term = pickTerm(terms, expectedTerm(now))
+	}
+	err = nil
+	if !slices.Contains(terms, c.Term) {
+		err = errors.New(T("doctor.term_not_offered", c.Term, strings.Join(terms, ", ")))
+	}
+	if !pass(T("doctor.term_offered"), err, true) {
+		return checks
+	}
+
+	c.Terms, c.Campuses = nil, nil
+	what := "subject " + subject
+	doc, err := c.searchQuery(searchQuery{Subject: subject})
+	if !pass(T("doctor.search_page"), err, errors.Is(err, ErrLoginPage)) {
+		return checks
+	}
+
+	table := doc.Find(".dataentrytable")
+	err = nil
+	if table.Length() == 0 {
+		err = c.unexpectedPage(doc, what, T("doctor.no_table"))
+	}
+	if !pass(T("doctor.table_present"), err, false) {
+		return checks
+	}
+
+	cols, ok := findHeader(table)
+	err = nil
+	if !ok {
+		err = c.unexpectedPage(doc, what, T("doctor.no_header"))
+	}
+	if !pass(T("doctor.header_row"), err, false) {
+		return checks
+	}
+
+	var missing []string
+	for _, col := range doctorColumns {
+		if _, ok := cols[col]; !ok {
+			missing = append(missing, col)
+		}
+	}
+	err = nil
+	if len(missing) > 0 {
+		err = c.unexpectedPage(doc, what, T("doctor.missing_columns", strings.Join(missing, ", ")))
+	}
+	if !pass(T("doctor.columns_present"), err, false) {
+		return checks
+	}
+
+	sections := parseSections(doc.Document)
+	err = nil
+	if len(sections) == 0 {
+		err = c.unexpectedPage(doc, what, T("doctor.no_rows"))
+	} else if bad := slices.IndexFunc(sections, func(s Section) bool {
+		return !courseCodePattern.MatchString(s.Course) || s.Title == ""
+	}); bad >= 0 {
+		err = c.unexpectedPage(doc, what, T("doctor.bad_row", sections[bad].CRN, sections[bad].Course, sections[bad].Title))
+	}
+	if !pass(T("doctor.rows_parse"), err, false) {
+		return checks
+	}
+
+	err = nil
+	for _, s := range sections {
+		if _, convErr := strconv.Atoi(s.Capacity); convErr != nil {
+			err = c.unexpectedPage(doc, what, T("doctor.bad_capacity", s.CRN, s.Capacity))
+			break
+		}
+	}
+	if !pass(T("doctor.capacities_parse"), err, false) {
+		return checks
+	}
+
+	open, err := c.searchQuery(searchQuery{Subject: subject, OpenOnly: true})
+	if err == nil {
+		err = c.checkResults(open, what+" (open only)")
+	}
+	pass(T("doctor.open_only"), err, errors.Is(err, ErrLoginPage))
+
+	return checks
+}
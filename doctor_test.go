@@ -0,0 +1,88 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newDoctorServer serves the term dropdown on GET and results on POST.
+func newDoctorServer(t *testing.T, results string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(termDropdownHTML))
+			return
+		}
+		w.Write([]byte(results))
+	}))
+}
+
+// ===================
+// doctorChecks tests
+// ===================
+
+func TestDoctorChecks_AllPass(t *testing.T) {
+	server := newDoctorServer(t, resultsHTML)
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", DebugDir: t.TempDir()}
+	checks := cfg.doctorChecks("CS", time.Now())
+
+	for _, c := range checks {
+		if c.Err != nil {
+			t.Errorf("%s: unexpected error: %v", c.Name, c.Err)
+		}
+	}
+	if len(checks) != 9 {
+		t.Errorf("expected 9 checks, got %d", len(checks))
+	}
+}
+
+func TestDoctorChecks_TermNotOfferedIsConfigProblem(t *testing.T) {
+	server := newDoctorServer(t, resultsHTML)
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "201901", DebugDir: t.TempDir()}
+	checks := cfg.doctorChecks("CS", time.Now())
+
+	last := checks[len(checks)-1]
+	if last.Err == nil || !last.Config {
+		t.Errorf("expected config failure, got %+v", last)
+	}
+}
+
+func TestDoctorChecks_MissingHeaderIsBannerChange(t *testing.T) {
+	server := newDoctorServer(t, `<table class="dataentrytable"><tr><td>12345</td><td>CS-1000</td><td>Title</td></tr></table>`)
+	defer server.Close()
+
+	dir := t.TempDir()
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", DebugDir: dir}
+	checks := cfg.doctorChecks("CS", time.Now())
+
+	last := checks[len(checks)-1]
+	if last.Name != "Results table has a header row" {
+		t.Fatalf("expected to stop at header check, stopped at %q", last.Name)
+	}
+	if last.Err == nil || last.Config {
+		t.Errorf("expected Banner failure, got %+v", last)
+	}
+}
+
+func TestDoctorChecks_NonNumericCapacity(t *testing.T) {
+	server := newDoctorServer(t, `
+		<table class="dataentrytable">
+			<tr><td>CRN</td><td>Course</td><td>Title</td><td>Capacity</td><td>Instructor</td><td>Days</td><td>Begin</td><td>End</td><td>Location</td></tr>
+			<tr><td>12345</td><td>CS-1000</td><td>Title</td><td>lots</td><td>Staff</td><td>M</td><td>9:00AM</td><td>9:50AM</td><td>MCB 100</td></tr>
+		</table>`)
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", DebugDir: t.TempDir()}
+	checks := cfg.doctorChecks("CS", time.Now())
+
+	last := checks[len(checks)-1]
+	if last.Name != "Capacities parse as integers" || last.Err == nil {
+		t.Errorf("expected capacity failure, got %+v", last)
+	}
+}
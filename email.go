@@ -0,0 +1,405 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/resend/resend-go/v2"
+)
+
+// Email services alerts can be sent through
+const (
+	ProviderResend   = "resend"   // Resend, with RESEND_API_KEY (default)
+	ProviderSendGrid = "sendgrid" // SendGrid, with SENDGRID_API_KEY
+	ProviderMailgun  = "mailgun"  // Mailgun, with MAILGUN_API_KEY and a sending domain
+	ProviderSES      = "ses"      // Amazon SES, with credentials from the standard AWS chain
+)
+
+// ResendSandboxFrom is the sender Resend allows without a verified domain
+const ResendSandboxFrom = "onboarding@resend.dev"
+
+// DefaultFromName is the display name alerts are sent under
+const DefaultFromName = "OpenSeat"
+
+// NotifyTimeout bounds sending one email or message through a provider's API
+const NotifyTimeout = 20 * time.Second
+
+// Provider API endpoints, overridden in tests
+var (
+	sendGridURL     = "https://api.sendgrid.com/v3/mail/send"
+	mailgunBaseURLs = map[string]string{
+		"us": "https://api.mailgun.net",
+		"eu": "https://api.eu.mailgun.net",
+	}
+)
+
+// validateEmailProvider checks the email service and the settings it needs.
+func (c Config) validateEmailProvider() error {
+	switch c.EmailProvider {
+	case ProviderResend:
+	case ProviderSendGrid, ProviderSES:
+		if c.EmailFrom == "" && c.EmailDomain == "" {
+			return fmt.Errorf("emailProvider %q needs emailFrom or emailDomain, a sender address or domain verified with %s", c.EmailProvider, c.EmailProvider)
+		}
+	case ProviderMailgun:
+		if c.MailgunDomain == "" {
+			return fmt.Errorf(`emailProvider "mailgun" needs mailgunDomain, e.g. mg.example.com`)
+		}
+		if _, ok := mailgunBaseURLs[c.MailgunRegion]; !ok {
+			return fmt.Errorf(`invalid mailgunRegion %q: must be "us" or "eu"`, c.MailgunRegion)
+		}
+	default:
+		return fmt.Errorf("invalid emailProvider %q: must be %q, %q, %q, or %q",
+			c.EmailProvider, ProviderResend, ProviderSendGrid, ProviderMailgun, ProviderSES)
+	}
+	return c.validateEmailIdentity()
+}
+
+// validateEmailIdentity checks the sender and reply-to addresses, and that a sender
+// address is on the verified domain when both are given.
+func (c Config) validateEmailIdentity() error {
+	if c.EmailDomain != "" && (strings.ContainsAny(c.EmailDomain, "@/ ") || !strings.Contains(c.EmailDomain, ".")) {
+		return fmt.Errorf("invalid emailDomain %q: expected a domain like example.com", c.EmailDomain)
+	}
+	if c.EmailFrom != "" {
+		addr, err := mail.ParseAddress(c.EmailFrom)
+		if err != nil || addr.Name != "" {
+			return fmt.Errorf("invalid emailFrom %q: expected a bare address like alerts@example.com (set the name with emailFromName)", c.EmailFrom)
+		}
+		if c.EmailDomain != "" && !strings.EqualFold(addressDomain(addr.Address), c.EmailDomain) {
+			return fmt.Errorf("emailFrom %q is not on emailDomain %q; providers reject or spam-filter senders on unverified domains", c.EmailFrom, c.EmailDomain)
+		}
+	}
+	if c.EmailReplyTo != "" {
+		if _, err := mail.ParseAddress(c.EmailReplyTo); err != nil {
+			return fmt.Errorf("invalid emailReplyTo %q: %w", c.EmailReplyTo, err)
+		}
+	}
+	return nil
+}
+
+// addressDomain returns the part of an email address after the @.
+func addressDomain(address string) string {
+	_, domain, _ := strings.Cut(address, "@")
+	return domain
+}
+
+// recipients returns every address alerts are emailed to: email, then emails,
+// without duplicates.
+func (c Config) recipients() []string {
+	var to []string
+	for _, addr := range append([]string{c.Email}, c.Emails...) {
+		if addr != "" && !slices.ContainsFunc(to, func(a string) bool { return strings.EqualFold(a, addr) }) {
+			to = append(to, addr)
+		}
+	}
+	return to
+}
+
+// validateRecipients checks every recipient address, and that there is someone to
+// send to when addresses are copied.
+func (c Config) validateRecipients() error {
+	for _, list := range []struct {
+		setting string
+		addrs   []string
+	}{{"email", []string{c.Email}}, {"emails", c.Emails}, {"emailCc", c.EmailCC}} {
+		for _, addr := range list.addrs {
+			if addr == "" && list.setting == "email" {
+				continue // not set
+			}
+			if _, err := mail.ParseAddress(addr); err != nil {
+				return fmt.Errorf("invalid %s address %q: %w", list.setting, addr, err)
+			}
+		}
+	}
+	if len(c.EmailCC) > 0 && len(c.recipients()) == 0 {
+		return fmt.Errorf("emailCc needs email or emails to send to")
+	}
+	return nil
+}
+
+// emailFrom returns the sender address. Without one configured, alerts come from
+// openseat@ the verified domain, or else the provider's default.
+func (c Config) emailFrom() string {
+	switch {
+	case c.EmailFrom != "":
+		return c.EmailFrom
+	case c.EmailDomain != "":
+		return "openseat@" + c.EmailDomain
+	case c.EmailProvider == ProviderMailgun:
+		return "openseat@" + c.MailgunDomain
+	default:
+		return ResendSandboxFrom
+	}
+}
+
+// emailIdentity returns who alerts are sent as.
+func (c Config) emailIdentity() EmailIdentity {
+	return EmailIdentity{From: c.emailFrom(), Name: cmp.Or(c.EmailFromName, DefaultFromName), ReplyTo: c.EmailReplyTo}
+}
+
+// EmailIdentity is who an email comes from and where replies to it go
+type EmailIdentity struct {
+	From    string // sender address
+	Name    string // display name shown with the address (optional)
+	ReplyTo string // address replies go to instead of From (optional)
+}
+
+// from formats the sender for a From header, e.g. "OpenSeat <alerts@example.com>".
+func (id EmailIdentity) from() string {
+	if id.Name == "" {
+		return id.From
+	}
+	return (&mail.Address{Name: id.Name, Address: id.From}).String()
+}
+
+// newEmailSender creates the sender for the configured email service. API keys
+// come from the environment so they stay out of config files.
+func (c Config) newEmailSender() (EmailSender, error) {
+	id := c.emailIdentity()
+	switch c.EmailProvider {
+	case ProviderSendGrid:
+		return &SendGridEmailSender{APIKey: os.Getenv("SENDGRID_API_KEY"), EmailIdentity: id}, nil
+	case ProviderMailgun:
+		return &MailgunEmailSender{
+			APIKey:        os.Getenv("MAILGUN_API_KEY"),
+			Domain:        c.MailgunDomain,
+			BaseURL:       mailgunBaseURLs[c.MailgunRegion],
+			EmailIdentity: id,
+		}, nil
+	case ProviderSES:
+		return newSESEmailSender(c.SESRegion, id)
+	default:
+		return &ResendEmailSender{APIKey: os.Getenv("RESEND_API_KEY"), EmailIdentity: id}, nil
+	}
+}
+
+// ===================
+// Resend
+// ===================
+
+// ResendEmailSender sends email through the Resend API
+type ResendEmailSender struct {
+	APIKey string
+	EmailIdentity
+}
+
+func (r *ResendEmailSender) Send(m EmailMessage) error {
+	if r.APIKey == "" {
+		return fmt.Errorf("RESEND_API_KEY not set")
+	}
+
+	client := resend.NewClient(r.APIKey)
+	params := &resend.SendEmailRequest{
+		From:    EmailIdentity{From: cmp.Or(r.From, ResendSandboxFrom), Name: r.Name}.from(),
+		To:      m.To,
+		Cc:      m.CC,
+		ReplyTo: r.ReplyTo,
+		Subject: m.Subject,
+		Text:    m.Body,
+	}
+
+	_, err := client.Emails.Send(params)
+	return err
+}
+
+// ===================
+// SendGrid
+// ===================
+
+// SendGridEmailSender sends email through the SendGrid v3 API
+type SendGridEmailSender struct {
+	APIKey        string
+	EmailIdentity // From must be a verified sender or on an authenticated domain
+}
+
+func (s *SendGridEmailSender) Send(m EmailMessage) error {
+	if s.APIKey == "" {
+		return fmt.Errorf("SENDGRID_API_KEY not set")
+	}
+
+	type address struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}
+	type content struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	addresses := func(emails []string) []address {
+		var list []address
+		for _, email := range emails {
+			list = append(list, address{Email: email})
+		}
+		return list
+	}
+	recipients := map[string][]address{"to": addresses(m.To)}
+	if len(m.CC) > 0 {
+		recipients["cc"] = addresses(m.CC)
+	}
+	var replyTo *address
+	if s.ReplyTo != "" {
+		replyTo = &address{Email: s.ReplyTo}
+	}
+	payload, err := json.Marshal(struct {
+		Personalizations []map[string][]address `json:"personalizations"`
+		From             address                `json:"from"`
+		ReplyTo          *address               `json:"reply_to,omitempty"`
+		Subject          string                 `json:"subject"`
+		Content          []content              `json:"content"`
+	}{
+		Personalizations: []map[string][]address{recipients},
+		From:             address{Email: s.From, Name: s.Name},
+		ReplyTo:          replyTo,
+		Subject:          m.Subject,
+		Content:          []content{{Type: "text/plain", Value: m.Body}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sendGridURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	return sendAPIRequest("sendgrid", req)
+}
+
+// ===================
+// Mailgun
+// ===================
+
+// MailgunEmailSender sends email through the Mailgun messages API
+type MailgunEmailSender struct {
+	APIKey  string
+	Domain  string // sending domain configured in Mailgun
+	BaseURL string // API host for the domain's region
+	EmailIdentity
+}
+
+func (mg *MailgunEmailSender) Send(m EmailMessage) error {
+	if mg.APIKey == "" {
+		return fmt.Errorf("MAILGUN_API_KEY not set")
+	}
+
+	form := url.Values{
+		"from":    {mg.from()},
+		"to":      m.To,
+		"subject": {m.Subject},
+		"text":    {m.Body},
+	}
+	if len(m.CC) > 0 {
+		form["cc"] = m.CC
+	}
+	if mg.ReplyTo != "" {
+		form.Set("h:Reply-To", mg.ReplyTo)
+	}
+	endpoint := fmt.Sprintf("%s/v3/%s/messages", mg.BaseURL, url.PathEscape(mg.Domain))
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("api", mg.APIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return sendAPIRequest("mailgun", req)
+}
+
+// sendAPIRequest sends a request to an email or messaging API, turning a non-2xx
+// response into an error that includes the start of the response body, where
+// providers explain what was wrong. Errors name only the host, since webhook URLs
+// hold secrets in their path and query.
+func sendAPIRequest(provider string, req *http.Request) error {
+	ctx, cancel := context.WithTimeout(req.Context(), NotifyTimeout)
+	defer cancel()
+
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			return fmt.Errorf("%s: %s %s: %w", provider, uerr.Op, req.URL.Host, uerr.Err)
+		}
+		return fmt.Errorf("%s: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: HTTP %d: %s", provider, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// ===================
+// Amazon SES
+// ===================
+
+// sesSender is the part of the SES client used to send email
+type sesSender interface {
+	SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error)
+}
+
+// SESEmailSender sends email through Amazon SES
+type SESEmailSender struct {
+	client        sesSender
+	EmailIdentity // From must be a verified identity in SES
+}
+
+// newSESEmailSender loads AWS credentials and creates the SES client. An empty
+// region uses the one from the AWS environment or shared config.
+func newSESEmailSender(region string, id EmailIdentity) (*SESEmailSender, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), NotifyTimeout)
+	defer cancel()
+
+	var opts []func(*config.LoadOptions) error
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("ses: failed to load AWS credentials: %w", err)
+	}
+	return &SESEmailSender{client: sesv2.NewFromConfig(cfg), EmailIdentity: id}, nil
+}
+
+func (s *SESEmailSender) Send(m EmailMessage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), NotifyTimeout)
+	defer cancel()
+
+	var replyTo []string
+	if s.ReplyTo != "" {
+		replyTo = []string{s.ReplyTo}
+	}
+	_, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(s.from()),
+		ReplyToAddresses: replyTo,
+		Destination:      &types.Destination{ToAddresses: m.To, CcAddresses: m.CC},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: aws.String(m.Subject), Charset: aws.String("UTF-8")},
+				Body:    &types.Body{Text: &types.Content{Data: aws.String(m.Body), Charset: aws.String("UTF-8")}},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("ses: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+)
+
+// mockSES records sent emails instead of calling AWS
+type mockSES struct {
+	inputs []*sesv2.SendEmailInput
+	err    error
+}
+
+func (m *mockSES) SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
+	m.inputs = append(m.inputs, params)
+	return &sesv2.SendEmailOutput{}, m.err
+}
+
+// ===================
+// Email provider config tests
+// ===================
+
+func TestLoadConfig_EmailProviderDefaults(t *testing.T) {
+	cfg, err := loadConfig(createTempConfig(t, `{"crns": ["12345"]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.EmailProvider != ProviderResend {
+		t.Errorf("EmailProvider = %q, want %q", cfg.EmailProvider, ProviderResend)
+	}
+	if from := cfg.emailFrom(); from != ResendSandboxFrom {
+		t.Errorf("emailFrom() = %q, want %q", from, ResendSandboxFrom)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidEmailProvider(t *testing.T) {
+	for _, provider := range []string{
+		`"emailProvider": "postmark"`,
+		`"emailProvider": "sendgrid"`,
+		`"emailProvider": "ses"`,
+		`"emailProvider": "mailgun"`,
+		`"emailProvider": "mailgun", "mailgunDomain": "mg.example.com", "mailgunRegion": "asia"`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], `+provider+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", provider)
+		}
+	}
+}
+
+func TestEmailIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want EmailIdentity
+	}{
+		{"defaults", Config{EmailProvider: ProviderResend}, EmailIdentity{From: ResendSandboxFrom, Name: DefaultFromName}},
+		{"verified domain", Config{EmailProvider: ProviderResend, EmailDomain: "example.com"}, EmailIdentity{From: "openseat@example.com", Name: DefaultFromName}},
+		{"configured", Config{EmailProvider: ProviderSES, EmailFrom: "alerts@example.com", EmailFromName: "Course Bot", EmailReplyTo: "me@vt.edu"},
+			EmailIdentity{From: "alerts@example.com", Name: "Course Bot", ReplyTo: "me@vt.edu"}},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.emailIdentity(); got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if got := (EmailIdentity{From: "alerts@example.com", Name: "OpenSeat"}).from(); got != `"OpenSeat" <alerts@example.com>` {
+		t.Errorf("from() = %q", got)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidEmailIdentity(t *testing.T) {
+	for _, identity := range []string{
+		`"emailFrom": "not an address"`,
+		`"emailFrom": "OpenSeat <alerts@example.com>"`,
+		`"emailFrom": "alerts@other.com", "emailDomain": "example.com"`,
+		`"emailDomain": "alerts@example.com"`,
+		`"emailReplyTo": "me at vt"`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], `+identity+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", identity)
+		}
+	}
+
+	path := createTempConfig(t, `{"crns": ["12345"], "emailProvider": "sendgrid", "emailDomain": "example.com"}`)
+	if _, err := loadConfig(path); err != nil {
+		t.Errorf("expected a verified domain to satisfy sendgrid, got %v", err)
+	}
+}
+
+func TestRecipients(t *testing.T) {
+	cfg := Config{Email: "me@vt.edu", Emails: []string{"friend@vt.edu", "ME@vt.edu", "roommate@vt.edu"}}
+	got := strings.Join(cfg.recipients(), ",")
+	if got != "me@vt.edu,friend@vt.edu,roommate@vt.edu" {
+		t.Errorf("recipients() = %s", got)
+	}
+
+	if len((Config{Emails: []string{"friend@vt.edu"}}).recipients()) != 1 {
+		t.Error("expected emails to work without email")
+	}
+}
+
+func TestLoadConfig_ErrorInvalidRecipients(t *testing.T) {
+	for _, recipients := range []string{
+		`"email": "me at vt"`,
+		`"emails": ["friend at vt"]`,
+		`"email": "me@vt.edu", "emailCc": ["not an address"]`,
+		`"emailCc": ["advisor@vt.edu"]`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], `+recipients+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", recipients)
+		}
+	}
+}
+
+func TestNewEmailSender(t *testing.T) {
+	cfg := Config{EmailProvider: ProviderMailgun, MailgunDomain: "mg.example.com", MailgunRegion: "eu"}
+	sender, err := cfg.newEmailSender()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mg, ok := sender.(*MailgunEmailSender)
+	if !ok {
+		t.Fatalf("got %T, want *MailgunEmailSender", sender)
+	}
+	if mg.From != "openseat@mg.example.com" || mg.BaseURL != "https://api.eu.mailgun.net" {
+		t.Errorf("unexpected sender: %+v", mg)
+	}
+
+	cfg = Config{EmailProvider: ProviderSendGrid, EmailFrom: "alerts@example.com"}
+	sender, _ = cfg.newEmailSender()
+	if sg, ok := sender.(*SendGridEmailSender); !ok || sg.From != "alerts@example.com" {
+		t.Errorf("unexpected sender: %+v", sender)
+	}
+}
+
+// ===================
+// Resend tests
+// ===================
+
+func TestResendEmailSender_NoAPIKey(t *testing.T) {
+	sender := &ResendEmailSender{APIKey: ""}
+	err := sender.Send(EmailMessage{To: []string{"to@example.com"}, Subject: "Subject", Body: "Body"})
+	if err == nil {
+		t.Error("expected error when API key is empty")
+	}
+}
+
+// ===================
+// SendGrid tests
+// ===================
+
+func TestSendGridEmailSender(t *testing.T) {
+	var got struct {
+		Personalizations []struct {
+			To []struct{ Email string } `json:"to"`
+			CC []struct{ Email string } `json:"cc"`
+		} `json:"personalizations"`
+		From    struct{ Email, Name string } `json:"from"`
+		ReplyTo struct{ Email string }       `json:"reply_to"`
+		Subject string                       `json:"subject"`
+		Content []struct{ Value string }     `json:"content"`
+	}
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	defer func(old string) { sendGridURL = old }(sendGridURL)
+	sendGridURL = server.URL
+
+	sender := &SendGridEmailSender{APIKey: "SG.test", EmailIdentity: EmailIdentity{From: "alerts@example.com", Name: "OpenSeat", ReplyTo: "me@vt.edu"}}
+	if err := sender.Send(EmailMessage{To: []string{"to@example.com"}, CC: []string{"cc@example.com"}, Subject: "Seat open", Body: "CRN 12345"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if auth != "Bearer SG.test" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if len(got.Personalizations) != 1 || len(got.Personalizations[0].To) != 1 || got.Personalizations[0].To[0].Email != "to@example.com" ||
+		len(got.Personalizations[0].CC) != 1 || got.Personalizations[0].CC[0].Email != "cc@example.com" {
+		t.Errorf("unexpected recipients: %+v", got.Personalizations)
+	}
+	if got.From.Name != "OpenSeat" || got.ReplyTo.Email != "me@vt.edu" {
+		t.Errorf("unexpected sender: %+v, reply to %+v", got.From, got.ReplyTo)
+	}
+	if got.From.Email != "alerts@example.com" || got.Subject != "Seat open" || len(got.Content) != 1 || got.Content[0].Value != "CRN 12345" {
+		t.Errorf("unexpected message: %+v", got)
+	}
+}
+
+func TestSendGridEmailSender_ErrorIncludesResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":[{"message":"The from address does not match a verified Sender Identity."}]}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+	defer func(old string) { sendGridURL = old }(sendGridURL)
+	sendGridURL = server.URL
+
+	sender := &SendGridEmailSender{APIKey: "SG.test", EmailIdentity: EmailIdentity{From: "alerts@example.com"}}
+	err := sender.Send(EmailMessage{To: []string{"to@example.com"}, Subject: "Subject", Body: "Body"})
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "verified Sender Identity") {
+		t.Errorf("expected the provider's explanation in the error, got %v", err)
+	}
+}
+
+func TestSendGridEmailSender_NoAPIKey(t *testing.T) {
+	if err := (&SendGridEmailSender{EmailIdentity: EmailIdentity{From: "alerts@example.com"}}).Send(EmailMessage{To: []string{"to@example.com"}, Subject: "Subject", Body: "Body"}); err == nil {
+		t.Error("expected error when API key is empty")
+	}
+}
+
+// ===================
+// Mailgun tests
+// ===================
+
+func TestMailgunEmailSender(t *testing.T) {
+	var path, user, key, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		user, key, _ = r.BasicAuth()
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	sender := &MailgunEmailSender{APIKey: "key-test", Domain: "mg.example.com", BaseURL: server.URL,
+		EmailIdentity: EmailIdentity{From: "openseat@mg.example.com", ReplyTo: "me@vt.edu"}}
+	if err := sender.Send(EmailMessage{To: []string{"to@example.com", "friend@example.com"}, CC: []string{"cc@example.com"}, Subject: "Seat open", Body: "CRN 12345"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if path != "/v3/mg.example.com/messages" {
+		t.Errorf("path = %q", path)
+	}
+	if user != "api" || key != "key-test" {
+		t.Errorf("basic auth = %q:%q", user, key)
+	}
+	for _, want := range []string{"to=to%40example.com", "to=friend%40example.com", "cc=cc%40example.com", "subject=Seat+open", "text=CRN+12345", "from=openseat%40mg.example.com", "h%3AReply-To=me%40vt.edu"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("form %q missing %q", body, want)
+		}
+	}
+}
+
+// ===================
+// SES tests
+// ===================
+
+func TestSESEmailSender(t *testing.T) {
+	client := &mockSES{}
+	sender := &SESEmailSender{client: client, EmailIdentity: EmailIdentity{From: "alerts@example.com", Name: "OpenSeat", ReplyTo: "me@vt.edu"}}
+	if err := sender.Send(EmailMessage{To: []string{"to@example.com"}, Subject: "Seat open", Body: "CRN 12345"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(client.inputs) != 1 {
+		t.Fatalf("expected one email, got %d", len(client.inputs))
+	}
+	in := client.inputs[0]
+	msg := in.Content.Simple
+	if len(in.ReplyToAddresses) != 1 || in.ReplyToAddresses[0] != "me@vt.edu" {
+		t.Errorf("ReplyToAddresses = %v", in.ReplyToAddresses)
+	}
+	if aws.ToString(in.FromEmailAddress) != `"OpenSeat" <alerts@example.com>` || in.Destination.ToAddresses[0] != "to@example.com" ||
+		aws.ToString(msg.Subject.Data) != "Seat open" || aws.ToString(msg.Body.Text.Data) != "CRN 12345" {
+		t.Errorf("unexpected input: %+v", in)
+	}
+}
+
+func TestSESEmailSender_Error(t *testing.T) {
+	sender := &SESEmailSender{client: &mockSES{err: errors.New("MessageRejected")}, EmailIdentity: EmailIdentity{From: "alerts@example.com"}}
+	if err := sender.Send(EmailMessage{To: []string{"to@example.com"}, Subject: "Subject", Body: "Body"}); err == nil || !strings.Contains(err.Error(), "MessageRejected") {
+		t.Errorf("expected the SES error, got %v", err)
+	}
+}
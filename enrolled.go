@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+)
+
+// runEnrolled records that the user got into a section. A running watcher picks it
+// up before its next check and stops watching that CRN.
+func runEnrolled(args []string) error {
+	fs := flag.NewFlagSet("enrolled", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file`")
+	crn := fs.String("crn", "", "`CRN` you enrolled in")
+	fs.Parse(args)
+
+	if err := validateCRN(*crn); err != nil {
+		return configError(err)
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+	if err := cfg.resolveAutoTerms(time.Now()); err != nil {
+		return err
+	}
+
+	e := HistoryEvent{Time: time.Now(), Event: EventEnrolled, CRN: *crn, Term: cfg.forCRN(*crn).Term}
+	if err := appendHistory(cfg.HistoryFile, e); err != nil {
+		return err
+	}
+	cfg.audit(AuditEntry{Action: AuditEnrolled, CRN: *crn, Actor: localActor(), Via: ViaCLI})
+	PrintMarkedEnrolled(*crn, cfg.HistoryFile)
+	if g, others, ok := cfg.alternatives(*crn); ok {
+		PrintAlternativesRetired(g.Name, others)
+	}
+	return nil
+}
+
+// markEnrolled stops watching every course the history marks as enrolled, and
+// every alternative to one in a group of alternatives, returning how many were
+// newly stopped.
+func (c Config) markEnrolled(courses []CourseStatus) (int, error) {
+	enrolled, err := c.enrolledCRNs()
+	if err != nil {
+		return 0, err
+	}
+
+	retired := make(map[string]string) // alternative CRN to the CRN enrolled in instead
+	for crn := range enrolled {
+		if _, others, ok := c.alternatives(crn); ok {
+			for _, other := range others {
+				retired[other] = crn
+			}
+		}
+	}
+
+	stopped := 0
+	for i := range courses {
+		if courses[i].Found {
+			continue
+		}
+		if enrolled[courses[i].CRN] {
+			courses[i].Found = true
+			stopped++
+			PrintEnrolled(courses[i].Name, courses[i].CRN)
+		} else if instead, ok := retired[courses[i].CRN]; ok {
+			courses[i].Found = true
+			stopped++
+			PrintRetired(courses[i].Name, courses[i].CRN, instead)
+		}
+	}
+	return stopped, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ===================
+// markEnrolled tests
+// ===================
+
+func TestMarkEnrolled_StopsWatchingEnrolledCRN(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	appendHistory(path, HistoryEvent{Time: time.Now(), Event: EventEnrolled, CRN: "22222", Term: "202601"})
+
+	cfg := Config{Term: "202601", HistoryFile: path}
+	courses := []CourseStatus{{CRN: "11111"}, {CRN: "22222"}}
+
+	stopped, err := cfg.markEnrolled(courses)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stopped != 1 || courses[0].Found || !courses[1].Found {
+		t.Errorf("stopped %d, courses %+v; want only 22222 stopped", stopped, courses)
+	}
+
+	// already stopped courses aren't counted again
+	if stopped, _ := cfg.markEnrolled(courses); stopped != 0 {
+		t.Errorf("second pass stopped %d, want 0", stopped)
+	}
+}
+
+func TestRunEnrolled_RecordsEvent(t *testing.T) {
+	dir := t.TempDir()
+	path := createTempConfig(t, `{"crns": ["12345"], "term": "202601", "historyFile": "`+filepath.Join(dir, "history.jsonl")+`"}`)
+
+	if err := runEnrolled([]string{"--config", path, "--crn", "12345"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events, _ := readHistory(filepath.Join(dir, "history.jsonl"))
+	if len(events) != 1 || events[0].Event != EventEnrolled || events[0].CRN != "12345" || events[0].Term != "202601" {
+		t.Errorf("got %+v", events)
+	}
+}
+
+func TestRunEnrolled_InvalidCRN(t *testing.T) {
+	if err := runEnrolled([]string{"--crn", "123"}); err == nil {
+		t.Error("expected error for an invalid CRN")
+	}
+}
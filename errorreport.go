@@ -0,0 +1,193 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// ReportAfterErrors is how many times the same unexpected error has to happen
+// before it is reported, so one-off glitches don't generate reports.
+const ReportAfterErrors = 3
+
+// ErrorReporting configures opt-in crash and error reports to Sentry or a compatible service.
+// Nothing is sent unless a DSN is configured.
+type ErrorReporting struct {
+	DSN         string `json:"dsn"`         // Sentry project DSN, e.g. https://<key>@<host>/<project>
+	Environment string `json:"environment"` // environment reported with each event (optional)
+}
+
+// applyDefaults checks the DSN.
+func (r ErrorReporting) applyDefaults() error {
+	if r.DSN == "" {
+		return nil
+	}
+	if _, err := sentry.NewDsn(r.DSN); err != nil {
+		return fmt.Errorf("invalid errorReporting dsn: %w", err)
+	}
+	return nil
+}
+
+// errorReporter sends panics and repeated unexpected errors to Sentry.
+// A nil errorReporter reports nothing.
+type errorReporter struct {
+	hub *sentry.Hub
+
+	mu       sync.Mutex
+	counts   map[string]int // occurrences of each error fingerprint this run
+	reported map[string]bool
+}
+
+// startErrorReporting creates a reporter tagged with the config's search settings,
+// or returns nil when error reporting isn't configured.
+func (c Config) startErrorReporting() (*errorReporter, error) {
+	return c.startErrorReportingWith(nil)
+}
+
+// startErrorReportingWith is startErrorReporting with a custom transport, for tests.
+func (c Config) startErrorReportingWith(transport sentry.Transport) (*errorReporter, error) {
+	if c.ErrorReporting.DSN == "" {
+		return nil, nil
+	}
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         c.ErrorReporting.DSN,
+		Environment: c.ErrorReporting.Environment,
+		Release:     release(),
+		ServerName:  "openseat",
+		Transport:   transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start error reporting: %w", err)
+	}
+
+	scope := sentry.NewScope()
+	scope.SetTag("term", strings.Join(c.watchedTerms(), ","))
+	scope.SetTag("backend", cmp.Or(c.Backend, BackendHTML))
+	scope.SetContext("config", c.reportedConfig())
+	return &errorReporter{hub: sentry.NewHub(client, scope), counts: make(map[string]int), reported: make(map[string]bool)}, nil
+}
+
+// release returns the module version OpenSeat was built from, if known.
+func release() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "(devel)" {
+		return "openseat@" + info.Main.Version
+	}
+	return ""
+}
+
+// reportedConfig is the part of the config sent with reports: the settings that shape
+// searches and parsing. Email addresses, credentials, and file paths are left out.
+func (c Config) reportedConfig() sentry.Context {
+	var overrides []string
+	for field := range c.FormOverrides {
+		overrides = append(overrides, field)
+	}
+	return sentry.Context{
+		"baseUrl":       redactURL(c.BaseURL),
+		"campuses":      c.watchedCampuses(),
+		"sessionCode":   c.SessionCode,
+		"level":         c.Level,
+		"crns":          len(c.CRNs),
+		"courses":       len(c.Courses),
+		"checkInterval": c.CheckInterval,
+		"formOverrides": overrides,
+		"locale":        c.Locale,
+	}
+}
+
+// watchedCampuses returns the campus codes searched.
+func (c Config) watchedCampuses() []string {
+	if len(c.Campuses) > 0 {
+		return c.Campuses
+	}
+	return []string{c.Campus}
+}
+
+// redactURL drops any credentials and query string from a URL.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	u.User, u.RawQuery = nil, ""
+	return u.String()
+}
+
+var (
+	// savedResponse matches the note unexpectedPage adds about the saved response
+	savedResponse = regexp.MustCompile(` \((response saved to|could not save response:) .*\)$`)
+	digitRuns     = regexp.MustCompile(`\d+`)
+)
+
+// fingerprint groups errors that differ only in CRNs, counts, or where the
+// response was saved, e.g. "unexpected timetable response for CRN N: no results table".
+func fingerprint(err error) string {
+	return digitRuns.ReplaceAllString(reportedMessage(err), "N")
+}
+
+// reportedMessage is the error's message without local file paths.
+func reportedMessage(err error) string {
+	return savedResponse.ReplaceAllString(err.Error(), "")
+}
+
+// unexpected reports whether err points at something wrong with OpenSeat or a changed
+// timetable rather than the network, a login page, or a bot check.
+func unexpected(err error) bool {
+	return errors.Is(err, ErrUnexpectedPage) || !isNetworkError(err)
+}
+
+// record counts an error and reports it the ReportAfterErrors-th time its fingerprint
+// comes up. Each fingerprint is reported at most once per run.
+func (r *errorReporter) record(err error) {
+	if r == nil || err == nil || !unexpected(err) {
+		return
+	}
+	fp := fingerprint(err)
+
+	r.mu.Lock()
+	r.counts[fp]++
+	due := r.counts[fp] >= ReportAfterErrors && !r.reported[fp]
+	if due {
+		r.reported[fp] = true
+	}
+	count := r.counts[fp]
+	r.mu.Unlock()
+	if !due {
+		return
+	}
+
+	r.hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetFingerprint([]string{fp})
+		scope.SetTag("parse", fmt.Sprint(errors.Is(err, ErrUnexpectedPage)))
+		scope.SetExtra("occurrences", count)
+		r.hub.CaptureEvent(&sentry.Event{Level: sentry.LevelError, Message: reportedMessage(err)})
+	})
+}
+
+// recoverPanic reports a panic, waits for the report to be sent, and re-panics.
+// It must be deferred directly.
+func (r *errorReporter) recoverPanic() {
+	if r == nil {
+		return
+	}
+	if p := recover(); p != nil {
+		r.hub.Recover(p)
+		r.hub.Flush(5 * time.Second)
+		panic(p)
+	}
+}
+
+// flush waits for queued reports to be sent before exiting.
+func (r *errorReporter) flush() {
+	if r != nil {
+		r.hub.Flush(5 * time.Second)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// Exit codes, so wrapper scripts and service managers can tell outcomes apart.
+// Every subcommand uses the same codes.
+const (
+	ExitOK          = 0   // success; for watch --once, a watched section is open
+	ExitError       = 1   // any failure not covered below
+	ExitConfig      = 2   // invalid config file or command-line flags
+	ExitNetwork     = 3   // the timetable couldn't be reached or answered with an unusable page
+	ExitNoSeat      = 4   // watch --once: no watched section is open
+	ExitInterrupted = 130 // stopped by Ctrl-C or SIGTERM
+)
+
+// ErrNoSeat is returned by watch --once when none of the watched sections are open
+var ErrNoSeat = errors.New("no watched section has an open seat")
+
+// ErrUnexpectedStatus indicates the timetable answered with a non-200 HTTP status
+var ErrUnexpectedStatus = errors.New("unexpected status")
+
+// exitError attaches an exit code to an error
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e exitError) Error() string { return e.err.Error() }
+func (e exitError) Unwrap() error { return e.err }
+
+// configError marks err as caused by the config file or command-line flags.
+func configError(err error) error {
+	return exitError{code: ExitConfig, err: err}
+}
+
+// exitCode picks the exit code for the error a command returned.
+func exitCode(err error) int {
+	var exitErr exitError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	case errors.Is(err, ErrNoSeat):
+		return ExitNoSeat
+	case isNetworkError(err):
+		return ExitNetwork
+	}
+	return ExitError
+}
+
+// isNetworkError reports whether err comes from reaching the timetable: a failed or
+// timed out request, a bad HTTP status, or a page that isn't search results.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	var urlErr *url.Error
+	return errors.Is(err, ErrLoginPage) || errors.Is(err, ErrChallenge) || errors.Is(err, ErrUnexpectedPage) || errors.Is(err, ErrUnexpectedStatus) ||
+		errors.Is(err, ErrResponseTooLarge) || errors.Is(err, ErrParseTimeout) || errors.As(err, &netErr) || errors.As(err, &urlErr)
+}
+
+var (
+	interruptMu    sync.Mutex
+	interruptHooks []func()
+)
+
+// onInterrupt registers cleanup to run before exiting on Ctrl-C or SIGTERM,
+// such as restoring the terminal.
+func onInterrupt(hook func()) {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+	interruptHooks = append(interruptHooks, hook)
+}
+
+// handleInterrupts exits with ExitInterrupted on Ctrl-C or SIGTERM, after running
+// the registered cleanup.
+func handleInterrupts() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		interruptMu.Lock()
+		for _, hook := range interruptHooks {
+			hook()
+		}
+		os.Exit(ExitInterrupted)
+	}()
+}
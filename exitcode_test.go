@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ===================
+// exitCode tests
+// ===================
+
+func TestExitCode(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want int
+	}{
+		"success":         {nil, ExitOK},
+		"other failure":   {errors.New("boom"), ExitError},
+		"config":          {configError(errors.New("bad crn")), ExitConfig},
+		"no seat":         {ErrNoSeat, ExitNoSeat},
+		"login page":      {fmt.Errorf("CRN 12345: %w", ErrLoginPage), ExitNetwork},
+		"unexpected page": {ErrUnexpectedPage, ExitNetwork},
+		"bad status":      {fmt.Errorf("%w: 503", ErrUnexpectedStatus), ExitNetwork},
+		"doctor":          {exitError{code: ExitNetwork, err: errors.New("doctor: results failed")}, ExitNetwork},
+	}
+
+	for name, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("%s: exitCode = %d, want %d", name, got, tt.want)
+		}
+	}
+}
+
+func TestExitCode_UnknownCommand(t *testing.T) {
+	if got := exitCode(runCommand("wacth", nil)); got != ExitConfig {
+		t.Errorf("exitCode = %d for a mistyped command, want %d", got, ExitConfig)
+	}
+}
+
+func TestExitCode_RequestFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := fetchDocument(http.DefaultClient, server.URL, nil)
+	if got := exitCode(err); got != ExitNetwork {
+		t.Errorf("exitCode(%v) = %d, want %d", err, got, ExitNetwork)
+	}
+}
+
+// ===================
+// checkOnce tests
+// ===================
+
+func TestCheckOnce(t *testing.T) {
+	tests := map[string]struct {
+		handler http.HandlerFunc
+		want    int
+	}{
+		"open": {func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td></tr></table>`))
+		}, ExitOK},
+		"full": {func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`<table class="dataentrytable"></table>`))
+		}, ExitNoSeat},
+		"server error": {func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}, ExitNetwork},
+	}
+
+	for name, tt := range tests {
+		server := httptest.NewServer(tt.handler)
+		cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601"}
+		err := cfg.checkOnce(newMemoryStateStore(), &MockEmailSender{}, []CourseStatus{{CRN: "12345", Name: "Intro to Testing"}})
+		server.Close()
+
+		if got := exitCode(err); got != tt.want {
+			t.Errorf("%s: exitCode(%v) = %d, want %d", name, err, got, tt.want)
+		}
+	}
+}
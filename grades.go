@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// gradeColumns maps normalized grade distribution CSV headers to the columns OpenSeat reads.
+// The names follow the university's grade distribution export.
+var gradeColumns = map[string]string{
+	"subject":           "subject",
+	"course no.":        "number",
+	"course no":         "number",
+	"course number":     "number",
+	"instructor":        "instructor",
+	"gpa":               "gpa",
+	"graded enrollment": "enrollment",
+}
+
+// gradeStats accumulates average GPA over past sections
+type gradeStats struct {
+	points   float64 // GPA weighted by graded enrollment
+	weight   float64
+	sections int
+}
+
+func (s *gradeStats) add(gpa, weight float64) {
+	s.points += gpa * weight
+	s.weight += weight
+	s.sections++
+}
+
+func (s *gradeStats) gpa() float64 {
+	return s.points / s.weight
+}
+
+// gradeBook holds historical GPAs by course and by course and instructor
+type gradeBook struct {
+	courses     map[string]*gradeStats // by course, e.g. "CS-3114"
+	instructors map[string]*gradeStats // by course and instructor last name, e.g. "CS-3114 shaffer"
+}
+
+// loadGrades reads a grade distribution CSV with at least Subject, Course No., Instructor,
+// and GPA columns. Sections are weighted by Graded Enrollment when that column is present.
+func loadGrades(path string) (*gradeBook, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gradesFile: %w", err)
+	}
+	defer f.Close()
+
+	book, err := readGrades(f)
+	if err != nil {
+		return nil, fmt.Errorf("gradesFile %s: %w", path, err)
+	}
+	return book, nil
+}
+
+func readGrades(r io.Reader) (*gradeBook, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	cols := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if col, ok := gradeColumns[name]; ok {
+			cols[col] = i
+		}
+	}
+	for _, required := range []string{"subject", "number", "instructor", "gpa"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("no %s column", required)
+		}
+	}
+
+	book := &gradeBook{courses: make(map[string]*gradeStats), instructors: make(map[string]*gradeStats)}
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(col string) string {
+			i, ok := cols[col]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		gpa, err := strconv.ParseFloat(field("gpa"), 64)
+		if err != nil {
+			continue // sections graded pass/fail have no GPA
+		}
+		weight := 1.0
+		if n, err := strconv.ParseFloat(field("enrollment"), 64); err == nil && n > 0 {
+			weight = n
+		}
+
+		course := strings.ToUpper(field("subject")) + "-" + field("number")
+		book.stats(book.courses, course).add(gpa, weight)
+		if last := lastName(field("instructor")); last != "" {
+			book.stats(book.instructors, course+" "+last).add(gpa, weight)
+		}
+	}
+	return book, nil
+}
+
+func (g *gradeBook) stats(m map[string]*gradeStats, key string) *gradeStats {
+	s, ok := m[key]
+	if !ok {
+		s = &gradeStats{}
+		m[key] = s
+	}
+	return s
+}
+
+// lastName reduces an instructor name to a lowercase last name for matching, since
+// the timetable and grade data spell names differently ("CA Shaffer", "Shaffer, Clifford", "Shaffer").
+// Returns "" for placeholder instructors.
+func lastName(name string) string {
+	if before, _, ok := strings.Cut(name, ","); ok {
+		name = before
+	}
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return ""
+	}
+	last := strings.ToLower(fields[len(fields)-1])
+	if last == "staff" || last == "tba" {
+		return ""
+	}
+	return last
+}
+
+// describe summarizes the historical GPA for a course, preferring the instructor's own
+// sections and falling back to every instructor. Returns "" when nothing is known.
+func (g *gradeBook) describe(course, instructor string) string {
+	if g == nil || course == "" {
+		return ""
+	}
+	if last := lastName(instructor); last != "" {
+		if s, ok := g.instructors[course+" "+last]; ok {
+			return T("grades.instructor", s.gpa(), instructor, s.sections)
+		}
+	}
+	if s, ok := g.courses[course]; ok {
+		return T("grades.course", s.gpa(), s.sections)
+	}
+	return ""
+}
+
+// annotate fills in each section's historical GPA.
+func (g *gradeBook) annotate(sections []Section) {
+	for i := range sections {
+		sections[i].GPA = g.describe(sections[i].Course, sections[i].Instructor)
+	}
+}
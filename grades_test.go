@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const gradesCSV = `Academic Year,Term,Subject,Course No.,Course Title,Instructor,GPA,A (%),Graded Enrollment,CRN
+2024-2025,Fall,CS,3114,Data Structures,Shaffer,3.00,30.0,100,83521
+2024-2025,Spring,CS,3114,Data Structures,Shaffer,3.60,45.0,50,13342
+2024-2025,Fall,CS,3114,Data Structures,Ellis,2.40,15.0,50,83522
+2024-2025,Fall,CS,1014,Intro to Computing,Staff,,,0,83001
+`
+
+// ===================
+// gradeBook tests
+// ===================
+
+func TestReadGrades_WeightsByEnrollment(t *testing.T) {
+	book, err := readGrades(strings.NewReader(gradesCSV))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// (3.00*100 + 3.60*50) / 150
+	if got := book.describe("CS-3114", "CA Shaffer"); got != "Avg GPA 3.20 with CA Shaffer (2 sections)" {
+		t.Errorf("got %q", got)
+	}
+	// (3.00*100 + 3.60*50 + 2.40*50) / 200
+	if got := book.describe("CS-3114", "Staff"); got != "Avg GPA 3.00 across instructors (3 sections)" {
+		t.Errorf("got %q", got)
+	}
+	if got := book.describe("CS-1014", "Staff"); got != "" {
+		t.Errorf("expected nothing for a course without GPAs, got %q", got)
+	}
+}
+
+func TestReadGrades_ErrorMissingColumn(t *testing.T) {
+	if _, err := readGrades(strings.NewReader("Subject,Course No.,GPA\nCS,3114,3.0\n")); err == nil {
+		t.Error("expected error without an Instructor column")
+	}
+}
+
+func TestLastName(t *testing.T) {
+	tests := map[string]string{
+		"CA Shaffer":        "shaffer",
+		"Shaffer, Clifford": "shaffer",
+		"Shaffer":           "shaffer",
+		"Staff":             "",
+		"":                  "",
+	}
+	for name, want := range tests {
+		if got := lastName(name); got != want {
+			t.Errorf("lastName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestNotifyOpen_IncludesGPA(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grades.csv")
+	os.WriteFile(path, []byte(gradesCSV), 0o644)
+	cfg, err := loadConfig(createTempConfig(t, `{"crns": ["12345"], "email": "test@example.com", "gradesFile": "`+path+`"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sender := &MockEmailSender{}
+
+	cfg.notifyOpen(newMemoryStateStore(), sender, CourseStatus{CRN: "12345", Name: "Data Structures", Course: "CS-3114", Instructor: "M Ellis"})
+
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "Avg GPA 2.40 with M Ellis") {
+		t.Errorf("expected the instructor's GPA in the alert, got %+v", sender.Sent)
+	}
+}
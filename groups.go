@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Alerts for watch groups
+const (
+	AlertGroupOpen    = "group_open"    // every section in a watch group is open
+	AlertGroupPartial = "group_partial" // some of a watch group's sections are open, the rest still full
+)
+
+// WatchGroup is a set of sections that are only useful together, like a lecture
+// and its lab. Its CRNs are checked in the same round, and the seat alert waits
+// until every one of them is open at once.
+//
+// With Any, the sections are alternatives instead, like three times for the same
+// course: each opening is alerted on its own, and once you enroll in one, the
+// rest are retired.
+type WatchGroup struct {
+	Name string   `json:"name"` // shown in alerts, e.g. "CS 3114 with lab" (defaults to the CRNs joined by " + ", or " or " with any)
+	CRNs []string `json:"crns"` // sections that must all be open at once, or with any, that can stand in for each other
+	Any  bool     `json:"any"`  // any one section will do; enrolling in one stops watching the rest
+}
+
+// validateGroups checks each group, names unnamed ones, and adds their CRNs to
+// the watched CRNs.
+func (c *Config) validateGroups() error {
+	inGroup := make(map[string]string)
+	for i := range c.Groups {
+		g := &c.Groups[i]
+		if g.Name == "" && g.Any {
+			g.Name = strings.Join(g.CRNs, " or ")
+		} else if g.Name == "" {
+			g.Name = strings.Join(g.CRNs, " + ")
+		}
+		if len(g.CRNs) < 2 {
+			return fmt.Errorf("group %q needs at least two CRNs", g.Name)
+		}
+		for _, crn := range g.CRNs {
+			if err := validateCRN(crn); err != nil {
+				return fmt.Errorf("group %q: %w", g.Name, err)
+			}
+			if other, ok := inGroup[crn]; ok {
+				return fmt.Errorf("CRN %s is listed in group %q and group %q; a CRN can only be in one group", crn, other, g.Name)
+			}
+			inGroup[crn] = g.Name
+			if !slices.Contains(c.CRNs, crn) {
+				c.CRNs = append(c.CRNs, crn)
+			}
+		}
+	}
+	return nil
+}
+
+// inGroup reports whether a CRN belongs to a watch group that needs all its
+// sections open, so its own openings aren't announced.
+func (c Config) inGroup(crn string) bool {
+	for _, g := range c.Groups {
+		if !g.Any && slices.Contains(g.CRNs, crn) {
+			return true
+		}
+	}
+	return false
+}
+
+// alternatives returns the group a CRN is one alternative in, and the group's
+// other CRNs, or false if it isn't in a group of alternatives.
+func (c Config) alternatives(crn string) (WatchGroup, []string, bool) {
+	for _, g := range c.Groups {
+		if g.Any && slices.Contains(g.CRNs, crn) {
+			return g, slices.DeleteFunc(slices.Clone(g.CRNs), func(other string) bool { return other == crn }), true
+		}
+	}
+	return WatchGroup{}, nil, false
+}
+
+// groupWatch is a watch group's state while watching
+type groupWatch struct {
+	WatchGroup
+	open    []string // members open as of the last round that checked them all
+	alerted bool     // every member was open and the group was announced
+	stopped bool     // announced, and its sections stopped with afterOpen "stop"
+}
+
+// newGroupWatches starts tracking the config's watch groups that need all their
+// sections open.
+func (c Config) newGroupWatches() []*groupWatch {
+	var groups []*groupWatch
+	for _, g := range c.Groups {
+		if !g.Any {
+			groups = append(groups, &groupWatch{WatchGroup: g})
+		}
+	}
+	return groups
+}
+
+// members returns the indexes of the group's sections that are still watched.
+func (g *groupWatch) members(courses []CourseStatus) []int {
+	var members []int
+	for i, course := range courses {
+		if !course.Found && slices.Contains(g.CRNs, course.CRN) {
+			members = append(members, i)
+		}
+	}
+	return members
+}
+
+// unwatched returns the group's CRNs that aren't being watched: ones that couldn't
+// be found at startup, or that were removed or enrolled in while watching. They
+// count as full, so the group is never announced as open without them.
+func (g *groupWatch) unwatched(courses []CourseStatus) []string {
+	var unwatched []string
+	for _, crn := range g.CRNs {
+		if !slices.ContainsFunc(courses, func(course CourseStatus) bool { return course.CRN == crn && !course.Found }) {
+			unwatched = append(unwatched, crn)
+		}
+	}
+	return unwatched
+}
+
+// checkGroupMembers warns about group sections that couldn't be found at startup,
+// since a group can't be announced while one of its sections isn't watched.
+func (c Config) checkGroupMembers(courses []CourseStatus) {
+	for _, g := range c.newGroupWatches() {
+		for _, crn := range g.unwatched(courses) {
+			PrintGroupMemberMissing(g.Name, crn)
+		}
+	}
+}
+
+// alignGroups makes every section in a group due as soon as one is, so the
+// group's sections are all checked in the same round.
+func alignGroups(groups []*groupWatch, courses []CourseStatus, now time.Time) {
+	for _, g := range groups {
+		members := g.members(courses)
+		if !slices.ContainsFunc(members, func(i int) bool { return !now.Before(courses[i].nextCheck) }) {
+			continue
+		}
+		for _, i := range members {
+			courses[i].nextCheck = time.Time{}
+		}
+	}
+}
+
+// checkGroups announces groups whose sections were all open in this round of
+// checks, and partial matches as info alerts when the set of open sections
+// changes. checked holds the CRNs checked without error this round; a group
+// with a section that wasn't is left for a round that checks them all. With
+// afterOpen "stop", an announced group's sections stop being watched, unless
+// they're in the target schedule. It returns
+// how many groups were announced and how many CRNs stopped.
+func (c Config) checkGroups(groups []*groupWatch, courses []CourseStatus, checked map[string]bool, muted bool, store StateStore, sender EmailSender) (opened, stopped int) {
+	for _, g := range groups {
+		members := g.members(courses)
+		if g.stopped || len(members) == 0 || slices.ContainsFunc(members, func(i int) bool { return !checked[courses[i].CRN] }) {
+			continue
+		}
+		unwatched := g.unwatched(courses)
+
+		var open, full []CourseStatus
+		for _, i := range members {
+			if courses[i].Open {
+				open = append(open, courses[i])
+			} else {
+				full = append(full, courses[i])
+			}
+		}
+		openCRNs := crnsOf(open)
+
+		switch {
+		case len(full) == 0 && len(unwatched) == 0 && !g.alerted:
+			g.alerted = true
+			opened++
+			PrintGroupOpen(g.Name, open, !muted)
+			if !muted {
+				go func() {
+					if err := c.AlertSound.play(); err != nil {
+						PrintSoundFailed(err)
+					}
+				}()
+			}
+			c.notifyGroupOpen(store, sender, g, open)
+			if c.AfterOpen == AfterOpenStop {
+				g.stopped = true
+				for _, i := range members {
+					if !c.inTarget(courses[i].CRN) {
+						courses[i].Found = true
+						stopped++
+					}
+				}
+			}
+
+		case len(full) > 0 || len(unwatched) > 0:
+			g.alerted = false
+			if len(open) > 0 && !slices.Equal(openCRNs, g.open) {
+				parts := describeGroup(open, full, unwatched)
+				PrintGroupPartial(g.Name, parts)
+				if c.notifying() {
+					c.notify(sender, Alert{
+						Event: AlertGroupPartial, Subject: T("email.group_partial.subject", g.Name),
+						Body: T("email.group_partial.body", g.Name, parts), Name: g.Name, Term: c.termOf(open[0]),
+					})
+				}
+			}
+		}
+		g.open = openCRNs
+	}
+	return opened, stopped
+}
+
+// notifyGroupOpen sends the seat alert for a group whose sections are all open,
+// claimed like a single section's so redundant instances send it once.
+func (c Config) notifyGroupOpen(store StateStore, sender EmailSender, g *groupWatch, open []CourseStatus) {
+	if !c.notifying() {
+		return
+	}
+
+	claimed, err := store.Claim(c.openEventID(strings.Join(g.CRNs, "+")), c.dedupeWindow())
+	if err != nil {
+		PrintStateError(err)
+	} else if !claimed {
+		PrintAlreadyNotified(g.Name)
+		return
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\n\n", T("email.group_open.body", g.Name))
+	for _, course := range open {
+		fmt.Fprintf(&body, "%s  %s  (%s)\n", course.CRN, course.Name, c.termOf(course))
+	}
+	c.notify(sender, Alert{Event: AlertGroupOpen, Subject: T("email.group_open.subject", g.Name), Body: body.String(), Name: g.Name, Term: c.termOf(open[0])})
+}
+
+// describeGroup lists which of a group's sections are open and which are still
+// full or not watched, e.g. "Intro to Testing (12345) open, Testing Lab (12346) still full".
+func describeGroup(open, full []CourseStatus, unwatched []string) string {
+	var parts []string
+	for _, course := range open {
+		parts = append(parts, T("group.open", course.Name, course.CRN))
+	}
+	for _, course := range full {
+		parts = append(parts, T("group.full", course.Name, course.CRN))
+	}
+	for _, crn := range unwatched {
+		parts = append(parts, T("group.unwatched", crn))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// crnsOf returns the courses' CRNs, in order.
+func crnsOf(courses []CourseStatus) []string {
+	var crns []string
+	for _, course := range courses {
+		crns = append(crns, course.CRN)
+	}
+	return crns
+}
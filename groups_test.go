@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// Watch group config tests
+// ===================
+
+func TestLoadConfig_GroupsAddCRNs(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "groups": [{"crns": ["12345", "12346"]}]}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(cfg.CRNs, []string{"12345", "12346"}) {
+		t.Errorf("expected the group's CRNs to be watched once each, got %v", cfg.CRNs)
+	}
+	if cfg.Groups[0].Name != "12345 + 12346" {
+		t.Errorf("expected a default name, got %q", cfg.Groups[0].Name)
+	}
+	if len(cfg.duplicateCRNs) != 0 {
+		t.Errorf("a CRN in crns and a group shouldn't count as a duplicate, got %v", cfg.duplicateCRNs)
+	}
+}
+
+func TestLoadConfig_GroupsErrors(t *testing.T) {
+	tests := map[string]string{
+		"one CRN":      `{"groups": [{"crns": ["12345"]}]}`,
+		"invalid CRN":  `{"groups": [{"crns": ["12345", "1234"]}]}`,
+		"in two":       `{"groups": [{"crns": ["12345", "12346"]}, {"crns": ["12345", "12347"]}]}`,
+		"listed twice": `{"groups": [{"crns": ["12345", "12345"]}]}`,
+	}
+	for name, config := range tests {
+		if _, err := loadConfig(createTempConfig(t, config)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// ===================
+// alignGroups tests
+// ===================
+
+func TestAlignGroups(t *testing.T) {
+	now := time.Now()
+	courses := []CourseStatus{
+		{CRN: "12345", nextCheck: now},
+		{CRN: "12346", nextCheck: now.Add(time.Minute)},
+		{CRN: "67890", nextCheck: now.Add(time.Minute)},
+	}
+	groups := []*groupWatch{{WatchGroup: WatchGroup{CRNs: []string{"12345", "12346"}}}}
+
+	alignGroups(groups, courses, now)
+	if now.Before(courses[1].nextCheck) {
+		t.Error("expected the lab to be checked with its lecture")
+	}
+	if !now.Before(courses[2].nextCheck) {
+		t.Error("expected a CRN outside the group to keep its schedule")
+	}
+}
+
+// ===================
+// checkGroups tests
+// ===================
+
+func TestCheckGroups_PartialThenOpen(t *testing.T) {
+	cfg := Config{Email: "test@example.com", AfterOpen: AfterOpenStop}
+	courses := []CourseStatus{{CRN: "12345", Name: "Intro to Testing", Open: true}, {CRN: "12346", Name: "Testing Lab"}}
+	groups := []*groupWatch{{WatchGroup: WatchGroup{Name: "Testing with lab", CRNs: []string{"12345", "12346"}}}}
+	checked := map[string]bool{"12345": true, "12346": true}
+	sender := &MockEmailSender{}
+
+	cfg.checkGroups(groups, courses, checked, true, newMemoryStateStore(), sender)
+	cfg.checkGroups(groups, courses, checked, true, newMemoryStateStore(), sender)
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "Intro to Testing (12345) open, Testing Lab (12346) still full") {
+		t.Fatalf("expected one partial alert, got %+v", sender.Sent)
+	}
+
+	courses[1].Open = true
+	opened, stopped := cfg.checkGroups(groups, courses, checked, true, newMemoryStateStore(), sender)
+	if opened != 1 || stopped != 2 || !courses[0].Found || !courses[1].Found {
+		t.Errorf("expected the group announced and both CRNs stopped, got %d opened, %d stopped", opened, stopped)
+	}
+	if len(sender.Sent) != 2 || sender.Sent[1].Subject != "Seats open in all of Testing with lab" {
+		t.Errorf("expected the group alert, got %+v", sender.Sent)
+	}
+}
+
+func TestCheckGroups_WaitsForEveryCheck(t *testing.T) {
+	cfg := Config{Email: "test@example.com", AfterOpen: AfterOpenKeep}
+	courses := []CourseStatus{{CRN: "12345", Open: true}, {CRN: "12346", Open: true}}
+	groups := []*groupWatch{{WatchGroup: WatchGroup{Name: "Testing with lab", CRNs: []string{"12345", "12346"}}}}
+	sender := &MockEmailSender{}
+
+	// the lab's check failed, so its open state is from an earlier round
+	opened, _ := cfg.checkGroups(groups, courses, map[string]bool{"12345": true}, true, newMemoryStateStore(), sender)
+	if opened != 0 || len(sender.Sent) != 0 {
+		t.Errorf("expected no alert without every section checked in the same round, got %+v", sender.Sent)
+	}
+}
+
+func TestCheckGroups_UnwatchedSectionIsNotOpen(t *testing.T) {
+	cfg := Config{Email: "test@example.com", AfterOpen: AfterOpenKeep}
+	groups := []*groupWatch{{WatchGroup: WatchGroup{Name: "Testing with lab", CRNs: []string{"12345", "12346"}}}}
+	sender := &MockEmailSender{}
+
+	// the lab couldn't be found at startup
+	courses := []CourseStatus{{CRN: "12345", Name: "Intro to Testing", Open: true}}
+	if opened, _ := cfg.checkGroups(groups, courses, map[string]bool{"12345": true}, true, newMemoryStateStore(), sender); opened != 0 {
+		t.Error("expected no group alert with the lab missing")
+	}
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "12346 not watched") {
+		t.Errorf("expected a partial alert naming the missing lab, got %+v", sender.Sent)
+	}
+
+	// the lab was removed while watching
+	courses = []CourseStatus{{CRN: "12345", Name: "Intro to Testing", Open: true}, {CRN: "12346", Name: "Testing Lab", Open: true, Found: true}}
+	if opened, _ := cfg.checkGroups(groups, courses, map[string]bool{"12345": true}, true, newMemoryStateStore(), sender); opened != 0 {
+		t.Error("expected no group alert with the lab removed")
+	}
+}
+
+func TestCheckGroupMembers_WarnsAboutMissingSections(t *testing.T) {
+	cfg := Config{Groups: []WatchGroup{{Name: "Testing with lab", CRNs: []string{"12345", "12346"}}}}
+	var buf bytes.Buffer
+	out = &buf
+	defer func() { out = os.Stdout }()
+
+	cfg.checkGroupMembers([]CourseStatus{{CRN: "12345"}})
+	if !strings.Contains(buf.String(), "12346") || strings.Contains(buf.String(), "12345") {
+		t.Errorf("expected a warning about the missing lab only, got %q", buf.String())
+	}
+}
+
+func TestAlertSeverity_Groups(t *testing.T) {
+	if got := (Alert{Event: AlertGroupOpen}).severity(); got != SeverityCritical {
+		t.Errorf("expected critical for a group opening, got %s", got)
+	}
+	if got := (Alert{Event: AlertGroupPartial}).severity(); got != SeverityInfo {
+		t.Errorf("expected info for a partial match, got %s", got)
+	}
+}
+
+func TestCheckOnce_GroupPartlyOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.FormValue("crn") == "12345" {
+			w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td></tr></table>`))
+			return
+		}
+		w.Write([]byte(`<table class="dataentrytable"></table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", Email: "test@example.com", Groups: []WatchGroup{{Name: "Testing with lab", CRNs: []string{"12345", "12346"}}}}
+	sender := &MockEmailSender{}
+	err := cfg.checkOnce(newMemoryStateStore(), sender, []CourseStatus{{CRN: "12345", Name: "Intro to Testing"}, {CRN: "12346", Name: "Testing Lab"}})
+	if exitCode(err) != ExitNoSeat {
+		t.Errorf("expected no seat while the lab is full, got %v", err)
+	}
+	if len(sender.Sent) != 1 || !strings.HasPrefix(sender.Sent[0].Subject, "Part of") {
+		t.Errorf("expected only the partial alert, got %+v", sender.Sent)
+	}
+}
+
+// ===================
+// Alternative group tests
+// ===================
+
+func TestLoadConfig_AlternativesName(t *testing.T) {
+	path := createTempConfig(t, `{"groups": [{"any": true, "crns": ["12345", "12346", "12347"]}]}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Groups[0].Name != "12345 or 12346 or 12347" || len(cfg.CRNs) != 3 {
+		t.Errorf("got name %q and CRNs %v", cfg.Groups[0].Name, cfg.CRNs)
+	}
+	if cfg.inGroup("12345") || len(cfg.newGroupWatches()) != 0 {
+		t.Error("alternatives should be alerted on their own, not as a group")
+	}
+}
+
+func TestMarkEnrolled_RetiresAlternatives(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	appendHistory(path, HistoryEvent{Time: time.Now(), Event: EventEnrolled, CRN: "12346", Term: "202601"})
+
+	cfg := Config{Term: "202601", HistoryFile: path, Groups: []WatchGroup{{Name: "Testing", Any: true, CRNs: []string{"12345", "12346", "12347"}}}}
+	courses := []CourseStatus{{CRN: "12345"}, {CRN: "12346"}, {CRN: "12347"}, {CRN: "67890"}}
+
+	stopped, err := cfg.markEnrolled(courses)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stopped != 3 || !courses[0].Found || !courses[1].Found || !courses[2].Found || courses[3].Found {
+		t.Errorf("stopped %d, courses %+v; want the group stopped and 67890 still watched", stopped, courses)
+	}
+}
+
+func TestNotifyOpen_MentionsAlternatives(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601", Groups: []WatchGroup{{Name: "Testing", Any: true, CRNs: []string{"12345", "12346"}}}}
+	sender := &MockEmailSender{}
+
+	cfg.notifyOpen(newMemoryStateStore(), sender, CourseStatus{CRN: "12345", Name: "Intro to Testing"})
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "openseat enrolled --crn 12345") || !strings.Contains(sender.Sent[0].Body, "(12346)") {
+		t.Errorf("expected the alert to explain retiring the alternatives, got %+v", sender.Sent)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// browserProfile is the headers a browser sends when loading pages
+type browserProfile struct {
+	userAgent, accept, acceptLanguage string
+}
+
+// browserProfiles are the browsers timetable requests can imitate
+var browserProfiles = map[string]browserProfile{
+	"chrome": {
+		userAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+		accept:         "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		acceptLanguage: "en-US,en;q=0.9",
+	},
+	"firefox": {
+		userAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0",
+		accept:         "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+		acceptLanguage: "en-US,en;q=0.5",
+	},
+	"safari": {
+		userAgent:      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+		accept:         "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+		acceptLanguage: "en-US,en;q=0.9",
+	},
+}
+
+// reservedHeaders are headers OpenSeat sets itself, which headers may not replace
+var reservedHeaders = map[string]string{
+	"Host":           "it is set from baseUrl",
+	"Content-Type":   "it is set by each request",
+	"Content-Length": "it is set by each request",
+}
+
+// validateHeaders checks the browser profile and the extra header names and values.
+func (c Config) validateHeaders() error {
+	if _, ok := browserProfiles[c.Browser]; c.Browser != "" && !ok {
+		return fmt.Errorf("invalid browser %q: must be chrome, firefox, or safari", c.Browser)
+	}
+	for name, value := range c.Headers {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+		if reason, reserved := reservedHeaders[http.CanonicalHeaderKey(name)]; reserved {
+			return fmt.Errorf("headers cannot set %q: %s", name, reason)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("header %q: value can't span lines", name)
+		}
+	}
+	return nil
+}
+
+// withHeaders wraps a timetable transport to send the browser profile and
+// extra headers, or returns it as is when neither is configured.
+func (c Config) withHeaders(base http.RoundTripper) http.RoundTripper {
+	if c.Browser == "" && len(c.Headers) == 0 {
+		return base
+	}
+	return &headerTransport{base: base, browser: browserProfiles[c.Browser], headers: c.Headers}
+}
+
+// headerTransport adds headers to timetable requests. With a browser profile it
+// also sends what a browser would on the way to the search: the page each
+// request was made from as the Referer, and the Origin on form posts.
+type headerTransport struct {
+	base    http.RoundTripper
+	browser browserProfile    // zero without a browser profile
+	headers map[string]string // sent as is, replacing the profile's; a Cookie is added to the session's
+
+	mu   sync.Mutex
+	last string // URL of the last page loaded, the next request's Referer
+}
+
+// RoundTrip sends the request with the configured headers.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context()) // a RoundTripper must not modify the request it's given
+	browsing := t.browser.userAgent != ""
+	if browsing {
+		req.Header.Set("User-Agent", t.browser.userAgent)
+		if req.Header.Get("Accept") == "" {
+			req.Header.Set("Accept", t.browser.accept)
+		}
+		req.Header.Set("Accept-Language", t.browser.acceptLanguage)
+		if req.Method == http.MethodPost {
+			req.Header.Set("Origin", req.URL.Scheme+"://"+req.URL.Host)
+		}
+		t.mu.Lock()
+		if t.last != "" {
+			req.Header.Set("Referer", t.last)
+		}
+		t.mu.Unlock()
+	}
+	for name, value := range t.headers {
+		if cookies := req.Header.Get("Cookie"); http.CanonicalHeaderKey(name) == "Cookie" && cookies != "" {
+			value = cookies + "; " + value
+		}
+		req.Header.Set(name, value)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if browsing && err == nil && resp.StatusCode == http.StatusOK && strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.mu.Lock()
+		t.last = req.URL.String()
+		t.mu.Unlock()
+	}
+	return resp, err
+}
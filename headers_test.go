@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ===================
+// Header config tests
+// ===================
+
+func TestLoadConfig_HeadersErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown browser": `{"crns": ["12345"], "browser": "netscape"}`,
+		"bad name":        `{"crns": ["12345"], "headers": {"X Forwarded": "1"}}`,
+		"reserved":        `{"crns": ["12345"], "headers": {"content-type": "text/plain"}}`,
+		"multiline":       `{"crns": ["12345"], "headers": {"Referer": "a\r\nX-Evil: 1"}}`,
+	}
+	for name, config := range tests {
+		if _, err := loadConfig(createTempConfig(t, config)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestWithHeaders_NoneConfigured(t *testing.T) {
+	if (Config{}).withHeaders(http.DefaultTransport) != http.DefaultTransport {
+		t.Error("expected the transport unwrapped without a browser or headers")
+	}
+}
+
+// ===================
+// headerTransport tests
+// ===================
+
+func TestHeaderTransport_BrowserFlow(t *testing.T) {
+	var got []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Clone())
+		http.SetCookie(w, &http.Cookie{Name: "SESSID", Value: "abc"})
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html></html>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL + "/search", Browser: "firefox", Headers: map[string]string{"Cookie": "consent=yes", "X-Requested-With": "openseat"}}
+	client := cfg.newHTTPClient()
+	resp, err := client.Get(server.URL + "/form")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	resp, err = client.PostForm(server.URL+"/search", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	form, search := got[0], got[1]
+	if !strings.Contains(form.Get("User-Agent"), "Firefox") || form.Get("Accept-Language") == "" || form.Get("Referer") != "" {
+		t.Errorf("expected the form page loaded like a browser with no referer, got %v", form)
+	}
+	if search.Get("Referer") != server.URL+"/form" || search.Get("Origin") != server.URL {
+		t.Errorf("expected the search posted from the form page, got Referer %q and Origin %q", search.Get("Referer"), search.Get("Origin"))
+	}
+	if search.Get("Cookie") != "SESSID=abc; consent=yes" {
+		t.Errorf("expected the configured cookie added to the session's, got %q", search.Get("Cookie"))
+	}
+	if search.Get("X-Requested-With") != "openseat" {
+		t.Errorf("expected extra headers sent, got %v", search)
+	}
+}
+
+func TestHeaderTransport_HeadersReplaceProfile(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	cfg := Config{Browser: "chrome", Headers: map[string]string{"Referer": "https://example.edu/start", "Accept": "*/*"}}
+	resp, err := cfg.newHTTPClient().PostForm(server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if got.Get("Referer") != "https://example.edu/start" || got.Get("Accept") != "*/*" || !strings.Contains(got.Get("User-Agent"), "Chrome") {
+		t.Errorf("expected configured headers to win over the profile, got %v", got)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// heatmapDays lists the heatmap rows, starting the week on Monday like a class schedule
+var heatmapDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// heatmap counts openings by day of week and hour of day, in local time
+type heatmap [7][24]int
+
+// buildHeatmap counts the open events in the history, limited to one CRN unless crn is empty.
+func buildHeatmap(events []HistoryEvent, crn string) heatmap {
+	var h heatmap
+	for _, e := range events {
+		if e.Event != EventOpen || (crn != "" && e.CRN != crn) {
+			continue
+		}
+		t := e.Time.Local()
+		h[t.Weekday()][t.Hour()]++
+	}
+	return h
+}
+
+// total returns how many openings the heatmap counts.
+func (h heatmap) total() int {
+	n := 0
+	for _, hours := range h {
+		for _, count := range hours {
+			n += count
+		}
+	}
+	return n
+}
+
+// peak returns the day and hour with the most openings, earliest in the week on ties.
+func (h heatmap) peak() (time.Weekday, int, int) {
+	day, hour, most := time.Monday, 0, 0
+	for _, d := range heatmapDays {
+		for hr, count := range h[d] {
+			if count > most {
+				day, hour, most = d, hr, count
+			}
+		}
+	}
+	return day, hour, most
+}
+
+// writeCSV writes one row per day and one column per hour.
+func (h heatmap) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"day"}
+	for hour := range 24 {
+		header = append(header, strconv.Itoa(hour))
+	}
+	cw.Write(header)
+
+	for _, d := range heatmapDays {
+		row := []string{d.String()}
+		for _, count := range h[d] {
+			row = append(row, strconv.Itoa(count))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// heatmapTitle describes what the heatmap covers, using the course name from the history when known.
+func heatmapTitle(events []HistoryEvent, crn string) string {
+	if crn == "" {
+		return T("heatmap.title_all")
+	}
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].CRN == crn && events[i].Name != "" {
+			return T("heatmap.title", fmt.Sprintf("%s (CRN: %s)", events[i].Name, crn))
+		}
+	}
+	return T("heatmap.title", "CRN "+crn)
+}
+
+// runHeatmap shows when watched sections have opened, from the history file.
+func runHeatmap(args []string) error {
+	fs := flag.NewFlagSet("heatmap", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file`")
+	crn := fs.String("crn", "", "only count openings of this `CRN`")
+	asCSV := fs.Bool("csv", false, "write the counts as CSV instead of drawing them")
+	fs.Parse(args)
+
+	if *crn != "" {
+		if err := validateCRN(*crn); err != nil {
+			return configError(err)
+		}
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+
+	events, err := readHistory(cfg.HistoryFile)
+	if err != nil {
+		return err
+	}
+	h := buildHeatmap(events, *crn)
+
+	if *asCSV {
+		return h.writeCSV(os.Stdout)
+	}
+	PrintHeatmap(heatmapTitle(events, *crn), cfg.HistoryFile, h)
+	return nil
+}
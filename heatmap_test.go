@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// buildHeatmap tests
+// ===================
+
+func TestBuildHeatmap_CountsOpenings(t *testing.T) {
+	tuesday := time.Date(2026, 8, 18, 8, 15, 0, 0, time.Local)
+	events := []HistoryEvent{
+		{Time: tuesday, Event: EventOpen, CRN: "12345"},
+		{Time: tuesday.Add(30 * time.Minute), Event: EventOpen, CRN: "12345"},
+		{Time: tuesday.Add(40 * time.Minute), Event: EventClosed, CRN: "12345"},
+		{Time: tuesday.Add(26 * time.Hour), Event: EventOpen, CRN: "67890"},
+	}
+
+	h := buildHeatmap(events, "12345")
+	if h[time.Tuesday][8] != 2 || h.total() != 2 {
+		t.Errorf("Tuesday 08:00 = %d, total %d; want 2 and 2", h[time.Tuesday][8], h.total())
+	}
+
+	all := buildHeatmap(events, "")
+	if all[time.Wednesday][10] != 1 || all.total() != 3 {
+		t.Errorf("Wednesday 10:00 = %d, total %d; want 1 and 3", all[time.Wednesday][10], all.total())
+	}
+}
+
+func TestHeatmap_Peak(t *testing.T) {
+	var h heatmap
+	h[time.Sunday][9] = 3
+	h[time.Monday][23] = 3
+	h[time.Friday][12] = 1
+
+	// ties go to the earliest in the week, which starts on Monday
+	day, hour, count := h.peak()
+	if day != time.Monday || hour != 23 || count != 3 {
+		t.Errorf("peak = %s %d:00 (%d), want Monday 23:00 (3)", day, hour, count)
+	}
+}
+
+func TestHeatmap_WriteCSV(t *testing.T) {
+	var h heatmap
+	h[time.Tuesday][8] = 2
+
+	var buf bytes.Buffer
+	if err := h.writeCSV(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("got %d lines, want a header and 7 days", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "day,0,1,2") || !strings.HasPrefix(lines[2], "Tuesday,0,0,0,0,0,0,0,0,2,") {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// DefaultHistoryFile is the history file name used when historyFile isn't configured.
+// It lives next to the config file.
+const DefaultHistoryFile = "history.jsonl"
+
+// History event types
+const (
+	EventWatching = "watching" // watching a section started, recorded once per CRN and term
+	EventOpen     = "open"     // a watched section opened
+	EventClosed   = "closed"   // an open section filled again
+	EventEnrolled = "enrolled" // the user got into the section
+)
+
+// HistoryEvent is one line of the history file
+type HistoryEvent struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+	CRN   string    `json:"crn"`
+	Term  string    `json:"term,omitempty"`
+	Name  string    `json:"name,omitempty"`
+
+	Seats    string `json:"seats,omitempty"`    // seats cell, for observed events
+	Capacity string `json:"capacity,omitempty"` // capacity cell, for observed events
+
+	Previous string `json:"previous,omitempty"` // old value, for change events
+	Current  string `json:"current,omitempty"`  // new value, for change events
+}
+
+// defaultHistoryFile places the history file next to the config file unless one is configured.
+func (c *Config) defaultHistoryFile(configPath string) {
+	if c.HistoryFile == "" {
+		c.HistoryFile = filepath.Join(filepath.Dir(configPath), DefaultHistoryFile)
+	}
+}
+
+// appendHistory adds an event to the end of the history file, creating it if needed.
+// Each event is written as a single JSON line, so concurrent writers don't interleave,
+// under the history lock, so a prune rewriting the file can't drop it.
+func appendHistory(path string, e HistoryEvent) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	unlock, err := lockFile(historyLockPath(path))
+	if err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	defer unlock()
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// historyLockPath returns the lock file held by whatever is writing the history
+// file, kept next to it.
+func historyLockPath(path string) string {
+	return path + ".lock"
+}
+
+// readHistory returns every event in the history file, oldest first.
+// A missing file is an empty history.
+func readHistory(path string) ([]HistoryEvent, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+
+	var events []HistoryEvent
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e HistoryEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("history file %s line %d: %w", path, line, err)
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+// recordHistory appends an event for a course in the config's term to the history file.
+// Failures are reported but never stop monitoring.
+func (c Config) recordHistory(event string, course CourseStatus, now time.Time) {
+	e := HistoryEvent{Time: now, Event: event, CRN: course.CRN, Term: c.termOf(course), Name: course.Name}
+	c.report.event(e)
+	if c.HistoryFile == "" {
+		return
+	}
+	if err := appendHistory(c.HistoryFile, e); err != nil {
+		PrintHistoryError(err)
+	}
+}
+
+// enrolledCRNs returns the CRNs the history marks as enrolled in any of the config's terms.
+func (c Config) enrolledCRNs() (map[string]bool, error) {
+	events, err := readHistory(c.HistoryFile)
+	if err != nil {
+		return nil, err
+	}
+	enrolled := make(map[string]bool)
+	for _, e := range events {
+		if e.Event == EventEnrolled && (e.Term == "" || slices.Contains(c.watchedTerms(), e.Term)) {
+			enrolled[e.CRN] = true
+		}
+	}
+	return enrolled, nil
+}
+
+// recordChange appends a change to a course's listing to the history file.
+func (c Config) recordChange(event string, course CourseStatus, previous, current string, now time.Time) {
+	e := HistoryEvent{Time: now, Event: event, CRN: course.CRN, Term: c.termOf(course), Name: course.Name, Previous: previous, Current: current}
+	c.report.event(e)
+	if c.HistoryFile == "" {
+		return
+	}
+	if err := appendHistory(c.HistoryFile, e); err != nil {
+		PrintHistoryError(err)
+	}
+}
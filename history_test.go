@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ===================
+// history file tests
+// ===================
+
+func TestHistory_AppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Date(2026, 1, 12, 9, 30, 0, 0, time.UTC)
+
+	appendHistory(path, HistoryEvent{Time: now, Event: EventOpen, CRN: "12345", Term: "202601"})
+	appendHistory(path, HistoryEvent{Time: now.Add(time.Hour), Event: EventClosed, CRN: "12345", Term: "202601"})
+
+	events, err := readHistory(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0].Event != EventOpen || events[1].Event != EventClosed {
+		t.Errorf("got %+v", events)
+	}
+	if !events[0].Time.Equal(now) {
+		t.Errorf("time = %v, want %v", events[0].Time, now)
+	}
+}
+
+func TestHistory_MissingFileIsEmpty(t *testing.T) {
+	events, err := readHistory(filepath.Join(t.TempDir(), "none.jsonl"))
+	if err != nil || len(events) != 0 {
+		t.Errorf("got %v, %v; want empty history", events, err)
+	}
+}
+
+func TestHistory_CorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	os.WriteFile(path, []byte("{\"event\":\"open\",\"crn\":\"12345\"}\nnot json\n"), 0o644)
+
+	if _, err := readHistory(path); err == nil {
+		t.Error("expected error for a corrupt line")
+	}
+}
+
+func TestLoadConfig_HistoryFileNextToConfig(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"]}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(filepath.Dir(path), DefaultHistoryFile); cfg.HistoryFile != want {
+		t.Errorf("HistoryFile = %q, want %q", cfg.HistoryFile, want)
+	}
+}
+
+func TestEnrolledCRNs_MatchesTerm(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	appendHistory(path, HistoryEvent{Event: EventEnrolled, CRN: "11111", Term: "202601"})
+	appendHistory(path, HistoryEvent{Event: EventEnrolled, CRN: "22222", Term: "202509"})
+	appendHistory(path, HistoryEvent{Event: EventOpen, CRN: "33333", Term: "202601"})
+
+	cfg := Config{Term: "202601", HistoryFile: path}
+	enrolled, err := cfg.enrolledCRNs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !enrolled["11111"] || enrolled["22222"] || enrolled["33333"] {
+		t.Errorf("got %v, want only 11111", enrolled)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimetableHost is another school's timetable watched alongside the main one,
+// e.g. for a transfer student taking classes at two Banner schools
+type TimetableHost struct {
+	Name                 string   `json:"name"`                 // Shown beside this host's CRNs (defaults to the timetable's host name)
+	BaseURL              string   `json:"baseUrl"`              // Its timetable search URL
+	Backend              string   `json:"backend"`              // Where its section data comes from: "html" (default) or "banner9"
+	Banner9URL           string   `json:"banner9Url"`           // Its Banner 9 StudentRegistrationSsb base URL, for the "banner9" backend
+	Term                 string   `json:"term"`                 // Its term code (defaults to the main term)
+	Campus               string   `json:"campus"`               // Its campus code (defaults to 0)
+	CRNs                 []string `json:"crns"`                 // CRNs to watch on this timetable
+	MaxRequestsPerMinute int      `json:"maxRequestsPerMinute"` // Most searches a minute sent to this timetable (optional)
+}
+
+// validateHosts checks each extra timetable, filling in default names and
+// backends, and adds their CRNs to the watched CRNs. A CRN can only be on one
+// host; listing a host's CRN in crns as well just watches it there.
+func (c *Config) validateHosts() error {
+	onHost := make(map[string]string)
+	for i := range c.Hosts {
+		h := &c.Hosts[i]
+		u, err := url.Parse(h.BaseURL)
+		if h.BaseURL == "" || err != nil || u.Host == "" {
+			return fmt.Errorf("hosts: invalid baseUrl %q, e.g. https://<host>/pls/prod/bwckschd.p_get_crse_unsec", h.BaseURL)
+		}
+		h.Name = cmp.Or(h.Name, u.Host)
+		if slices.ContainsFunc(c.Hosts[:i], func(o TimetableHost) bool { return o.Name == h.Name }) {
+			return fmt.Errorf("hosts: two hosts are named %q", h.Name)
+		}
+		h.Backend = cmp.Or(h.Backend, BackendHTML)
+		if err := (Config{Backend: h.Backend, Banner9URL: h.Banner9URL}).validateBackend(); err != nil {
+			return fmt.Errorf("host %q: %w", h.Name, err)
+		}
+		if h.Term == AutoTerm {
+			return fmt.Errorf("host %q: term must be a term code; \"auto\" only works for the main timetable", h.Name)
+		}
+		if h.MaxRequestsPerMinute < 0 {
+			return fmt.Errorf("host %q: maxRequestsPerMinute must be positive, got %d", h.Name, h.MaxRequestsPerMinute)
+		}
+		if len(h.CRNs) == 0 {
+			return fmt.Errorf("host %q has no CRNs", h.Name)
+		}
+		for _, crn := range h.CRNs {
+			if err := validateCRN(crn); err != nil {
+				return fmt.Errorf("host %q: %w", h.Name, err)
+			}
+			if other, ok := onHost[crn]; ok {
+				return fmt.Errorf("CRN %s is listed on host %q and host %q; a CRN can only be on one host", crn, other, h.Name)
+			}
+			onHost[crn] = h.Name
+			if !slices.Contains(c.CRNs, crn) {
+				c.CRNs = append(c.CRNs, crn)
+			}
+		}
+	}
+	return nil
+}
+
+// hostOf returns the extra timetable a CRN is watched on, or false for the main one.
+func (c Config) hostOf(crn string) (TimetableHost, bool) {
+	for _, h := range c.Hosts {
+		if slices.Contains(h.CRNs, crn) {
+			return h, true
+		}
+	}
+	return TimetableHost{}, false
+}
+
+// hostName returns the name of the extra timetable a CRN is on, or "" for the main one.
+func (c Config) hostName(crn string) string {
+	h, _ := c.hostOf(crn)
+	return h.Name
+}
+
+// onHost returns the config for searching an extra timetable. Settings that only
+// make sense for the main timetable, like form overrides and the summer session,
+// are dropped.
+func (c Config) onHost(h TimetableHost) Config {
+	c.BaseURL, c.Backend, c.Banner9URL = h.BaseURL, h.Backend, h.Banner9URL
+	if h.Term != "" {
+		c.Term, c.Terms = h.Term, nil
+	}
+	c.Campus, c.Campuses = cmp.Or(h.Campus, "0"), nil
+	c.SessionCode, c.FormOverrides = "", nil
+	c.MaxRequestsPerMinute = h.MaxRequestsPerMinute
+	c.Hosts = nil
+	if c.session != nil {
+		c.session = c.session.hosts[h.Name]
+	}
+	return c
+}
+
+// watchLabel returns what to show beside a watched CRN: its host when it's on
+// another timetable, and its term when several terms are watched.
+func (c Config) watchLabel(crn, term string) string {
+	labels := slices.DeleteFunc([]string{c.hostName(crn), c.termLabel(term)}, func(s string) bool { return s == "" })
+	return strings.Join(labels, " · ")
+}
+
+// pacer spaces out the searches sent to one timetable to honor maxRequestsPerMinute
+type pacer struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time // when the next search may be sent
+}
+
+// newPacer returns a pacer for perMinute searches a minute, or nil when unlimited.
+func newPacer(perMinute int) *pacer {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &pacer{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// wait blocks until the next search may be sent. Searches waiting together are
+// sent one interval apart, in the order they arrived.
+func (p *pacer) wait() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	now := time.Now()
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	p.next = at.Add(p.interval)
+	p.mu.Unlock()
+	time.Sleep(at.Sub(now))
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// Host config tests
+// ===================
+
+func TestLoadConfig_HostsAddCRNs(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "hosts": [{"baseUrl": "https://ssb.radford.edu/pls/prod/search", "term": "202610", "crns": ["54321", "12345"]}]}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(cfg.CRNs, []string{"12345", "54321"}) {
+		t.Errorf("expected the host's CRNs watched once each, got %v", cfg.CRNs)
+	}
+	h := cfg.Hosts[0]
+	if h.Name != "ssb.radford.edu" || h.Backend != BackendHTML {
+		t.Errorf("expected a default name and backend, got %+v", h)
+	}
+	if cfg.session.hosts[h.Name] == nil {
+		t.Error("expected the host to get its own session")
+	}
+	if !slices.Contains(cfg.watchedTerms(), "202610") {
+		t.Errorf("expected the host's term among the watched terms, got %v", cfg.watchedTerms())
+	}
+}
+
+func TestLoadConfig_HostsErrors(t *testing.T) {
+	tests := map[string]string{
+		"no baseUrl":   `{"hosts": [{"crns": ["12345"]}]}`,
+		"no CRNs":      `{"crns": ["12345"], "hosts": [{"baseUrl": "https://ssb.radford.edu/search"}]}`,
+		"same name":    `{"hosts": [{"name": "R", "baseUrl": "https://a.edu/s", "crns": ["12345"]}, {"name": "R", "baseUrl": "https://b.edu/s", "crns": ["12346"]}]}`,
+		"on two hosts": `{"hosts": [{"baseUrl": "https://a.edu/s", "crns": ["12345"]}, {"baseUrl": "https://b.edu/s", "crns": ["12345"]}]}`,
+		"auto term":    `{"hosts": [{"baseUrl": "https://a.edu/s", "term": "auto", "crns": ["12345"]}]}`,
+		"banner9":      `{"hosts": [{"baseUrl": "https://a.edu/s", "backend": "banner9", "crns": ["12345"]}]}`,
+		"rate limit":   `{"hosts": [{"baseUrl": "https://a.edu/s", "maxRequestsPerMinute": -1, "crns": ["12345"]}]}`,
+	}
+	for name, config := range tests {
+		if _, err := loadConfig(createTempConfig(t, config)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// ===================
+// forCRN tests
+// ===================
+
+func TestForCRN_Host(t *testing.T) {
+	cfg := Config{BaseURL: "https://vt.edu/search", Term: "202601", Campus: "0", FormOverrides: map[string]string{"SCHDTYPE": "L"},
+		Hosts: []TimetableHost{{Name: "Radford", BaseURL: "https://radford.edu/search", Backend: BackendHTML, Term: "202610", Campus: "R", CRNs: []string{"54321"}}}}
+
+	host := cfg.forCRN("54321")
+	if host.BaseURL != "https://radford.edu/search" || host.Term != "202610" || host.Campus != "R" || host.FormOverrides != nil {
+		t.Errorf("expected the host's timetable settings, got %+v", host)
+	}
+	if main := cfg.forCRN("12345"); main.BaseURL != "https://vt.edu/search" || main.Term != "202601" {
+		t.Errorf("expected other CRNs on the main timetable, got %+v", main)
+	}
+	if got := cfg.watchLabel("54321", "202610"); got != "Radford · 202610" {
+		t.Errorf("expected the host and its term beside its CRN, got %q", got)
+	}
+}
+
+func TestCheckSectionOpen_SearchesHost(t *testing.T) {
+	main := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the host's CRN not to be searched on the main timetable")
+	}))
+	defer main.Close()
+	var term string
+	host := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		term = r.FormValue("TERMYEAR")
+		w.Write([]byte(`<table class="dataentrytable"><tr><td>54321</td></tr></table>`))
+	}))
+	defer host.Close()
+
+	cfg := Config{BaseURL: main.URL, Term: "202601", Campus: "0",
+		Hosts: []TimetableHost{{Name: "Radford", BaseURL: host.URL, Backend: BackendHTML, Term: "202610", CRNs: []string{"54321"}}}}
+	cfg.session = cfg.newSession()
+	open, err := cfg.checkSectionOpen("54321")
+	if err != nil || !open {
+		t.Fatalf("expected the section open on the host, got %v, %v", open, err)
+	}
+	if term != "202610" {
+		t.Errorf("expected the host's term, got %q", term)
+	}
+}
+
+func TestNotifyOpen_NamesHost(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601"}
+	sender := &MockEmailSender{}
+	cfg.notifyOpen(newMemoryStateStore(), sender, CourseStatus{CRN: "54321", Name: "Intro to Testing", Host: "Radford"})
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "On the Radford timetable.") {
+		t.Errorf("expected the alert to name the host, got %+v", sender.Sent)
+	}
+}
+
+// ===================
+// pacer tests
+// ===================
+
+func TestPacer_SpacesSearches(t *testing.T) {
+	if newPacer(0) != nil {
+		t.Error("expected no pacer without a limit")
+	}
+	p := newPacer(1200) // one every 50ms
+	start := time.Now()
+	for range 3 {
+		p.wait()
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected three searches to take two intervals, took %v", elapsed)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is used when no locale is configured, and for any message a locale doesn't translate
+const DefaultLocale = "en"
+
+// localeFiles holds one message catalog per locale, named <locale>.json.
+// Adding a translation only takes a new file in locales/.
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	catalogsOnce sync.Once
+	catalogs     map[string]map[string]string
+	catalogsErr  error
+
+	// locale is the locale user-facing strings are shown in
+	locale = DefaultLocale
+)
+
+// loadCatalogs parses every embedded message catalog, keyed by locale.
+func loadCatalogs() (map[string]map[string]string, error) {
+	catalogsOnce.Do(func() {
+		entries, err := localeFiles.ReadDir("locales")
+		if err != nil {
+			catalogsErr = err
+			return
+		}
+		catalogs = make(map[string]map[string]string)
+		for _, entry := range entries {
+			data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+			if err != nil {
+				catalogsErr = err
+				return
+			}
+			var messages map[string]string
+			if err := json.Unmarshal(data, &messages); err != nil {
+				catalogsErr = fmt.Errorf("invalid locale file %s: %w", entry.Name(), err)
+				return
+			}
+			catalogs[strings.TrimSuffix(entry.Name(), ".json")] = messages
+		}
+	})
+	return catalogs, catalogsErr
+}
+
+// locales returns the available locales, sorted.
+func locales() []string {
+	loaded, _ := loadCatalogs()
+	var names []string
+	for name := range loaded {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// setLocale picks the locale for user-facing strings. A configured locale must exist;
+// with none configured, the language from $LANG is used if there is a catalog for it.
+func setLocale(configured string) error {
+	loaded, err := loadCatalogs()
+	if err != nil {
+		return err
+	}
+
+	if configured != "" {
+		if _, ok := loaded[configured]; !ok {
+			return fmt.Errorf("unknown locale %q: available locales are %s", configured, strings.Join(locales(), ", "))
+		}
+		locale = configured
+		return nil
+	}
+
+	locale = DefaultLocale
+	if lang := envLanguage(os.Getenv("LANG")); lang != "" {
+		if _, ok := loaded[lang]; ok {
+			locale = lang
+		}
+	}
+	return nil
+}
+
+// envLanguage extracts the language from a POSIX locale such as "es_ES.UTF-8".
+func envLanguage(lang string) string {
+	lang, _, _ = strings.Cut(lang, ".")
+	lang, _, _ = strings.Cut(lang, "_")
+	return strings.ToLower(lang)
+}
+
+// T returns the message for key in the current locale, formatted with args.
+// Messages missing from the locale fall back to English, then to the key itself.
+func T(key string, args ...any) string {
+	loaded, _ := loadCatalogs()
+	format, ok := loaded[locale][key]
+	if !ok {
+		format, ok = loaded[DefaultLocale][key]
+	}
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
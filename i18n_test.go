@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ===================
+// i18n tests
+// ===================
+
+func TestT_FormatsEnglishMessage(t *testing.T) {
+	if got := T("watch.email_sent", "me@vt.edu"); got != "Notification sent to me@vt.edu" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestT_MissingKeyFallsBackToKey(t *testing.T) {
+	if got := T("no.such.message"); got != "no.such.message" {
+		t.Errorf("got %q, want the key itself", got)
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	defer setLocale(DefaultLocale)
+
+	if err := setLocale("xx"); err == nil {
+		t.Error("expected error for an unknown configured locale")
+	}
+
+	// an unknown language in $LANG quietly falls back to English
+	t.Setenv("LANG", "xx_XX.UTF-8")
+	if err := setLocale(""); err != nil || locale != DefaultLocale {
+		t.Errorf("got locale %q, err %v; want %q", locale, err, DefaultLocale)
+	}
+}
+
+func TestEnvLanguage(t *testing.T) {
+	tests := map[string]string{
+		"es_ES.UTF-8": "es",
+		"fr":          "fr",
+		"C.UTF-8":     "c",
+		"":            "",
+	}
+	for lang, want := range tests {
+		if got := envLanguage(lang); got != want {
+			t.Errorf("envLanguage(%q) = %q, want %q", lang, got, want)
+		}
+	}
+}
+
+// TestCatalogs_CoverEveryKey makes sure every message the code asks for exists in English.
+func TestCatalogs_CoverEveryKey(t *testing.T) {
+	loaded, err := loadCatalogs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob("*.go")
+	files = slices.DeleteFunc(files, func(f string) bool { return strings.HasSuffix(f, "_test.go") })
+	keyPattern := regexp.MustCompile(`\bT\("([a-z_.]+)"`)
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range keyPattern.FindAllSubmatch(src, -1) {
+			if _, ok := loaded[DefaultLocale][string(m[1])]; !ok {
+				t.Errorf("%s: message %q missing from locales/%s.json", file, m[1], DefaultLocale)
+			}
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultICSFile is where `export ics` writes the calendar when --out isn't given
+const DefaultICSFile = "schedule.ics"
+
+// icsTimeLayout formats floating local times, which calendar apps show in the
+// viewer's own time zone, as the timetable's times are meant
+const icsTimeLayout = "20060102T150405"
+
+// icsDays maps the timetable's day letters to iCalendar weekdays
+var icsDays = map[rune]struct {
+	code    string
+	weekday time.Weekday
+}{
+	'M': {"MO", time.Monday},
+	'T': {"TU", time.Tuesday},
+	'W': {"WE", time.Wednesday},
+	'R': {"TH", time.Thursday},
+	'F': {"FR", time.Friday},
+	'S': {"SA", time.Saturday},
+	'U': {"SU", time.Sunday},
+}
+
+// icsEvent is one VEVENT in an exported calendar
+type icsEvent struct {
+	uid         string
+	summary     string
+	location    string
+	description string
+	start, end  time.Time
+	rrule       string // repeat rule for weekly meetings, "" for one-off events like exams
+}
+
+// examSlot is when a final exam is held
+type examSlot struct {
+	start, end time.Time
+}
+
+// parseExamSlot reads an exam slot like "2026-05-08 7:45AM-9:45AM" in local time.
+func parseExamSlot(s string) (examSlot, bool) {
+	date, times, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		return examSlot{}, false
+	}
+	begin, end, ok := strings.Cut(strings.ReplaceAll(times, " ", ""), "-")
+	if !ok {
+		return examSlot{}, false
+	}
+	start, err1 := time.ParseInLocation(calendarDateLayout+" 3:04PM", date+" "+strings.ToUpper(begin), time.Local)
+	finish, err2 := time.ParseInLocation(calendarDateLayout+" 3:04PM", date+" "+strings.ToUpper(end), time.Local)
+	if err1 != nil || err2 != nil || !finish.After(start) {
+		return examSlot{}, false
+	}
+	return examSlot{start: start, end: finish}, true
+}
+
+// classDates returns the first and last days of classes, which bound the
+// exported meetings.
+func (cal Calendar) classDates() (begin, end time.Time, err error) {
+	begin, ok1 := cal.day(cal.ClassesBegin)
+	end, ok2 := cal.day(cal.ClassesEnd)
+	if !ok1 || !ok2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("exporting a calendar needs calendar.classesBegin and calendar.classesEnd")
+	}
+	if end.Before(begin) {
+		return time.Time{}, time.Time{}, fmt.Errorf("calendar.classesEnd %s is before classesBegin %s", cal.ClassesEnd, cal.ClassesBegin)
+	}
+	return begin, end, nil
+}
+
+// meetingEvent returns a section's weekly meetings from the first day of classes
+// through the last. ok is false for sections without set times, like online ones.
+func meetingEvent(s Section, term string, begin, end time.Time) (icsEvent, bool) {
+	m, ok := parseMeeting(s.Meeting())
+	if !ok {
+		return icsEvent{}, false
+	}
+
+	var days []string
+	var weekdays []time.Weekday
+	for _, d := range m.days {
+		days = append(days, icsDays[d].code)
+		weekdays = append(weekdays, icsDays[d].weekday)
+	}
+	// the series starts on the first day of classes the section meets
+	first := begin
+	for !slices.Contains(weekdays, first.Weekday()) {
+		first = first.AddDate(0, 0, 1)
+	}
+	if first.After(end) {
+		return icsEvent{}, false
+	}
+	until := end.Add(24*time.Hour - time.Second)
+
+	return icsEvent{
+		uid:         fmt.Sprintf("%s-%s-meeting@openseat", s.CRN, term),
+		summary:     strings.TrimSpace(s.Course + " " + s.Title),
+		location:    s.Location,
+		description: sectionDescription(s),
+		start:       first.Add(time.Duration(m.start) * time.Minute),
+		end:         first.Add(time.Duration(m.end) * time.Minute),
+		rrule:       fmt.Sprintf("FREQ=WEEKLY;BYDAY=%s;UNTIL=%s", strings.Join(days, ","), until.Format(icsTimeLayout)),
+	}, true
+}
+
+// examEvent returns a section's final exam. ok is false when the section has no
+// exam code, or calendar.exams doesn't give the code's slot.
+func (cal Calendar) examEvent(s Section, term string) (icsEvent, bool) {
+	if s.Exam == "" {
+		return icsEvent{}, false
+	}
+	slot, ok := parseExamSlot(cal.Exams[s.Exam])
+	if !ok {
+		return icsEvent{}, false
+	}
+	return icsEvent{
+		uid:         fmt.Sprintf("%s-%s-exam@openseat", s.CRN, term),
+		summary:     T("ics.exam", strings.TrimSpace(s.Course+" "+s.Title)),
+		description: sectionDescription(s),
+		start:       slot.start,
+		end:         slot.end,
+	}, true
+}
+
+// sectionDescription notes the CRN and instructor on a calendar event.
+func sectionDescription(s Section) string {
+	if s.Instructor == "" {
+		return T("ics.crn", s.CRN)
+	}
+	return T("ics.crn_instructor", s.CRN, s.Instructor)
+}
+
+// writeICS writes events as an iCalendar file.
+func writeICS(w io.Writer, events []icsEvent, now time.Time) error {
+	var b strings.Builder
+	line := func(name, value string) {
+		b.WriteString(foldICS(name + ":" + value))
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//OpenSeat//Schedule Export//EN")
+	line("CALSCALE", "GREGORIAN")
+	for _, e := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.uid)
+		line("DTSTAMP", now.UTC().Format(icsTimeLayout)+"Z")
+		line("DTSTART", e.start.Format(icsTimeLayout))
+		line("DTEND", e.end.Format(icsTimeLayout))
+		if e.rrule != "" {
+			line("RRULE", e.rrule)
+		}
+		line("SUMMARY", escapeICS(e.summary))
+		if e.location != "" {
+			line("LOCATION", escapeICS(e.location))
+		}
+		if e.description != "" {
+			line("DESCRIPTION", escapeICS(e.description))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeICS escapes a text value's backslashes, commas, semicolons, and newlines.
+func escapeICS(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`).Replace(s)
+}
+
+// foldICS ends a content line, folding it onto continuation lines every 75 octets
+// as iCalendar requires, without splitting a UTF-8 character.
+func foldICS(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	b.WriteString(line + "\r\n")
+	return b.String()
+}
+
+// exportCRNs returns the CRNs to put on the calendar: every watched CRN and every
+// one marked enrolled, or only the enrolled ones.
+func (c Config) exportCRNs(enrolledOnly bool) ([]string, error) {
+	enrolled, err := c.enrolledCRNs()
+	if err != nil {
+		return nil, err
+	}
+	var crns []string
+	if !enrolledOnly {
+		crns = append(crns, c.CRNs...)
+	}
+	for _, crn := range slices.Sorted(maps.Keys(enrolled)) {
+		if !slices.Contains(crns, crn) {
+			crns = append(crns, crn)
+		}
+	}
+	return crns, nil
+}
+
+// calendarEvents looks up each CRN and returns its meetings and final exam.
+// A CRN that can't be looked up, or has no times, is reported and left out.
+func (c Config) calendarEvents(crns []string) ([]icsEvent, error) {
+	begin, end, err := c.Calendar.classDates()
+	if err != nil {
+		return nil, err
+	}
+	var events []icsEvent
+	for _, crn := range crns {
+		section, err := c.getSection(crn)
+		if err != nil {
+			if errors.Is(err, ErrLoginPage) || errors.Is(err, ErrChallenge) {
+				return nil, err
+			}
+			PrintScheduleNotFound(crn, err)
+			continue
+		}
+		term := c.forCRN(crn).Term
+		if e, ok := meetingEvent(section, term, begin, end); ok {
+			events = append(events, e)
+		} else {
+			PrintExportNoMeeting(crn)
+		}
+		if e, ok := c.Calendar.examEvent(section, term); ok {
+			events = append(events, e)
+		} else if section.Exam != "" {
+			PrintExportNoExam(crn, section.Exam)
+		}
+	}
+	return events, nil
+}
+
+// runExport writes the watched sections' schedule in another format. Only ics
+// is supported.
+func runExport(args []string) error {
+	if len(args) == 0 || args[0] != "ics" {
+		return configError(fmt.Errorf("usage: openseat export ics [--out file]"))
+	}
+	fs := flag.NewFlagSet("export ics", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file`")
+	outPath := fs.String("out", DefaultICSFile, "`file` to write the calendar to, or - for stdout")
+	enrolledOnly := fs.Bool("enrolled", false, "only export sections marked as enrolled")
+	fs.Parse(args[1:])
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+	if _, _, err := cfg.Calendar.classDates(); err != nil {
+		return configError(err)
+	}
+	if err := cfg.resolveAutoTerms(time.Now()); err != nil {
+		return err
+	}
+	if err := cfg.resolveCampusNames(); err != nil {
+		return err
+	}
+
+	crns, err := cfg.exportCRNs(*enrolledOnly)
+	if err != nil {
+		return err
+	}
+	events, err := cfg.calendarEvents(crns)
+	if err != nil {
+		return err
+	}
+
+	if *outPath == "-" {
+		return writeICS(os.Stdout, events, time.Now())
+	}
+	f, err := os.Create(*outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create calendar: %w", err)
+	}
+	if err := writeICS(f, events, time.Now()); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	PrintExported(*outPath, len(events), len(crns))
+	return nil
+}
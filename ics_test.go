@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// calendar event tests
+// ===================
+
+func TestMeetingEvent_WeeklyThroughClasses(t *testing.T) {
+	begin := time.Date(2026, 1, 13, 0, 0, 0, 0, time.Local) // a Tuesday
+	end := time.Date(2026, 5, 6, 0, 0, 0, 0, time.Local)
+	s := Section{CRN: "13466", Course: "CS-3114", Title: "Data Structures", Days: "M W F", Begin: "10:10AM", End: "11:00AM", Location: "MCB 100"}
+
+	e, ok := meetingEvent(s, "202601", begin, end)
+	if !ok {
+		t.Fatal("expected a meeting event")
+	}
+	if want := time.Date(2026, 1, 14, 10, 10, 0, 0, time.Local); !e.start.Equal(want) {
+		t.Errorf("expected the first Wednesday of classes, got %v", e.start)
+	}
+	if e.rrule != "FREQ=WEEKLY;BYDAY=MO,WE,FR;UNTIL=20260506T235959" {
+		t.Errorf("got rrule %q", e.rrule)
+	}
+	if _, ok := meetingEvent(Section{CRN: "13467", Days: "ONLINE"}, "202601", begin, end); ok {
+		t.Error("expected no meetings for an online section")
+	}
+}
+
+func TestExamEvent(t *testing.T) {
+	cal := Calendar{Exams: map[string]string{"08M": "2026-05-08 7:45AM-9:45AM"}}
+	e, ok := cal.examEvent(Section{CRN: "13466", Course: "CS-3114", Exam: "08M"}, "202601")
+	if !ok || !e.start.Equal(time.Date(2026, 5, 8, 7, 45, 0, 0, time.Local)) || e.end.Sub(e.start) != 2*time.Hour {
+		t.Errorf("expected the exam slot, got %+v, %v", e, ok)
+	}
+	if _, ok := cal.examEvent(Section{CRN: "13466", Exam: "15T"}, "202601"); ok {
+		t.Error("expected no exam for a code without a slot")
+	}
+}
+
+func TestCalendar_ValidateExams(t *testing.T) {
+	if err := (Calendar{Exams: map[string]string{"08M": "May 8 morning"}}).validate(); err == nil {
+		t.Error("expected an error for an unreadable exam slot")
+	}
+	if err := (Calendar{ClassesBegin: "Jan 12"}).validate(); err == nil {
+		t.Error("expected an error for an unreadable first day of classes")
+	}
+}
+
+// ===================
+// iCalendar file tests
+// ===================
+
+func TestWriteICS(t *testing.T) {
+	start := time.Date(2026, 1, 14, 10, 10, 0, 0, time.Local)
+	events := []icsEvent{{uid: "13466-202601-meeting@openseat", summary: "CS-3114 Data Structures, Algorithms; and More",
+		description: strings.Repeat("long ", 30), start: start, end: start.Add(50 * time.Minute), rrule: "FREQ=WEEKLY;BYDAY=MO"}}
+
+	var buf bytes.Buffer
+	if err := writeICS(&buf, events, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ics := buf.String()
+	for _, want := range []string{"BEGIN:VCALENDAR\r\n", "DTSTART:20260114T101000\r\n", "DTSTAMP:20260101T000000Z\r\n",
+		`SUMMARY:CS-3114 Data Structures\, Algorithms\; and More`, "END:VCALENDAR\r\n"} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected %q in\n%s", want, ics)
+		}
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("expected lines folded at 75 octets, got %q", line)
+		}
+	}
+}
+
+func TestFoldICS_KeepsCharactersWhole(t *testing.T) {
+	folded := foldICS("DESCRIPTION:" + strings.Repeat("·", 40))
+	for _, line := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n ") {
+		if !strings.HasPrefix(line, "DESCRIPTION") && !strings.HasPrefix(line, "·") {
+			t.Errorf("expected folds between characters, got %q", line)
+		}
+	}
+}
+
+// ===================
+// export tests
+// ===================
+
+func TestExportCRNs_AddsEnrolled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	appendHistory(path, HistoryEvent{Time: time.Now(), Event: EventEnrolled, CRN: "13470", Term: "202601"})
+	cfg := Config{CRNs: []string{"13466"}, Term: "202601", HistoryFile: path}
+
+	if crns, _ := cfg.exportCRNs(false); !slices.Equal(crns, []string{"13466", "13470"}) {
+		t.Errorf("expected the watched and enrolled CRNs, got %v", crns)
+	}
+	if crns, _ := cfg.exportCRNs(true); !slices.Equal(crns, []string{"13470"}) {
+		t.Errorf("expected only the enrolled CRN, got %v", crns)
+	}
+}
+
+func TestCalendarEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(resultsHTML))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", Calendar: Calendar{ClassesBegin: "2026-01-12", ClassesEnd: "2026-05-06",
+		Exams: map[string]string{"08M": "2026-05-08 7:45AM-9:45AM"}}}
+	events, err := cfg.calendarEvents([]string{"13466"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0].location != "MCB 100" || !strings.Contains(events[1].summary, "CS-3114") {
+		t.Errorf("expected the section's meetings and exam, got %+v", events)
+	}
+
+	cfg.Calendar.ClassesEnd = ""
+	if _, err := cfg.calendarEvents([]string{"13466"}); err == nil {
+		t.Error("expected an error without the last day of classes")
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// crnKeys are the JSON keys schedule planner exports use for a section's CRN,
+// compared without case. courseReferenceNumber is Banner 9's name for it.
+var crnKeys = []string{"crn", "coursereferencenumber"}
+
+// runImport adds the CRNs in a schedule planner export to the config's crns, or
+// with --schedule, to the schedule open sections are checked for clashes with.
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file` to add the CRNs to (created if missing)")
+	dryRun := fs.Bool("dry-run", false, "list the CRNs found without changing the config")
+	schedule := fs.Bool("schedule", false, "add the CRNs to schedule, the sections you're enrolled in, instead of crns")
+	fs.Parse(args)
+
+	// flags may also follow the export, as in `openseat import schedule.csv --dry-run`
+	path := fs.Arg(0)
+	if fs.NArg() > 0 {
+		fs.Parse(fs.Args()[1:])
+	}
+	if path == "" || fs.NArg() > 0 {
+		return configError(fmt.Errorf("usage: openseat import [--config file] [--dry-run] [--schedule] <export file, or - for stdin>"))
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read export: %w", err)
+	}
+	found, err := parseScheduleExport(data)
+	if err != nil {
+		return configError(err)
+	}
+	if len(found) == 0 {
+		return configError(fmt.Errorf("no CRNs found in %s", path))
+	}
+
+	config, err := os.ReadFile(*configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		config, err = []byte("{}\n"), nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var current struct {
+		CRNs     []string `json:"crns"`
+		Schedule []string `json:"schedule"`
+	}
+	if err := json.Unmarshal(config, &current); err != nil {
+		return configError(fmt.Errorf("failed to parse config file: %w", err))
+	}
+	key, list := "crns", current.CRNs
+	if *schedule {
+		key, list = "schedule", current.Schedule
+	}
+
+	var added []string
+	for _, crn := range found {
+		if !slices.Contains(list, crn) {
+			added = append(added, crn)
+		}
+	}
+	PrintImported(found, added, *schedule)
+	if *dryRun || len(added) == 0 {
+		return nil
+	}
+
+	updated, err := setConfigList(config, key, append(list, added...))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*configPath, updated, 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	PrintImportSaved(len(added), *configPath)
+	return nil
+}
+
+// parseScheduleExport finds the CRNs in a schedule planner export, in the order
+// they appear, without duplicates. JSON exports are searched for crn or
+// courseReferenceNumber keys at any depth; anything else is read as CSV.
+func parseScheduleExport(data []byte) ([]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	var crns []string
+	var err error
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		crns, err = jsonCRNs(data)
+	} else {
+		crns, err = csvCRNs(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	crns, _ = dedupeCRNs(crns)
+	return crns, nil
+}
+
+// jsonCRNs walks a JSON document's tokens, collecting every valid CRN stored
+// under one of crnKeys, whether as a string or a number.
+func jsonCRNs(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	// each open object or array; objects track whether a key comes next
+	type frame struct{ object, wantKey bool }
+	var stack []frame
+	var key string
+	valueDone := func() {
+		if n := len(stack); n > 0 && stack[n-1].object {
+			stack[n-1].wantKey = true
+		}
+	}
+
+	var crns []string
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return crns, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JSON export: %w", err)
+		}
+
+		if n := len(stack); n > 0 && stack[n-1].wantKey {
+			if tok == json.Delim('}') {
+				stack = stack[:n-1]
+				valueDone()
+				continue
+			}
+			key, _ = tok.(string)
+			stack[n-1].wantKey = false
+			continue
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			if v == '{' || v == '[' {
+				stack = append(stack, frame{object: v == '{', wantKey: v == '{'})
+				continue
+			}
+			stack = stack[:len(stack)-1]
+		case string, json.Number:
+			inObject := len(stack) > 0 && stack[len(stack)-1].object
+			crn := strings.TrimSpace(fmt.Sprint(v))
+			if inObject && slices.Contains(crnKeys, strings.ToLower(key)) && validateCRN(crn) == nil {
+				crns = append(crns, crn)
+			}
+		}
+		valueDone()
+	}
+}
+
+// csvCRNs reads CRNs from a CSV export. When the header row has a CRN column,
+// only that column is read and every value in it must be a CRN; otherwise
+// every cell holding exactly a CRN is taken.
+func csvCRNs(data []byte) ([]string, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV export: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	column := slices.IndexFunc(records[0], func(cell string) bool {
+		return strings.EqualFold(strings.TrimSpace(cell), "crn")
+	})
+
+	var crns []string
+	if column < 0 {
+		for _, record := range records {
+			for _, cell := range record {
+				if cell = strings.TrimSpace(cell); validateCRN(cell) == nil {
+					crns = append(crns, cell)
+				}
+			}
+		}
+		return crns, nil
+	}
+
+	for i, record := range records[1:] {
+		if column >= len(record) || strings.TrimSpace(record[column]) == "" {
+			continue
+		}
+		crn := strings.TrimSpace(record[column])
+		if err := validateCRN(crn); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		crns = append(crns, crn)
+	}
+	return crns, nil
+}
+
+// setConfigList replaces a top-level array of CRNs, like crns, in a config file's
+// JSON, adding it if missing. The rest of the file is left exactly as written.
+func setConfigList(config []byte, key string, crns []string) ([]byte, error) {
+	list, err := json.Marshal(crns)
+	if err != nil {
+		return nil, err
+	}
+	list = bytes.ReplaceAll(list, []byte(`","`), []byte(`", "`))
+
+	dec := json.NewDecoder(bytes.NewReader(config))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("config file must be a JSON object")
+	}
+	open := int(dec.InputOffset())
+	empty := true
+	for dec.More() {
+		empty = false
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+		afterKey := int(dec.InputOffset())
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+		if tok != key {
+			continue
+		}
+		end := int(dec.InputOffset())
+		start := afterKey + bytes.IndexFunc(config[afterKey:end], func(r rune) bool {
+			return r != ':' && r != ' ' && r != '\t' && r != '\r' && r != '\n'
+		})
+		return slices.Concat(config[:start], list, config[end:]), nil
+	}
+
+	entry := fmt.Sprintf("\n  %q: %s", key, list)
+	if !empty {
+		entry += ","
+	} else {
+		entry += "\n"
+	}
+	return slices.Concat(config[:open], []byte(entry), config[open:]), nil
+}
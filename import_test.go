@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// ===================
+// parseScheduleExport tests
+// ===================
+
+func TestParseScheduleExport_JSON(t *testing.T) {
+	export := `{
+		"name": "Spring plan",
+		"sections": [
+			{"courseReferenceNumber": "12345", "subject": "CS", "linked": [{"crn": ""}]},
+			{"CRN": 13579, "title": "Data Structures"},
+			{"crn": "12345"}
+		],
+		"backup": {"crn": "24680"}
+	}`
+
+	got, err := parseScheduleExport([]byte(export))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, " ") != "12345 13579 24680" {
+		t.Errorf("got %v, want [12345 13579 24680]", got)
+	}
+}
+
+func TestParseScheduleExport_CSVWithHeader(t *testing.T) {
+	export := "\ufeffCourse,CRN,Title,Seats\nCS-3114,12345,Data Structures,40\nSTAT-4705,67890,\"Probability, Stats\",\n,,break row,\n"
+
+	got, err := parseScheduleExport([]byte(export))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, " ") != "12345 67890" {
+		t.Errorf("got %v, want only the CRN column", got)
+	}
+}
+
+func TestParseScheduleExport_CSVBadCRN(t *testing.T) {
+	_, err := parseScheduleExport([]byte("crn\n12345\n1234\n"))
+	if err == nil || !strings.Contains(err.Error(), "row 3") {
+		t.Errorf("got %v, want an error naming row 3", err)
+	}
+}
+
+func TestParseScheduleExport_CSVWithoutHeader(t *testing.T) {
+	got, err := parseScheduleExport([]byte("CS-3114 12345,12345\nmy backup,67890\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, " ") != "12345 67890" {
+		t.Errorf("got %v, want [12345 67890]", got)
+	}
+}
+
+// ===================
+// setConfigList tests
+// ===================
+
+func TestSetConfigList_ReplacesInPlace(t *testing.T) {
+	config := "{\n  \"email\": \"me@example.com\",\n  \"crns\": [\"12345\"],\n  \"term\": \"202601\"\n}\n"
+
+	got, err := setConfigList([]byte(config), "crns", []string{"12345", "67890"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "{\n  \"email\": \"me@example.com\",\n  \"crns\": [\"12345\", \"67890\"],\n  \"term\": \"202601\"\n}\n"
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSetConfigList_AddsMissing(t *testing.T) {
+	for _, config := range []string{"{}\n", "{\n  \"email\": \"me@example.com\"\n}\n"} {
+		got, err := setConfigList([]byte(config), "crns", []string{"12345"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var parsed map[string]any
+		if err := json.Unmarshal(got, &parsed); err != nil {
+			t.Fatalf("result is not valid JSON: %v\n%s", err, got)
+		}
+		if crns, _ := parsed["crns"].([]any); len(crns) != 1 || crns[0] != "12345" {
+			t.Errorf("crns = %v in:\n%s", parsed["crns"], got)
+		}
+	}
+}
+
+func TestSetConfigList_OtherKey(t *testing.T) {
+	config := "{\n  \"crns\": [\"12345\"]\n}\n"
+
+	got, err := setConfigList([]byte(config), "schedule", []string{"67890"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "{\n  \"schedule\": [\"67890\"],\n  \"crns\": [\"12345\"]\n}\n"
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// runInfo prints everything the timetable lists for one CRN.
+func runInfo(args []string) error {
+	fs := flag.NewFlagSet("info", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file` (optional, for term and campus)")
+	asJSON := fs.Bool("json", false, "print the section as JSON instead of a card")
+	fs.Parse(args)
+
+	// flags may also follow the CRN, as in `openseat info 12345 --json`
+	crn := fs.Arg(0)
+	if fs.NArg() > 0 {
+		fs.Parse(fs.Args()[1:])
+	}
+	if err := validateCRN(crn); err != nil {
+		return configError(fmt.Errorf("usage: openseat info <crn>: %w", err))
+	}
+	if fs.NArg() > 0 {
+		return configError(fmt.Errorf("info takes one CRN, got extra arguments %q", fs.Args()))
+	}
+
+	cfg, err := loadSearchConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+	if err := cfg.resolveAutoTerms(time.Now()); err != nil {
+		return err
+	}
+	if err := cfg.resolveCampusNames(); err != nil {
+		return err
+	}
+
+	section, err := cfg.sectionInfo(crn)
+	if err != nil {
+		return err
+	}
+	sections := []Section{section}
+	cfg.grades.annotate(sections)
+	cfg.RateMyProfessors.annotate(sections)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sections[0])
+	}
+	PrintSectionInfo(sections[0])
+	return nil
+}
+
+// sectionInfo searches every term/campus variant for the CRN's full listing,
+// comments included. The first variant (in config order) that lists it wins.
+func (c Config) sectionInfo(crn string) (Section, error) {
+	sections, err := c.forCRN(crn).findSections(searchQuery{CRN: crn, Comments: true}, "CRN "+crn)
+	for _, s := range sections {
+		if s.CRN == crn {
+			return s, nil
+		}
+	}
+	if err != nil {
+		return Section{}, err
+	}
+	return Section{}, fmt.Errorf("course not found for CRN: %s", crn)
+}
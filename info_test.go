@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// ===================
+// sectionInfo tests
+// ===================
+
+func TestSectionInfo_AsksForComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.FormValue("crn") != "13466" || r.FormValue("disp_comments_in") != "Y" {
+			t.Errorf("unexpected search: %v", r.Form)
+		}
+		w.Write([]byte(resultsHTML))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601"}
+	s, err := cfg.sectionInfo("13466")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Title != "Data Structures and Algorithms" || s.Comments != "Restricted to majors" || s.Term != "202601" {
+		t.Errorf("got %+v", s)
+	}
+}
+
+func TestSectionInfo_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(resultsHTML))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601"}
+	if _, err := cfg.sectionInfo("99999"); err == nil {
+		t.Error("expected error for a CRN the timetable doesn't list")
+	}
+}
+
+// ===================
+// PrintSectionInfo tests
+// ===================
+
+func TestPrintSectionInfo_SkipsEmptyFields(t *testing.T) {
+	var buf bytes.Buffer
+	out = &buf
+	defer func() { out = os.Stdout }()
+
+	PrintSectionInfo(Section{CRN: "13466", Course: "CS-3114", Title: "Data Structures and Algorithms", Seats: "3", Capacity: "120", Exam: "08M"})
+
+	got := buf.String()
+	if !strings.Contains(got, "3 of 120") || !strings.Contains(got, "08M") {
+		t.Errorf("card missing seats or exam:\n%s", got)
+	}
+	if strings.Contains(got, T("info.waitlist")) || strings.Contains(got, T("info.instructor")) {
+		t.Errorf("card should leave out fields the timetable didn't list:\n%s", got)
+	}
+}
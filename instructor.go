@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// EventInstructorChanged records a change to a watched section's listed instructor
+const EventInstructorChanged = "instructor_changed"
+
+// checkInstructor announces a change to the section's listed instructor, including
+// "Staff" being replaced by a name, and remembers the new instructor.
+func (c Config) checkInstructor(course *CourseStatus, section Section, sender EmailSender, now time.Time) {
+	previous, current := course.Instructor, section.Instructor
+	if current == "" || strings.EqualFold(previous, current) {
+		return
+	}
+	course.Instructor = current
+	if previous == "" {
+		return // the first listing seen, nothing to compare against
+	}
+
+	PrintInstructorChanged(course.Name, course.CRN, previous, current)
+	c.recordChange(EventInstructorChanged, *course, previous, current, now)
+	if !c.notifying() {
+		return
+	}
+
+	body := T("email.instructor.body", course.Name, course.CRN, previous, current)
+	if gpa := c.grades.describe(course.Course, current); gpa != "" {
+		body += "\n" + gpa
+	}
+	if rating := c.RateMyProfessors.describe(current); rating != "" {
+		body += "\n" + rating
+	}
+	c.notify(sender, c.courseAlert(EventInstructorChanged, *course, T("email.instructor.subject", course.CRN), body))
+}
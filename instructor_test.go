@@ -0,0 +1,50 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// checkInstructor tests
+// ===================
+
+func TestCheckInstructor_StaffToNamed(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601", HistoryFile: filepath.Join(t.TempDir(), "history.jsonl")}
+	sender := &MockEmailSender{}
+	course := CourseStatus{CRN: "12345", Name: "Data Structures", Instructor: "Staff"}
+
+	cfg.checkInstructor(&course, Section{CRN: "12345", Instructor: "CA Shaffer"}, sender, time.Now())
+
+	if course.Instructor != "CA Shaffer" {
+		t.Errorf("Instructor = %q, want the new instructor", course.Instructor)
+	}
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "from Staff to CA Shaffer") {
+		t.Errorf("expected one change email, got %+v", sender.Sent)
+	}
+	events, _ := readHistory(cfg.HistoryFile)
+	if len(events) != 1 || events[0].Event != EventInstructorChanged || events[0].Previous != "Staff" || events[0].Current != "CA Shaffer" {
+		t.Errorf("got history %+v", events)
+	}
+}
+
+func TestCheckInstructor_NoChange(t *testing.T) {
+	cfg := Config{Email: "test@example.com"}
+	sender := &MockEmailSender{}
+	tests := map[string]struct{ previous, current string }{
+		"same":             {"CA Shaffer", "CA Shaffer"},
+		"case only":        {"CA Shaffer", "CA SHAFFER"},
+		"cell went blank":  {"CA Shaffer", ""},
+		"first seen value": {"", "CA Shaffer"},
+	}
+
+	for name, tt := range tests {
+		course := CourseStatus{CRN: "12345", Instructor: tt.previous}
+		cfg.checkInstructor(&course, Section{Instructor: tt.current}, sender, time.Now())
+		if len(sender.Sent) != 0 {
+			t.Errorf("%s: expected no email, got %+v", name, sender.Sent)
+		}
+	}
+}
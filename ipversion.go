@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// IP versions timetable requests can be limited to
+const (
+	IPv4 = "4"
+	IPv6 = "6"
+)
+
+// validateIPVersion checks that ipVersion, if set, is 4 or 6.
+func (c Config) validateIPVersion() error {
+	switch c.IPVersion {
+	case "", IPv4, IPv6:
+		return nil
+	}
+	return fmt.Errorf("invalid ipVersion %q: must be \"4\" or \"6\"", c.IPVersion)
+}
+
+// baseTransport returns the transport timetable requests go out on, which only
+// dials the configured IP version and uses the configured DNS settings. Without
+// either, the system picks as usual.
+func (c Config) baseTransport() http.RoundTripper {
+	if c.IPVersion == "" && !c.DNS.enabled() {
+		return http.DefaultTransport
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = c.newDialer().dial
+	return t
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ===================
+// ipVersion tests
+// ===================
+
+func TestLoadConfig_ErrorInvalidIPVersion(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "ipVersion": "ipv4"}`)
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error for an unknown ipVersion")
+	}
+}
+
+func TestBaseTransport_Default(t *testing.T) {
+	if (Config{}).baseTransport() != http.DefaultTransport {
+		t.Error("expected the default transport without an ipVersion")
+	}
+}
+
+func TestBaseTransport_ForcesIPVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close() // listens on 127.0.0.1
+
+	client := &http.Client{Transport: Config{IPVersion: IPv4}.baseTransport()}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected IPv4 to reach an IPv4 server: %v", err)
+	}
+	resp.Body.Close()
+
+	client = &http.Client{Transport: Config{IPVersion: IPv6}.baseTransport()}
+	if resp, err := client.Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Error("expected IPv6 not to dial an IPv4 address")
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// keyAction is what the watch loop should do after a keypress
+type keyAction int
+
+const (
+	keyNone     keyAction = iota
+	keyCheckNow           // check every CRN right away
+	keyAdd                // start watching the CRN that was typed
+	keyRemove             // stop watching the highlighted CRN
+	keyQuit               // stop monitoring and exit cleanly
+	keyBrowse             // search the subject typed in the course browser
+	keyList               // list what the course browser now shows
+)
+
+// controls is the state of the interactive keyboard controls while watching
+type controls struct {
+	paused    bool      // checks are on hold
+	resumeAt  time.Time // when a pause started by a bot check ends on its own
+	muted     bool      // alert sounds and the terminal bell are off
+	highlight int       // index of the highlighted course
+	adding    bool      // a CRN to add is being typed
+	input     string    // digits typed so far
+	browsing  *browser  // the course browser, while it's open
+}
+
+// handleKey applies a keypress and returns what the watch loop should do about it.
+// For keyAdd, the typed CRN is returned too.
+func (ctl *controls) handleKey(k byte, courses []CourseStatus) (keyAction, string) {
+	if ctl.browsing != nil {
+		return ctl.handleBrowseKey(k)
+	}
+	if ctl.adding {
+		switch {
+		case k >= '0' && k <= '9' && len(ctl.input) < 5:
+			ctl.input += string(k)
+		case (k == 127 || k == '\b') && ctl.input != "":
+			ctl.input = ctl.input[:len(ctl.input)-1]
+		case k == '\r' || k == '\n':
+			ctl.adding = false
+			return keyAdd, ctl.input
+		case k == 27: // Esc
+			ctl.adding = false
+		}
+		return keyNone, ""
+	}
+
+	switch k {
+	case 'p':
+		if ctl.paused {
+			ctl.resume()
+		} else {
+			ctl.paused = true
+		}
+	case 'c':
+		return keyCheckNow, ""
+	case 'a':
+		ctl.adding, ctl.input = true, ""
+	case 'b':
+		ctl.browsing = &browser{}
+	case 'n':
+		ctl.next(courses)
+	case 'x':
+		return keyRemove, ""
+	case 's':
+		ctl.muted = !ctl.muted
+	case 'q':
+		return keyQuit, ""
+	}
+	return keyNone, ""
+}
+
+// pauseUntil holds checks until the given time, or until the user resumes them.
+func (ctl *controls) pauseUntil(t time.Time) {
+	ctl.paused, ctl.resumeAt = true, t
+}
+
+// resume lets checks continue.
+func (ctl *controls) resume() {
+	ctl.paused, ctl.resumeAt = false, time.Time{}
+}
+
+// holding reports whether checks are on hold, ending a timed pause that has run out.
+func (ctl *controls) holding(now time.Time) bool {
+	if ctl.paused && !ctl.resumeAt.IsZero() && !now.Before(ctl.resumeAt) {
+		ctl.resume()
+	}
+	return ctl.paused
+}
+
+// next moves the highlight to the next course still being watched.
+func (ctl *controls) next(courses []CourseStatus) {
+	for range courses {
+		ctl.highlight = (ctl.highlight + 1) % len(courses)
+		if !courses[ctl.highlight].Found {
+			return
+		}
+	}
+}
+
+// highlighted returns the index of the highlighted course, moving the highlight
+// off courses that are no longer watched. ok is false when nothing is watched.
+func (ctl *controls) highlighted(courses []CourseStatus) (int, bool) {
+	if len(courses) == 0 {
+		return 0, false
+	}
+	ctl.highlight %= len(courses)
+	if courses[ctl.highlight].Found {
+		ctl.next(courses)
+	}
+	return ctl.highlight, !courses[ctl.highlight].Found
+}
+
+// status describes the controls for the status line, e.g. "paused · ▸ 12345".
+func (ctl *controls) status(courses []CourseStatus) string {
+	if ctl.adding {
+		return T("keys.adding", ctl.input)
+	}
+	if ctl.browsing != nil {
+		return ctl.browsing.status()
+	}
+	var parts []string
+	switch {
+	case ctl.paused && !ctl.resumeAt.IsZero():
+		parts = append(parts, T("keys.paused_until", ctl.resumeAt.Format("15:04")))
+	case ctl.paused:
+		parts = append(parts, T("keys.paused"))
+	}
+	if ctl.muted {
+		parts = append(parts, T("keys.muted"))
+	}
+	if i, ok := ctl.highlighted(courses); ok {
+		parts = append(parts, "▸ "+courses[i].CRN)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// checkNow makes every watched course due for a check immediately.
+func checkNow(courses []CourseStatus) {
+	for i := range courses {
+		courses[i].nextCheck = time.Time{}
+	}
+}
+
+// joinNotes combines the non-empty status line notes.
+func joinNotes(notes ...string) string {
+	return strings.Join(slices.DeleteFunc(notes, func(n string) bool { return n == "" }), " · ")
+}
+
+// watchCRN looks up a CRN typed while watching so it can be added to the watch.
+func (c Config) watchCRN(crn string, courses []CourseStatus) (CourseStatus, error) {
+	if err := validateCRN(crn); err != nil {
+		return CourseStatus{}, err
+	}
+	for _, course := range courses {
+		if course.CRN == crn && !course.Found {
+			return CourseStatus{}, fmt.Errorf("already watching %s", crn)
+		}
+	}
+	section, err := c.getSection(crn)
+	if err != nil {
+		return CourseStatus{}, err
+	}
+	return c.courseStatus(section), nil
+}
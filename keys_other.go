@@ -0,0 +1,8 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package main
+
+// startKeys is unsupported here; the watcher runs without keyboard controls.
+func startKeys() (<-chan byte, func()) {
+	return nil, func() {}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"testing"
+)
+
+// ===================
+// controls tests
+// ===================
+
+func TestHandleKey_Toggles(t *testing.T) {
+	var ctl controls
+	courses := []CourseStatus{{CRN: "11111"}}
+
+	ctl.handleKey('p', courses)
+	ctl.handleKey('s', courses)
+	if !ctl.paused || !ctl.muted {
+		t.Errorf("got %+v, want paused and muted", ctl)
+	}
+	ctl.handleKey('p', courses)
+	if ctl.paused {
+		t.Error("expected second p to resume")
+	}
+}
+
+func TestHandleKey_Actions(t *testing.T) {
+	tests := map[byte]keyAction{'c': keyCheckNow, 'x': keyRemove, 'q': keyQuit, 'z': keyNone}
+	for k, want := range tests {
+		var ctl controls
+		if got, _ := ctl.handleKey(k, nil); got != want {
+			t.Errorf("key %q: got %v, want %v", k, got, want)
+		}
+	}
+}
+
+func TestHandleKey_TypingCRN(t *testing.T) {
+	var ctl controls
+	ctl.handleKey('a', nil)
+	for _, k := range []byte("123456") {
+		ctl.handleKey(k, nil)
+	}
+	ctl.handleKey(127, nil) // backspace
+	ctl.handleKey('9', nil)
+
+	// typing doesn't trigger other controls
+	if action, _ := ctl.handleKey('q', nil); action != keyNone {
+		t.Errorf("q while typing: got %v, want keyNone", action)
+	}
+
+	action, crn := ctl.handleKey('\r', nil)
+	if action != keyAdd || crn != "12349" {
+		t.Errorf("got %v %q, want keyAdd 12349", action, crn)
+	}
+	if ctl.adding {
+		t.Error("expected typing to end on Enter")
+	}
+}
+
+func TestHandleKey_EscCancelsTyping(t *testing.T) {
+	var ctl controls
+	ctl.handleKey('a', nil)
+	ctl.handleKey('1', nil)
+	if action, _ := ctl.handleKey(27, nil); action != keyNone || ctl.adding {
+		t.Errorf("got %v, adding %v; want typing cancelled", action, ctl.adding)
+	}
+}
+
+func TestControls_HighlightSkipsStoppedCourses(t *testing.T) {
+	var ctl controls
+	courses := []CourseStatus{{CRN: "11111"}, {CRN: "22222", Found: true}, {CRN: "33333"}}
+
+	ctl.handleKey('n', courses)
+	if i, ok := ctl.highlighted(courses); !ok || courses[i].CRN != "33333" {
+		t.Errorf("highlighted %d, want 33333", i)
+	}
+
+	courses[2].Found = true
+	if i, ok := ctl.highlighted(courses); !ok || courses[i].CRN != "11111" {
+		t.Errorf("highlighted %d, want 11111 after 33333 stopped", i)
+	}
+
+	courses[0].Found = true
+	if _, ok := ctl.highlighted(courses); ok {
+		t.Error("expected no highlight when nothing is watched")
+	}
+}
+
+func TestControls_Status(t *testing.T) {
+	ctl := controls{paused: true}
+	if got := ctl.status([]CourseStatus{{CRN: "12345"}}); got != "paused · ▸ 12345" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestJoinNotes(t *testing.T) {
+	if got := joinNotes("", "paused", "", "drop/add ends today"); got != "paused · drop/add ends today" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestWatchCRN_RejectsDuplicateAndInvalid(t *testing.T) {
+	cfg := Config{}
+	courses := []CourseStatus{{CRN: "12345"}}
+
+	if _, err := cfg.watchCRN("1234", courses); err == nil {
+		t.Error("expected error for an invalid CRN")
+	}
+	if _, err := cfg.watchCRN("12345", courses); err == nil {
+		t.Error("expected error for a CRN already watched")
+	}
+}
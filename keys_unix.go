@@ -0,0 +1,43 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package main
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// startKeys switches the terminal to reading single keypresses without echoing them,
+// and returns the keys as they are pressed along with a function that restores the
+// terminal. When stdin isn't a terminal, the channel never receives.
+func startKeys() (<-chan byte, func()) {
+	fd := int(os.Stdin.Fd())
+	saved, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, func() {}
+	}
+
+	cbreak := *saved
+	cbreak.Lflag &^= unix.ICANON | unix.ECHO
+	cbreak.Cc[unix.VMIN], cbreak.Cc[unix.VTIME] = 1, 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &cbreak); err != nil {
+		return nil, func() {}
+	}
+	restore := func() { unix.IoctlSetTermios(fd, ioctlSetTermios, saved) }
+
+	// Ctrl-C would otherwise exit with the terminal still in cbreak mode
+	onInterrupt(restore)
+
+	keys := make(chan byte)
+	go func() {
+		buf := make([]byte, 1)
+		for {
+			if n, err := os.Stdin.Read(buf); err != nil || n == 0 {
+				return
+			}
+			keys <- buf[0]
+		}
+	}()
+	return keys, restore
+}
@@ -0,0 +1,60 @@
+package main
+
+import "fmt"
+
+// Course levels for the level filter
+const (
+	LevelUndergrad = "undergrad" // 1000- to 4000-level courses
+	LevelGrad      = "grad"      // 5000-level courses and up
+)
+
+// validateLevel checks that the level filter is empty (every level), undergrad, or grad.
+func validateLevel(level string) error {
+	switch level {
+	case "", LevelUndergrad, LevelGrad:
+		return nil
+	}
+	return fmt.Errorf("invalid level %q: must be %q or %q", level, LevelUndergrad, LevelGrad)
+}
+
+// courseLevel returns the level of a course code like "CS-3114", or "" if the
+// code has no course number. Courses numbered 5000 and up are graduate courses.
+func courseLevel(course string) string {
+	_, number, err := parseCourseCode(course)
+	if err != nil {
+		return ""
+	}
+	if number[0] >= '5' {
+		return LevelGrad
+	}
+	return LevelUndergrad
+}
+
+// filterLevel returns the sections at the given level. Sections whose level
+// can't be told from their course code are kept.
+func filterLevel(sections []Section, level string) []Section {
+	if level == "" {
+		return sections
+	}
+	var kept []Section
+	for _, s := range sections {
+		if l := courseLevel(s.Course); l == "" || l == level {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// validateCourseLevels checks that no watched course is outside the level filter,
+// since its sections would all be filtered out.
+func (c Config) validateCourseLevels() error {
+	if c.Level == "" {
+		return nil
+	}
+	for _, course := range c.Courses {
+		if l := courseLevel(course); l != "" && l != c.Level {
+			return fmt.Errorf("courses: %s is a %s course, but level is %q", course, l, c.Level)
+		}
+	}
+	return nil
+}
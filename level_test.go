@@ -0,0 +1,64 @@
+package main
+
+import "testing"
+
+// ===================
+// courseLevel tests
+// ===================
+
+func TestCourseLevel(t *testing.T) {
+	tests := map[string]string{
+		"CS-3114":   LevelUndergrad,
+		"STAT-4705": LevelUndergrad,
+		"CS-5114":   LevelGrad,
+		"ECE-6504":  LevelGrad,
+		"":          "",
+		"Staff":     "",
+	}
+
+	for course, want := range tests {
+		if got := courseLevel(course); got != want {
+			t.Errorf("courseLevel(%q) = %q, want %q", course, got, want)
+		}
+	}
+}
+
+// ===================
+// filterLevel tests
+// ===================
+
+func TestFilterLevel(t *testing.T) {
+	sections := []Section{
+		{CRN: "11111", Course: "CS-4114"},
+		{CRN: "22222", Course: "CS-5114"},
+		{CRN: "33333"},
+	}
+
+	if got := filterLevel(sections, ""); len(got) != 3 {
+		t.Errorf("no filter: got %d sections, want 3", len(got))
+	}
+	got := filterLevel(sections, LevelUndergrad)
+	if len(got) != 2 || got[0].CRN != "11111" || got[1].CRN != "33333" {
+		t.Errorf("undergrad: got %+v, want 11111 and the unknown-level 33333", got)
+	}
+	if got := filterLevel(sections, LevelGrad); len(got) != 2 || got[0].CRN != "22222" {
+		t.Errorf("grad: got %+v, want 22222 and the unknown-level 33333", got)
+	}
+}
+
+// ===================
+// level config tests
+// ===================
+
+func TestLoadConfig_ErrorInvalidLevel(t *testing.T) {
+	tests := map[string]string{
+		"unknown level":     `{"crns": ["12345"], "level": "graduate"}`,
+		"course outside it": `{"courses": ["CS-5114"], "level": "undergrad"}`,
+	}
+
+	for name, content := range tests {
+		if _, err := loadConfig(createTempConfig(t, content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultObserveMinutes is how often seat counts are recorded when observeMinutes isn't configured
+const DefaultObserveMinutes = 15
+
+// EventObserved records a section's seat counts, for spotting trends before it opens
+const EventObserved = "observed"
+
+// hint is a sign that a full section may open soon
+type hint string
+
+const (
+	hintNone           hint = ""
+	hintCapacityRaised hint = "capacity_raised" // the department just added seats
+	hintTrending       hint = "trending"        // enrollment has been falling
+	hintOpensOften     hint = "opens_often"     // the section opened several times this week
+)
+
+const (
+	opensOftenThreshold    = 3              // openings in a week that count as often
+	trendObservations      = 3              // observations a trend is judged over
+	capacityRaisedFreshFor = 24 * time.Hour // how long a capacity increase stays news
+)
+
+// strong reports whether the hint is worth an email on its own.
+func (h hint) strong() bool {
+	return h == hintCapacityRaised
+}
+
+// String describes the hint for the terminal and notifications.
+func (h hint) String() string {
+	switch h {
+	case hintCapacityRaised:
+		return T("likelihood.capacity_raised")
+	case hintTrending:
+		return T("likelihood.trending")
+	case hintOpensOften:
+		return T("likelihood.opens_often")
+	}
+	return ""
+}
+
+// observeInterval returns how often each CRN's seat counts are recorded.
+func (c Config) observeInterval() time.Duration {
+	if c.ObserveMinutes == 0 {
+		return DefaultObserveMinutes * time.Minute
+	}
+	return time.Duration(c.ObserveMinutes) * time.Minute
+}
+
+// parseSeats reads the timetable's seats cell. "Full" counts as no seats, and
+// negative counts mean the section is over-enrolled.
+func parseSeats(seats string) (int, bool) {
+	fields := strings.Fields(seats)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	if strings.EqualFold(fields[0], "full") {
+		return 0, true
+	}
+	n, err := strconv.Atoi(fields[0])
+	return n, err == nil
+}
+
+// likelihood judges from the history whether a CRN's section looks likely to open soon.
+// A recent capacity increase beats falling enrollment, which beats frequent past openings.
+func likelihood(events []HistoryEvent, crn string, now time.Time) hint {
+	var observed []HistoryEvent
+	opens := 0
+	for _, e := range events {
+		if e.CRN != crn {
+			continue
+		}
+		switch {
+		case e.Event == EventObserved:
+			observed = append(observed, e)
+		case e.Event == EventOpen && now.Sub(e.Time) <= SummaryPeriod:
+			opens++
+		}
+	}
+
+	if n := len(observed); n >= 2 {
+		last, prev := observed[n-1], observed[n-2]
+		lastCap, err1 := strconv.Atoi(last.Capacity)
+		prevCap, err2 := strconv.Atoi(prev.Capacity)
+		if err1 == nil && err2 == nil && lastCap > prevCap && now.Sub(last.Time) <= capacityRaisedFreshFor {
+			return hintCapacityRaised
+		}
+	}
+	if trending(observed) {
+		return hintTrending
+	}
+	if opens >= opensOftenThreshold {
+		return hintOpensOften
+	}
+	return hintNone
+}
+
+// trending reports whether the seats left have risen over the last few observations
+// without the section opening, i.e. students are dropping out of a full section.
+func trending(observed []HistoryEvent) bool {
+	if len(observed) < trendObservations {
+		return false
+	}
+	var seats []int
+	for _, e := range observed[len(observed)-trendObservations:] {
+		n, ok := parseSeats(e.Seats)
+		if !ok {
+			return false
+		}
+		seats = append(seats, n)
+	}
+	for i := 1; i < len(seats); i++ {
+		if seats[i] < seats[i-1] {
+			return false
+		}
+	}
+	return seats[len(seats)-1] > seats[0] && seats[len(seats)-1] <= 0
+}
+
+// observe looks the course's section up again, announcing changes to its listing
+// and updating its hint.
+func (c Config) observe(course *CourseStatus, sender EmailSender, now time.Time) {
+	section, err := c.getSection(course.CRN)
+	if err != nil {
+		return
+	}
+	c.checkInstructor(course, section, sender, now)
+	c.checkMeeting(course, section, sender, now)
+	c.updateHint(course, section, sender, now)
+}
+
+// updateHint records the section's seat counts in the history and updates the course's
+// hint, announcing the hint when it changes and emailing strong hints when enabled.
+func (c Config) updateHint(course *CourseStatus, section Section, sender EmailSender, now time.Time) {
+	if c.HistoryFile == "" {
+		return
+	}
+	e := HistoryEvent{Time: now, Event: EventObserved, CRN: course.CRN, Term: c.termOf(*course), Name: course.Name, Seats: section.Seats, Capacity: section.Capacity}
+	if err := appendHistory(c.HistoryFile, e); err != nil {
+		PrintHistoryError(err)
+		return
+	}
+
+	events, err := readHistory(c.HistoryFile)
+	if err != nil {
+		PrintHistoryError(err)
+		return
+	}
+	h := likelihood(events, course.CRN, now)
+	if h == course.Hint {
+		return
+	}
+	course.Hint = h
+	if h == hintNone {
+		return
+	}
+	PrintHint(course.Name, course.CRN, h.String())
+
+	if h.strong() && c.NotifyHints && c.notifying() {
+		c.notify(sender, c.courseAlert(AlertHint, *course, T("email.hint.subject", course.CRN), T("email.hint.body", course.Name, course.CRN, h)))
+	}
+}
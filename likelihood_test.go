@@ -0,0 +1,91 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func observed(at time.Time, seats, capacity string) HistoryEvent {
+	return HistoryEvent{Time: at, Event: EventObserved, CRN: "12345", Seats: seats, Capacity: capacity}
+}
+
+// ===================
+// likelihood tests
+// ===================
+
+func TestLikelihood(t *testing.T) {
+	now := time.Now()
+	hour := func(h int) time.Time { return now.Add(time.Duration(-h) * time.Hour) }
+	opened := func(h int) HistoryEvent { return HistoryEvent{Time: hour(h), Event: EventOpen, CRN: "12345"} }
+
+	tests := map[string]struct {
+		events []HistoryEvent
+		want   hint
+	}{
+		"nothing observed":   {nil, hintNone},
+		"steady":             {[]HistoryEvent{observed(hour(3), "Full", "100"), observed(hour(2), "Full", "100"), observed(hour(1), "Full", "100")}, hintNone},
+		"capacity raised":    {[]HistoryEvent{observed(hour(2), "Full", "100"), observed(hour(1), "Full", "110")}, hintCapacityRaised},
+		"raised long ago":    {[]HistoryEvent{observed(hour(50), "Full", "100"), observed(hour(49), "Full", "110")}, hintNone},
+		"over-enrolled drop": {[]HistoryEvent{observed(hour(3), "-4", "100"), observed(hour(2), "-2", "100"), observed(hour(1), "-1", "100")}, hintTrending},
+		"still filling":      {[]HistoryEvent{observed(hour(3), "-1", "100"), observed(hour(2), "-2", "100"), observed(hour(1), "-2", "100")}, hintNone},
+		"opens often":        {[]HistoryEvent{opened(100), opened(50), opened(10)}, hintOpensOften},
+		"opened last month":  {[]HistoryEvent{opened(700), opened(600), opened(500)}, hintNone},
+		"other CRN":          {[]HistoryEvent{{Time: hour(2), Event: EventObserved, CRN: "67890", Capacity: "1"}, {Time: hour(1), Event: EventObserved, CRN: "67890", Capacity: "9"}}, hintNone},
+	}
+
+	for name, tt := range tests {
+		if got := likelihood(tt.events, "12345", now); got != tt.want {
+			t.Errorf("%s: likelihood = %q, want %q", name, got, tt.want)
+		}
+	}
+}
+
+func TestParseSeats(t *testing.T) {
+	tests := map[string]int{"Full": 0, "12": 12, "-3": -3, "Full 0": 0}
+	for seats, want := range tests {
+		if got, ok := parseSeats(seats); !ok || got != want {
+			t.Errorf("parseSeats(%q) = %d, %v; want %d", seats, got, ok, want)
+		}
+	}
+	if _, ok := parseSeats(""); ok {
+		t.Error("expected an empty seats cell not to parse")
+	}
+}
+
+// ===================
+// observe tests
+// ===================
+
+func TestObserve_EmailsCapacityIncrease(t *testing.T) {
+	capacity := "100"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<table class="dataentrytable">
+			<tr><th>CRN</th><th>Course</th><th>Title</th><th>Seats</th><th>Capacity</th></tr>
+			<tr><td>12345</td><td>CS-3114</td><td>Data Structures</td><td>Full</td><td>` + capacity + `</td></tr></table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Term: "202601", Email: "test@example.com", NotifyHints: true, HistoryFile: filepath.Join(t.TempDir(), "history.jsonl")}
+	sender := &MockEmailSender{}
+	course := CourseStatus{CRN: "12345", Name: "Data Structures"}
+
+	cfg.observe(&course, sender, time.Now())
+	capacity = "110"
+	cfg.observe(&course, sender, time.Now())
+
+	if course.Hint != hintCapacityRaised {
+		t.Errorf("hint = %q, want capacity raised", course.Hint)
+	}
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "capacity just raised") {
+		t.Errorf("expected one hint email, got %+v", sender.Sent)
+	}
+
+	events, _ := readHistory(cfg.HistoryFile)
+	if len(events) != 2 || events[1].Capacity != "110" || events[1].Seats != "Full" {
+		t.Errorf("got history %+v", events)
+	}
+}
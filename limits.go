@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Limits on a single timetable response, so a huge, malformed, or hostile
+// response can't use up memory or hold up the check loop. Real results pages,
+// even for the largest subjects, are a few megabytes and parse in well under a
+// second. Variables so tests can lower them.
+var (
+	maxResponseBytes int64 = 32 << 20
+	parseTimeout           = 10 * time.Second
+)
+
+// ErrResponseTooLarge indicates a timetable response over maxResponseBytes
+var ErrResponseTooLarge = errors.New("timetable response too large")
+
+// ErrParseTimeout indicates a timetable response that took longer than parseTimeout to parse
+var ErrParseTimeout = errors.New("timetable response took too long to parse")
+
+// readResponse reads a response body, failing once it passes maxResponseBytes
+// rather than reading the rest.
+func readResponse(body io.Reader) ([]byte, error) {
+	raw, err := io.ReadAll(io.LimitReader(body, maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if int64(len(raw)) > maxResponseBytes {
+		return nil, fmt.Errorf("%w: over %d MiB", ErrResponseTooLarge, maxResponseBytes>>20)
+	}
+	return raw, nil
+}
+
+// parseResponse parses a response as HTML, giving up after parseTimeout. The parser
+// can't be stopped partway, so a parse that runs over finishes in the background
+// and its result is dropped; readResponse already bounds how much it can hold.
+func parseResponse(raw []byte) (*goquery.Document, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), parseTimeout)
+	defer cancel()
+
+	type parsed struct {
+		doc *goquery.Document
+		err error
+	}
+	done := make(chan parsed, 1)
+	go func() {
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(raw))
+		done <- parsed{doc, err}
+	}()
+
+	select {
+	case p := <-done:
+		if p.err != nil {
+			return nil, fmt.Errorf("failed to parse HTML: %w", p.err)
+		}
+		return p.doc, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w (over %v)", ErrParseTimeout, parseTimeout)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// Response limit tests
+// ===================
+
+// lowerLimits shrinks the response limits for one test.
+func lowerLimits(t *testing.T, size int64, timeout time.Duration) {
+	oldSize, oldTimeout := maxResponseBytes, parseTimeout
+	maxResponseBytes, parseTimeout = size, timeout
+	t.Cleanup(func() { maxResponseBytes, parseTimeout = oldSize, oldTimeout })
+}
+
+func TestFetchDocument_ResponseTooLarge(t *testing.T) {
+	lowerLimits(t, 1<<20, parseTimeout)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>" + strings.Repeat("<p>x</p>", 1<<18) + "</html>"))
+	}))
+	defer server.Close()
+
+	_, err := fetchDocument(http.DefaultClient, server.URL, url.Values{})
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+	if exitCode(err) != ExitNetwork {
+		t.Errorf("expected a network exit code, got %d", exitCode(err))
+	}
+}
+
+func TestReadResponse_AtLimit(t *testing.T) {
+	lowerLimits(t, 16, parseTimeout)
+	if raw, err := readResponse(strings.NewReader(strings.Repeat("x", 16))); err != nil || len(raw) != 16 {
+		t.Errorf("expected a response at the limit to be read, got %d bytes, %v", len(raw), err)
+	}
+	if _, err := readResponse(strings.NewReader(strings.Repeat("x", 17))); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge just over the limit, got %v", err)
+	}
+}
+
+func TestParseResponse_Timeout(t *testing.T) {
+	lowerLimits(t, maxResponseBytes, time.Nanosecond)
+	raw := []byte("<html>" + strings.Repeat("<div><p>x</p></div>", 1<<16) + "</html>")
+
+	_, err := parseResponse(raw)
+	if !errors.Is(err, ErrParseTimeout) {
+		t.Fatalf("expected ErrParseTimeout, got %v", err)
+	}
+	if exitCode(err) != ExitNetwork {
+		t.Errorf("expected a network exit code, got %d", exitCode(err))
+	}
+}
+
+func TestParseResponse_WithinBudget(t *testing.T) {
+	doc, err := parseResponse([]byte(`<html><table class="dataentrytable"></table></html>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Find(".dataentrytable").Length() != 1 {
+		t.Error("expected the document to be parsed")
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrAlreadyRunning indicates another OpenSeat is already watching with the same config
+var ErrAlreadyRunning = errors.New("openseat is already running with this config")
+
+// lockPath returns the lock file for a config, kept next to it so each config
+// (or profile) can be watched by one instance at a time.
+func lockPath(configPath string) string {
+	return configPath + ".lock"
+}
+
+// acquireLock claims the lock file by creating it with this process's pid, so a
+// second instance started with the same config fails instead of doubling the request
+// rate and sending duplicate alerts. A lock left by a process that is no longer
+// running is taken over; force takes over a live one too.
+// The returned function removes the lock.
+func acquireLock(path string, force bool) (func(), error) {
+	// the pid is written to a file of its own and then linked into place, which fails
+	// if the lock exists, so no other instance can see the lock without its pid and
+	// take it for a stale one
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return nil, fmt.Errorf("lock: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	fmt.Fprintf(tmp, "%d\n", os.Getpid())
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("lock: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return nil, fmt.Errorf("lock: %w", err)
+	}
+
+	// two attempts: the second follows removing a stale (or forced) lock
+	for attempt := 0; attempt < 2; attempt++ {
+		err := os.Link(tmp.Name(), path)
+		if err == nil {
+			return func() { releaseLock(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("lock: %w", err)
+		}
+
+		pid, ok := lockOwner(path)
+		if ok && processAlive(pid) && !force {
+			return nil, fmt.Errorf("%w (pid %d); stop it first, or start with --force if it isn't really running", ErrAlreadyRunning, pid)
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("lock: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("lock: %s keeps reappearing", path)
+}
+
+// lockOwner reads the pid recorded in a lock file.
+func lockOwner(path string) (int, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	return pid, err == nil && pid > 0
+}
+
+// releaseLock removes the lock file, unless another instance has since taken it over with --force.
+func releaseLock(path string) {
+	if pid, ok := lockOwner(path); ok && pid == os.Getpid() {
+		os.Remove(path)
+	}
+}
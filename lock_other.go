@@ -0,0 +1,16 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// processAlive reports whether a process with the given pid exists.
+// On Windows, finding a process fails once it has exited.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	p.Release()
+	return true
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ===================
+// acquireLock tests
+// ===================
+
+func TestAcquireLock_SecondInstanceFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json.lock")
+
+	release, err := acquireLock(path, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer release()
+
+	_, err = acquireLock(path, false)
+	if !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("expected ErrAlreadyRunning, got %v", err)
+	}
+	if !strings.Contains(err.Error(), fmt.Sprintf("pid %d", os.Getpid())) {
+		t.Errorf("expected the running pid in %q", err)
+	}
+}
+
+func TestAcquireLock_Force(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json.lock")
+	if _, err := acquireLock(path, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := acquireLock(path, true); err != nil {
+		t.Errorf("expected --force to take over the lock, got %v", err)
+	}
+}
+
+func TestAcquireLock_TakesOverStaleLock(t *testing.T) {
+	tests := map[string]string{
+		"exited process": "2147483646\n",
+		"garbage":        "not a pid\n",
+	}
+
+	for name, content := range tests {
+		path := filepath.Join(t.TempDir(), "config.json.lock")
+		os.WriteFile(path, []byte(content), 0o644)
+
+		if _, err := acquireLock(path, false); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if pid, _ := lockOwner(path); pid != os.Getpid() {
+			t.Errorf("%s: lock owner = %d, want %d", name, pid, os.Getpid())
+		}
+	}
+}
+
+func TestAcquireLock_OnlyOneOfManyAtOnce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json.lock")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	acquired := 0
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := acquireLock(path, false); err == nil {
+				mu.Lock()
+				acquired++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if acquired != 1 {
+		t.Errorf("%d instances took the lock at once, want 1", acquired)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only the lock file left, got %v", entries)
+	}
+}
+
+func TestAcquireLock_ReleaseRemovesLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json.lock")
+	release, err := acquireLock(path, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected the lock file to be removed")
+	}
+}
+
+func TestReleaseLock_KeepsLockTakenOverByAnother(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json.lock")
+	os.WriteFile(path, []byte("1\n"), 0o644)
+
+	releaseLock(path)
+	if _, err := os.Stat(path); err != nil {
+		t.Error("expected another instance's lock to be left alone")
+	}
+}
@@ -3,20 +3,99 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 func main() {
-	// Check for --demo flag
-	for _, arg := range os.Args[1:] {
-		if arg == "--demo" {
-			RunDemo()
-			return
-		}
+	// Default to the watch command so a bare `openseat` (or `openseat --demo`) keeps working
+	cmd, args := "watch", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cmd, args = args[0], args[1:]
 	}
+	// installed as openseatctl (e.g. a symlink), every argument belongs to ctl
+	if strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe") == "openseatctl" {
+		cmd, args = "ctl", os.Args[1:]
+	}
+
+	handleInterrupts()
 
-	if err := Run(RunOptions{ConfigPath: "config.json"}); err != nil {
-		log.Fatal(err)
+	if err := runCommand(cmd, args); err != nil {
+		log.Print(err)
+		os.Exit(exitCode(err))
 	}
 }
+
+// runCommand runs a subcommand with its arguments.
+func runCommand(cmd string, args []string) error {
+	switch cmd {
+	case "watch":
+		return runWatch(args)
+	case "open":
+		return runOpen(args)
+	case "diff":
+		return runDiff(args)
+	case "doctor":
+		return runDoctor(args)
+	case "bench":
+		return runBench(args)
+	case "enrolled":
+		return runEnrolled(args)
+	case "heatmap":
+		return runHeatmap(args)
+	case "info":
+		return runInfo(args)
+	case "history":
+		return runHistory(args)
+	case "audit":
+		return runAudit(args)
+	case "export":
+		return runExport(args)
+	case "import":
+		return runImport(args)
+	case "ctl":
+		return runCtl(args)
+	case "server":
+		return runServer(args)
+	case "agent":
+		return runAgent(args)
+	default:
+		return configError(fmt.Errorf("unknown command %q", cmd))
+	}
+}
+
+// runWatch parses the watch command's flags and starts monitoring.
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file`")
+	demo := fs.Bool("demo", false, "run a scripted demo for recording GIFs/videos")
+	simulate := fs.Bool("simulate", false, "watch a built-in fake timetable whose sections open and close on a script")
+	record := fs.String("record", "", "record every timetable response to `dir`")
+	replay := fs.String("replay", "", "serve timetable responses recorded in `dir` instead of contacting the timetable")
+	noBanner := fs.Bool("no-banner", false, "don't show the ASCII art banner")
+	noIcons := fs.Bool("no-icons", false, "leave out the Nerd Font icons")
+	accessible := fs.Bool("accessible", false, "plain line-oriented output for screen readers: no spinners, boxes, icons, or colors")
+	once := fs.Bool("once", false, "check each CRN once and exit: 0 if a seat is open, 4 if none are")
+	crnFile := fs.String("crn-file", "", "also watch the CRNs listed in `file` (newline or comma separated), or - to read them from stdin")
+	force := fs.Bool("force", false, "start even if another openseat seems to be running with the same config")
+	report := fs.String("report", "", "write a JSON report to `file` when the watcher stops, or - for stdout")
+	fs.Parse(args)
+
+	if *demo {
+		RunDemo()
+		return nil
+	}
+
+	if *record != "" && *replay != "" {
+		return configError(fmt.Errorf("--record and --replay cannot be used together"))
+	}
+	if *simulate && *replay != "" {
+		return configError(fmt.Errorf("--simulate and --replay cannot be used together"))
+	}
+
+	return Run(RunOptions{ConfigPath: *configPath, RecordDir: *record, ReplayDir: *replay, Simulate: *simulate, Accessible: *accessible, HideBanner: *noBanner, HideIcons: *noIcons, Once: *once, CRNFile: *crnFile, Force: *force, ReportFile: *report})
+}
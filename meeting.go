@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// EventMeetingChanged records a change to a watched section's days, times, or location
+const EventMeetingChanged = "meeting_changed"
+
+// where describes when and where a section meets, e.g. "M W F 10:10AM-11:00AM, MCB 100".
+func where(meeting, location string) string {
+	var parts []string
+	for _, p := range []string{meeting, location} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// checkMeeting announces a change to the section's meeting days, times, or location,
+// and remembers the new ones.
+func (c Config) checkMeeting(course *CourseStatus, section Section, sender EmailSender, now time.Time) {
+	previous := where(course.Meeting, course.Location)
+	current := where(section.Meeting(), section.Location)
+	if current == "" || previous == current {
+		return
+	}
+	course.Meeting, course.Location = section.Meeting(), section.Location
+	if previous == "" {
+		return // the first listing seen, nothing to compare against
+	}
+
+	PrintMeetingChanged(course.Name, course.CRN, previous, current)
+	c.recordChange(EventMeetingChanged, *course, previous, current, now)
+	if !c.notifying() {
+		return
+	}
+	c.notify(sender, c.courseAlert(EventMeetingChanged, *course, T("email.meeting.subject", course.CRN), T("email.meeting.body", course.Name, course.CRN, previous, current)))
+}
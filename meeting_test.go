@@ -0,0 +1,56 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// checkMeeting tests
+// ===================
+
+func TestCheckMeeting_RoomChange(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601", HistoryFile: filepath.Join(t.TempDir(), "history.jsonl")}
+	sender := &MockEmailSender{}
+	course := cfg.courseStatus(Section{CRN: "12345", Title: "Data Structures", Days: "M W F", Begin: "10:10AM", End: "11:00AM", Location: "MCB 100"})
+
+	moved := Section{CRN: "12345", Days: "M W F", Begin: "10:10AM", End: "11:00AM", Location: "GBJ 102"}
+	cfg.checkMeeting(&course, moved, sender, time.Now())
+	cfg.checkMeeting(&course, moved, sender, time.Now())
+
+	if len(sender.Sent) != 1 {
+		t.Fatalf("expected one change email, got %+v", sender.Sent)
+	}
+	if body := sender.Sent[0].Body; !strings.Contains(body, "Was: M W F 10:10AM-11:00AM, MCB 100") || !strings.Contains(body, "Now: M W F 10:10AM-11:00AM, GBJ 102") {
+		t.Errorf("unexpected body %q", body)
+	}
+	events, _ := readHistory(cfg.HistoryFile)
+	if len(events) != 1 || events[0].Event != EventMeetingChanged {
+		t.Errorf("got history %+v", events)
+	}
+}
+
+func TestCheckMeeting_TimeChange(t *testing.T) {
+	cfg := Config{Email: "test@example.com"}
+	sender := &MockEmailSender{}
+	course := cfg.courseStatus(Section{CRN: "12345", Days: "T R", Begin: "2:00PM", End: "3:15PM"})
+
+	cfg.checkMeeting(&course, Section{Days: "T R", Begin: "3:30PM", End: "4:45PM"}, sender, time.Now())
+
+	if course.Meeting != "T R 3:30PM-4:45PM" || len(sender.Sent) != 1 {
+		t.Errorf("Meeting = %q after %d emails, want the new time and one email", course.Meeting, len(sender.Sent))
+	}
+}
+
+func TestCheckMeeting_BlankListingIgnored(t *testing.T) {
+	cfg := Config{Email: "test@example.com"}
+	sender := &MockEmailSender{}
+	course := cfg.courseStatus(Section{CRN: "12345", Days: "T R", Begin: "2:00PM", End: "3:15PM"})
+
+	cfg.checkMeeting(&course, Section{}, sender, time.Now())
+	if len(sender.Sent) != 0 || course.Meeting == "" {
+		t.Errorf("expected a blank listing to be ignored, got %d emails and meeting %q", len(sender.Sent), course.Meeting)
+	}
+}
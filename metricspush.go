@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Metrics push formats
+const (
+	PushInflux   = "influx"   // InfluxDB line protocol over HTTP
+	PushGraphite = "graphite" // Graphite plaintext protocol over TCP
+)
+
+// Defaults for pushing metrics
+const (
+	DefaultPushInterval = 60 * time.Second
+	DefaultGraphitePort = "2003"
+)
+
+// MetricsPush configures pushing OpenSeat's metrics to InfluxDB or Graphite, for
+// monitoring stacks that don't take OTLP. The same counters and timings as the
+// OTLP export are sent every interval. An InfluxDB token, if needed, comes from
+// INFLUX_TOKEN.
+type MetricsPush struct {
+	Format          string `json:"format"`          // "influx" or "graphite"
+	URL             string `json:"url"`             // InfluxDB write URL, e.g. http://localhost:8086/api/v2/write?org=me&bucket=openseat, or a Graphite host:port
+	IntervalSeconds int    `json:"intervalSeconds"` // how often metrics are pushed (defaults to 60)
+}
+
+// applyDefaults checks the format and URL, adding Graphite's default port.
+func (p *MetricsPush) applyDefaults() error {
+	if p.Format == "" && p.URL == "" {
+		return nil
+	}
+	switch p.Format {
+	case PushInflux:
+		u, err := url.Parse(p.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid telemetry push url %q: InfluxDB needs an http:// or https:// write URL", p.URL)
+		}
+	case PushGraphite:
+		if p.URL == "" {
+			return fmt.Errorf("telemetry push: url is required, e.g. graphite.example.com:2003")
+		}
+		if _, _, err := net.SplitHostPort(p.URL); err != nil {
+			p.URL = net.JoinHostPort(p.URL, DefaultGraphitePort)
+		}
+	default:
+		return fmt.Errorf("invalid telemetry push format %q: must be influx or graphite", p.Format)
+	}
+	if p.IntervalSeconds < 0 {
+		return fmt.Errorf("telemetry push: intervalSeconds must be positive, got %d", p.IntervalSeconds)
+	}
+	return nil
+}
+
+// enabled reports whether metrics are pushed.
+func (p MetricsPush) enabled() bool {
+	return p.Format != ""
+}
+
+// interval returns how often metrics are pushed.
+func (p MetricsPush) interval() time.Duration {
+	if p.IntervalSeconds > 0 {
+		return time.Duration(p.IntervalSeconds) * time.Second
+	}
+	return DefaultPushInterval
+}
+
+// pushExporter is a metric exporter that writes InfluxDB line protocol or
+// Graphite plaintext. Counters are cumulative, as both expect.
+type pushExporter struct {
+	MetricsPush
+	service string // tagged on every metric, from telemetry's serviceName
+}
+
+func (e *pushExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return metricdata.CumulativeTemporality
+}
+
+func (e *pushExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// Export sends one round of metrics.
+func (e *pushExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	samples := e.samples(rm)
+	if len(samples) == 0 {
+		return nil
+	}
+	if e.Format == PushGraphite {
+		return e.sendGraphite(ctx, samples)
+	}
+	return e.sendInflux(ctx, samples)
+}
+
+func (e *pushExporter) ForceFlush(ctx context.Context) error { return nil }
+func (e *pushExporter) Shutdown(ctx context.Context) error   { return nil }
+
+// metricSample is one data point, flattened for either format
+type metricSample struct {
+	name   string
+	tags   [][2]string // key and value, in attribute order
+	fields [][2]string // name and formatted value, e.g. {"value", "3i"} for an integer
+	time   time.Time
+}
+
+// samples flattens the metrics into data points. Timings become their count,
+// sum, min, and max, since neither format has histograms.
+func (e *pushExporter) samples(rm *metricdata.ResourceMetrics) []metricSample {
+	var out []metricSample
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					out = append(out, e.sample(m.Name, dp.Attributes.ToSlice(), dp.Time, [2]string{"value", strconv.FormatInt(dp.Value, 10) + "i"}))
+				}
+			case metricdata.Sum[float64]:
+				for _, dp := range data.DataPoints {
+					out = append(out, e.sample(m.Name, dp.Attributes.ToSlice(), dp.Time, [2]string{"value", formatFloat(dp.Value)}))
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					fields := [][2]string{{"count", strconv.FormatUint(dp.Count, 10) + "i"}, {"sum", formatFloat(dp.Sum)}}
+					if v, ok := dp.Min.Value(); ok {
+						fields = append(fields, [2]string{"min", formatFloat(v)})
+					}
+					if v, ok := dp.Max.Value(); ok {
+						fields = append(fields, [2]string{"max", formatFloat(v)})
+					}
+					out = append(out, e.sample(m.Name, dp.Attributes.ToSlice(), dp.Time, fields...))
+				}
+			}
+		}
+	}
+	return out
+}
+
+// sample builds one data point, tagged with the service.
+func (e *pushExporter) sample(name string, attrs []attribute.KeyValue, t time.Time, fields ...[2]string) metricSample {
+	s := metricSample{name: name, time: t, fields: fields, tags: [][2]string{{"service", e.service}}}
+	for _, kv := range attrs {
+		s.tags = append(s.tags, [2]string{string(kv.Key), kv.Value.Emit()})
+	}
+	return s
+}
+
+// formatFloat formats a float field value.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// influxEscaper escapes the characters line protocol treats specially in names and tags
+var influxEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+// influxLines formats samples as InfluxDB line protocol, e.g.
+// openseat.checks,service=openseat,result=open value=3i 1736672400000000000
+func influxLines(samples []metricSample) []byte {
+	var b bytes.Buffer
+	for _, s := range samples {
+		b.WriteString(influxEscaper.Replace(s.name))
+		for _, tag := range s.tags {
+			if tag[1] == "" {
+				continue // line protocol doesn't allow empty tag values
+			}
+			fmt.Fprintf(&b, ",%s=%s", influxEscaper.Replace(tag[0]), influxEscaper.Replace(tag[1]))
+		}
+		for i, field := range s.fields {
+			sep := ","
+			if i == 0 {
+				sep = " "
+			}
+			fmt.Fprintf(&b, "%s%s=%s", sep, influxEscaper.Replace(field[0]), field[1])
+		}
+		fmt.Fprintf(&b, " %d\n", s.time.UnixNano())
+	}
+	return b.Bytes()
+}
+
+// graphiteEscaper replaces characters that would break a Graphite path or tag
+var graphiteEscaper = strings.NewReplacer(" ", "_", ";", "_", "~", "_", "=", "_")
+
+// graphiteLines formats samples as Graphite plaintext with tags, one line per
+// field, e.g. openseat.checks;service=openseat;result=open 3 1736672400
+func graphiteLines(samples []metricSample) []byte {
+	var b bytes.Buffer
+	for _, s := range samples {
+		for _, field := range s.fields {
+			name := s.name
+			if field[0] != "value" {
+				name += "." + field[0]
+			}
+			b.WriteString(graphiteEscaper.Replace(name))
+			for _, tag := range s.tags {
+				if tag[1] == "" {
+					continue // Graphite doesn't allow empty tag values
+				}
+				fmt.Fprintf(&b, ";%s=%s", graphiteEscaper.Replace(tag[0]), graphiteEscaper.Replace(tag[1]))
+			}
+			fmt.Fprintf(&b, " %s %d\n", strings.TrimSuffix(field[1], "i"), s.time.Unix())
+		}
+	}
+	return b.Bytes()
+}
+
+// sendInflux posts samples to the InfluxDB write URL.
+func (e *pushExporter) sendInflux(ctx context.Context, samples []metricSample) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(influxLines(samples)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if token := os.Getenv("INFLUX_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Token "+token)
+	}
+	return sendAPIRequest("influxdb", req)
+}
+
+// sendGraphite writes samples to the Graphite plaintext port.
+func (e *pushExporter) sendGraphite(ctx context.Context, samples []metricSample) error {
+	var d net.Dialer
+	ctx, cancel := context.WithTimeout(ctx, NotifyTimeout)
+	defer cancel()
+	conn, err := d.DialContext(ctx, "tcp", e.URL)
+	if err != nil {
+		return fmt.Errorf("graphite: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetWriteDeadline(deadline)
+	}
+	if _, err := conn.Write(graphiteLines(samples)); err != nil {
+		return fmt.Errorf("graphite: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// ===================
+// MetricsPush config tests
+// ===================
+
+func TestMetricsPush_ApplyDefaults(t *testing.T) {
+	p := MetricsPush{Format: PushGraphite, URL: "graphite.example.com"}
+	if err := p.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.URL != "graphite.example.com:2003" {
+		t.Errorf("expected the default Graphite port, got %q", p.URL)
+	}
+	if p.interval() != DefaultPushInterval {
+		t.Errorf("expected the default interval, got %v", p.interval())
+	}
+}
+
+func TestLoadConfig_ErrorInvalidMetricsPush(t *testing.T) {
+	for _, push := range []string{
+		`{"format": "prometheus", "url": "http://localhost:9090"}`,
+		`{"format": "influx", "url": "localhost:8086"}`,
+		`{"format": "graphite"}`,
+		`{"url": "http://localhost:8086/api/v2/write"}`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], "telemetry": {"push": `+push+`}}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", push)
+		}
+	}
+}
+
+// ===================
+// Formatting tests
+// ===================
+
+// testMetrics is one round of metrics like OpenSeat's own.
+func testMetrics(now time.Time) *metricdata.ResourceMetrics {
+	return &metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{Metrics: []metricdata.Metrics{
+		{Name: "openseat.checks", Data: metricdata.Sum[int64]{DataPoints: []metricdata.DataPoint[int64]{
+			{Attributes: attribute.NewSet(attribute.String("result", "open")), Time: now, Value: 3},
+		}}},
+		{Name: "openseat.search.duration", Data: metricdata.Histogram[float64]{DataPoints: []metricdata.HistogramDataPoint[float64]{
+			{Attributes: attribute.NewSet(attribute.String("backend", "html"), attribute.Bool("error", false)), Time: now, Count: 2, Sum: 1.5,
+				Min: metricdata.NewExtrema(0.5), Max: metricdata.NewExtrema(1.0)},
+		}}},
+	}}}}
+}
+
+func TestInfluxLines(t *testing.T) {
+	now := time.Unix(1736672400, 0)
+	e := &pushExporter{service: "openseat fall"}
+	got := string(influxLines(e.samples(testMetrics(now))))
+
+	want := `openseat.checks,service=openseat\ fall,result=open value=3i 1736672400000000000
+openseat.search.duration,service=openseat\ fall,backend=html,error=false count=2i,sum=1.5,min=0.5,max=1 1736672400000000000
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGraphiteLines(t *testing.T) {
+	now := time.Unix(1736672400, 0)
+	e := &pushExporter{service: "openseat"}
+	got := string(graphiteLines(e.samples(testMetrics(now))))
+
+	for _, line := range []string{
+		"openseat.checks;service=openseat;result=open 3 1736672400\n",
+		"openseat.search.duration.count;service=openseat;backend=html;error=false 2 1736672400\n",
+		"openseat.search.duration.max;service=openseat;backend=html;error=false 1 1736672400\n",
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("expected %q in:\n%s", line, got)
+		}
+	}
+}
+
+// ===================
+// Sending tests
+// ===================
+
+func TestPushExporter_Influx(t *testing.T) {
+	t.Setenv("INFLUX_TOKEN", "secret")
+	var body, auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body, auth = string(data), r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	e := &pushExporter{MetricsPush: MetricsPush{Format: PushInflux, URL: server.URL}, service: "openseat"}
+	if err := e.Export(context.Background(), testMetrics(time.Now())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if auth != "Token secret" || !strings.HasPrefix(body, "openseat.checks,") {
+		t.Errorf("unexpected request: auth %q, body %q", auth, body)
+	}
+}
+
+func TestPushExporter_Graphite(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	lines := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	e := &pushExporter{MetricsPush: MetricsPush{Format: PushGraphite, URL: listener.Addr().String()}, service: "openseat"}
+	if err := e.Export(context.Background(), testMetrics(time.Now())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for line := range lines {
+		got = append(got, line)
+	}
+	if len(got) != 5 || !strings.HasPrefix(got[0], "openseat.checks;") {
+		t.Errorf("expected 5 lines, got %q", got)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventSectionAdded records a section that appeared for a watched course
+const EventSectionAdded = "section_added"
+
+// courseWatch watches a course for sections added after monitoring started
+type courseWatch struct {
+	Code            string // subject and number, e.g. "CS-3114"
+	Subject, Number string
+
+	known     map[string]bool // CRNs listed so far
+	nextCheck time.Time
+}
+
+// validateCourses checks that every watched course is a subject and number.
+func (c Config) validateCourses() error {
+	for _, course := range c.Courses {
+		if _, _, err := parseCourseCode(course); err != nil {
+			return fmt.Errorf("courses: %w", err)
+		}
+	}
+	return nil
+}
+
+// startCourseWatch lists the course's current sections, which later checks are compared against.
+func (c Config) startCourseWatch(course string) (*courseWatch, error) {
+	subject, number, err := parseCourseCode(course)
+	if err != nil {
+		return nil, err
+	}
+	w := &courseWatch{Code: subject + "-" + number, Subject: subject, Number: number, known: make(map[string]bool)}
+	sections, err := w.sections(c)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sections {
+		w.known[s.CRN] = true
+	}
+	return w, nil
+}
+
+// sections lists the course's sections at the configured level in every configured term and campus.
+func (w *courseWatch) sections(c Config) ([]Section, error) {
+	sections, err := c.findSections(searchQuery{Subject: w.Subject, Number: w.Number}, "course "+w.Code)
+	return filterLevel(sections, c.Level), err
+}
+
+// added returns the sections not listed before, remembering them so each is reported once.
+func (w *courseWatch) added(sections []Section) []Section {
+	var added []Section
+	for _, s := range sections {
+		if !w.known[s.CRN] {
+			w.known[s.CRN] = true
+			added = append(added, s)
+		}
+	}
+	return added
+}
+
+// checkCourseWatch lists the course's sections again and announces any that are new.
+// Failures are reported and the watch is retried at its next check.
+func (c Config) checkCourseWatch(w *courseWatch, sender EmailSender, checkTime string, now time.Time) {
+	w.nextCheck = now.Add(c.checkInterval(PriorityNormal))
+	sections, err := w.sections(c)
+	if err != nil && len(sections) == 0 {
+		PrintCheckError(checkTime, w.Code, err)
+		return
+	}
+
+	for _, s := range w.added(sections) {
+		PrintNewSection(w.Code, s)
+		e := HistoryEvent{Time: now, Event: EventSectionAdded, CRN: s.CRN, Term: c.Term, Name: s.Title}
+		if c.HistoryFile != "" {
+			if err := appendHistory(c.HistoryFile, e); err != nil {
+				PrintHistoryError(err)
+			}
+		}
+		c.notifyNewSection(sender, w.Code, s)
+	}
+}
+
+// notifyNewSection emails that a section was added to a watched course.
+func (c Config) notifyNewSection(sender EmailSender, course string, s Section) {
+	if !c.notifying() {
+		return
+	}
+	body := T("email.new_section.body", course, s.Title, s.CRN)
+	if meeting := s.Meeting(); meeting != "" {
+		body += "\n" + meeting
+	}
+	if s.Instructor != "" {
+		body += "\n" + s.Instructor
+	}
+	if s.Seats != "" {
+		body += "\n" + T("open.seats", s.Seats)
+	}
+	c.notify(sender, sectionAlert(EventSectionAdded, s, T("email.new_section.subject", course), body))
+}
+
+// nextWatchDue returns when the next course watch is due, or the zero time if there are none.
+func nextWatchDue(watches []*courseWatch) time.Time {
+	var next time.Time
+	for _, w := range watches {
+		if next.IsZero() || w.nextCheck.Before(next) {
+			next = w.nextCheck
+		}
+	}
+	return next
+}
+
+// earliest returns the earlier of two times, ignoring zero times.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
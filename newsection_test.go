@@ -0,0 +1,74 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// course watch tests
+// ===================
+
+func TestCourseWatch_AlertsOnNewSection(t *testing.T) {
+	rows := `<tr><td>11111</td><td>CS-3114</td><td>Data Structures</td></tr>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.FormValue("subj_code") != "CS" || r.FormValue("CRSE_NUMBER") != "3114" {
+			t.Errorf("unexpected search %v", r.Form)
+		}
+		w.Write([]byte(`<table class="dataentrytable">` + rows + `</table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Term: "202601", Campus: "0", CheckInterval: 30, Email: "test@example.com", HistoryFile: filepath.Join(t.TempDir(), "history.jsonl")}
+	sender := &MockEmailSender{}
+
+	w, err := cfg.startCourseWatch("cs 3114")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Code != "CS-3114" || len(w.known) != 1 {
+		t.Fatalf("got watch %+v", w)
+	}
+
+	// nothing new yet
+	cfg.checkCourseWatch(w, sender, "12:00:00", time.Now())
+	if len(sender.Sent) != 0 {
+		t.Fatalf("expected no alert, got %+v", sender.Sent)
+	}
+
+	rows += `<tr><td>22222</td><td>CS-3114</td><td>Data Structures</td></tr>`
+	cfg.checkCourseWatch(w, sender, "12:00:30", time.Now())
+	cfg.checkCourseWatch(w, sender, "12:01:00", time.Now())
+
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "CRN: 22222") {
+		t.Errorf("expected one alert for 22222, got %+v", sender.Sent)
+	}
+	events, _ := readHistory(cfg.HistoryFile)
+	if len(events) != 1 || events[0].Event != EventSectionAdded || events[0].CRN != "22222" {
+		t.Errorf("got history %+v", events)
+	}
+}
+
+func TestLoadConfig_CoursesWithoutCRNs(t *testing.T) {
+	if _, err := loadConfig(createTempConfig(t, `{"courses": ["CS-3114"]}`)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := loadConfig(createTempConfig(t, `{"courses": ["Data Structures"]}`)); err == nil {
+		t.Error("expected error for a course that isn't a subject and number")
+	}
+}
+
+func TestEarliest(t *testing.T) {
+	now := time.Now()
+	if got := earliest(time.Time{}, now); !got.Equal(now) {
+		t.Errorf("got %v, want %v", got, now)
+	}
+	if got := earliest(now, now.Add(time.Minute)); !got.Equal(now) {
+		t.Errorf("got %v, want %v", got, now)
+	}
+}
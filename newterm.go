@@ -0,0 +1,66 @@
+package main
+
+import "time"
+
+// EventTermPublished records a term that appeared in the timetable's term dropdown
+const EventTermPublished = "term_published"
+
+// termWatch watches the timetable's term dropdown for newly published terms
+type termWatch struct {
+	known     map[string]bool // term codes listed so far
+	nextCheck time.Time
+}
+
+// startTermWatch notes the terms the timetable lists now, which later checks are compared against.
+func (c Config) startTermWatch() (*termWatch, error) {
+	options, err := c.fetchTermOptions()
+	if err != nil {
+		return nil, err
+	}
+	w := &termWatch{known: make(map[string]bool)}
+	for _, opt := range options {
+		w.known[opt.Code] = true
+	}
+	return w, nil
+}
+
+// added returns the terms not listed before, remembering them so each is reported once.
+func (w *termWatch) added(options []termOption) []termOption {
+	var added []termOption
+	for _, opt := range options {
+		if !w.known[opt.Code] {
+			w.known[opt.Code] = true
+			added = append(added, opt)
+		}
+	}
+	return added
+}
+
+// checkTermWatch reads the term dropdown again and announces any newly published term.
+// Failures are reported and the watch is retried at its next check.
+func (c Config) checkTermWatch(w *termWatch, sender EmailSender, checkTime string, now time.Time) {
+	w.nextCheck = now.Add(c.checkInterval(PriorityNormal))
+	options, err := c.fetchTermOptions()
+	if err != nil {
+		PrintCheckError(checkTime, T("watch.terms"), err)
+		return
+	}
+
+	for _, opt := range w.added(options) {
+		PrintTermPublished(opt.Name, opt.Code)
+		if c.HistoryFile != "" {
+			if err := appendHistory(c.HistoryFile, HistoryEvent{Time: now, Event: EventTermPublished, Term: opt.Code, Name: opt.Name}); err != nil {
+				PrintHistoryError(err)
+			}
+		}
+		c.notifyTermPublished(sender, opt)
+	}
+}
+
+// notifyTermPublished emails that a new term's timetable is available.
+func (c Config) notifyTermPublished(sender EmailSender, opt termOption) {
+	if !c.notifying() {
+		return
+	}
+	c.notify(sender, Alert{Event: EventTermPublished, Subject: T("email.new_term.subject", opt.Name), Body: T("email.new_term.body", opt.Name, opt.Code), Term: opt.Code})
+}
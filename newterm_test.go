@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// term watch tests
+// ===================
+
+func TestTermWatch_AlertsOnNewTerm(t *testing.T) {
+	options := `<option value="202601">Spring 2026</option>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<select name="TERMYEAR">` + options + `</select>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, CheckInterval: 30, Email: "test@example.com"}
+	sender := &MockEmailSender{}
+
+	w, err := cfg.startTermWatch()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg.checkTermWatch(w, sender, "12:00:00", time.Now())
+	if len(sender.Sent) != 0 {
+		t.Fatalf("expected no alert, got %+v", sender.Sent)
+	}
+
+	options += `<option value="202609">Fall
+		2026</option>`
+	cfg.checkTermWatch(w, sender, "12:00:30", time.Now())
+	cfg.checkTermWatch(w, sender, "12:01:00", time.Now())
+
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Subject, "Fall 2026") {
+		t.Errorf("expected one alert for Fall 2026, got %+v", sender.Sent)
+	}
+}
+
+func TestLoadConfig_WatchTermsOnly(t *testing.T) {
+	if _, err := loadConfig(createTempConfig(t, `{"watchTerms": true}`)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Alerts that aren't recorded in the history file
+const (
+	AlertStarted   = "started"   // monitoring started, see notifyOnStart
+	AlertSummary   = "summary"   // the weekly activity summary
+	AlertChallenge = "challenge" // the timetable is showing a bot check
+	AlertHint      = "hint"      // a section may open soon
+	AlertSlowdown  = "slowdown"  // timetable searches are much slower than usual
+	AlertFailing   = "failing"   // checks of a section keep failing
+)
+
+// failingAfter is how many checks of a section in a row must fail before the
+// failures are alerted, so a passing network blip doesn't page anyone
+const failingAfter = 5
+
+// Alert is one notification. Subject and Body are the rendered message; the rest
+// are the details behind it, for channels that format their own message. Course
+// fields are empty for alerts that aren't about one section.
+type Alert struct {
+	Event      string `json:"event"` // what happened, e.g. EventOpen or AlertStarted
+	Subject    string `json:"subject"`
+	Body       string `json:"body"`
+	CRN        string `json:"crn,omitempty"`
+	Name       string `json:"name,omitempty"`   // course title
+	Course     string `json:"course,omitempty"` // subject and number, e.g. "CS-3114"
+	Term       string `json:"term,omitempty"`
+	Instructor string `json:"instructor,omitempty"`
+	Seats      string `json:"seats,omitempty"` // open seats, when known
+}
+
+// courseAlert returns an alert about a watched section.
+func (c Config) courseAlert(event string, course CourseStatus, subject, body string) Alert {
+	return Alert{
+		Event: event, Subject: subject, Body: body,
+		CRN: course.CRN, Name: course.Name, Course: course.Course, Term: c.termOf(course), Instructor: course.Instructor,
+	}
+}
+
+// sectionAlert returns an alert about a section found in the timetable.
+func sectionAlert(event string, s Section, subject, body string) Alert {
+	return Alert{
+		Event: event, Subject: subject, Body: body,
+		CRN: s.CRN, Name: s.Title, Course: s.Course, Term: s.Term, Instructor: s.Instructor, Seats: s.Seats,
+	}
+}
+
+// Notifier delivers alerts over a channel other than email, like an SNS topic.
+// Every alert that is emailed also goes to each configured notifier.
+type Notifier interface {
+	Name() string // channel name shown when an alert is sent, e.g. "SNS"
+	Notify(a Alert) error
+}
+
+// newNotifiers sets up the configured notification channels besides email.
+// Channels that deliver by email, like SMS gateways, send through sender.
+func (c Config) newNotifiers(sender EmailSender) ([]Notifier, error) {
+	var notifiers []Notifier
+	if c.SNS.TopicARN != "" {
+		n, err := c.SNS.newNotifier()
+		if err != nil {
+			return nil, fmt.Errorf("sns: %w", err)
+		}
+		notifiers = append(notifiers, n)
+	}
+	if c.SMSGateway.Number != "" {
+		notifiers = append(notifiers, &smsGatewayNotifier{sender: sender, to: c.SMSGateway.address()})
+	}
+	if len(c.WhatsApp.To) > 0 {
+		n, err := c.WhatsApp.newNotifier()
+		if err != nil {
+			return nil, fmt.Errorf("whatsapp: %w", err)
+		}
+		notifiers = append(notifiers, n)
+	}
+	if len(c.TwilioSMS.To) > 0 {
+		n, err := c.TwilioSMS.newNotifier()
+		if err != nil {
+			return nil, fmt.Errorf("twilioSms: %w", err)
+		}
+		notifiers = append(notifiers, n)
+	}
+	if c.Signal.URL != "" {
+		notifiers = append(notifiers, &signalNotifier{Signal: c.Signal})
+	}
+	if c.Bark.DeviceKey != "" {
+		notifiers = append(notifiers, &barkNotifier{Bark: c.Bark})
+	}
+	if c.Slack.WebhookURL != "" {
+		notifiers = append(notifiers, &slackNotifier{Slack: c.Slack})
+	}
+	if c.Ntfy.Topic != "" {
+		notifiers = append(notifiers, c.Ntfy.newNotifier())
+	}
+	for _, hook := range c.Webhooks {
+		notifiers = append(notifiers, &webhookNotifier{Webhook: hook})
+	}
+	for _, cmd := range c.NotifyCommands {
+		notifiers = append(notifiers, &cmd)
+	}
+	return notifiers, nil
+}
+
+// notifying reports whether alerts go anywhere: to an email address or another channel.
+func (c Config) notifying() bool {
+	return len(c.recipients()) > 0 || len(c.notifiers) > 0
+}
+
+// channels names where alerts go, for the startup summary.
+func (c Config) channels() []string {
+	var names []string
+	if len(c.recipients()) > 0 {
+		names = append(names, "email")
+	}
+	for _, n := range c.notifiers {
+		names = append(names, n.Name())
+	}
+	return names
+}
+
+// notify sends an alert by email, if an address is configured, and over every other
+// configured channel. Each channel's outcome is shown, and one channel failing
+// doesn't keep the alert from the others. Channels skip alerts below their minimum
+// severity, and any that would put them over their rate limit. Notify commands
+// that retry run in the background, and their outcome is shown when they finish.
+func (c Config) notify(sender EmailSender, a Alert) {
+	severity := a.severity()
+	c, span := c.startSpan("notify", attribute.String("event", a.Event), attribute.String("crn", a.CRN), attribute.String("severity", string(severity)))
+	defer span.End()
+
+	now := time.Now()
+	if to := c.recipients(); len(to) > 0 && c.wants("email", severity) && c.withinRateLimit("email", a.CRN, now) {
+		err := sender.Send(EmailMessage{To: to, CC: c.EmailCC, Subject: a.Subject, Body: a.Body})
+		c.recordDelivery(span, a, "email", strings.Join(to, ", "), err)
+		if err != nil {
+			PrintEmailFailed(err)
+		} else {
+			PrintEmailSent(strings.Join(to, ", "))
+		}
+	}
+	for _, n := range c.notifiers {
+		if !c.wants(n.Name(), severity) || !c.withinRateLimit(n.Name(), a.CRN, now) {
+			continue
+		}
+		// a failing command's retries and their delays can take minutes, so they run
+		// alongside the watch loop instead of holding it and the other channels up.
+		// The notify span has ended by the time they finish, so each gets its own.
+		if cmd, ok := n.(*NotifyCommand); ok && cmd.Retries > 0 {
+			cmd.inBackground(func() {
+				c, span := c.startSpan("notify.command", attribute.String("channel", n.Name()))
+				defer span.End()
+				c.deliver(span, a, n)
+			})
+			continue
+		}
+		c.deliver(span, a, n)
+	}
+}
+
+// deliver sends an alert over one channel other than email and records the outcome.
+func (c Config) deliver(span trace.Span, a Alert, n Notifier) {
+	err := n.Notify(a)
+	c.recordDelivery(span, a, n.Name(), notifierRecipient(n), err)
+	if err != nil {
+		PrintNotifyFailed(n.Name(), err)
+	} else {
+		PrintNotified(n.Name())
+	}
+}
+
+// withinRateLimit reports whether a channel may send an alert about crn, showing
+// that the alert was held back if not.
+func (c Config) withinRateLimit(channel, crn string, now time.Time) bool {
+	if c.limiter.allow(channel, crn, now) {
+		return true
+	}
+	PrintNotifyLimited(channel)
+	c.recordNotifyLimited(channel)
+	c.report.limited(channel)
+	return false
+}
+
+// recordDelivery notes one channel's outcome on the notify span, in the notification
+// metrics, and in the audit log.
+func (c Config) recordDelivery(span trace.Span, a Alert, channel, recipient string, err error) {
+	if err != nil {
+		span.RecordError(err, trace.WithAttributes(attribute.String("channel", channel)))
+	}
+	c.recordNotify(channel, err)
+	c.report.delivered(channel, err)
+	c.auditDelivery(a, channel, recipient, err)
+}
+
+// checkFailed counts a failed check of a section, sending a warning once
+// failingAfter have failed in a row, since OpenSeat can't see it open until they
+// succeed again. The count is reset when a check succeeds.
+func (c Config) checkFailed(sender EmailSender, course *CourseStatus, err error) {
+	course.failures++
+	if course.failures != failingAfter || !c.notifying() {
+		return
+	}
+	subject := T("email.failing.subject", course.CRN)
+	body := T("email.failing.body", failingAfter, course.Name, course.CRN, err)
+	c.notify(sender, c.courseAlert(AlertFailing, *course, subject, body))
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// MockNotifier records alerts instead of sending them
+type MockNotifier struct {
+	Sent        []string // subjects
+	ShouldError bool
+}
+
+func (m *MockNotifier) Name() string { return "Mock" }
+
+func (m *MockNotifier) Notify(a Alert) error {
+	if m.ShouldError {
+		return errors.New("mock notifier error")
+	}
+	m.Sent = append(m.Sent, a.Subject)
+	return nil
+}
+
+// ===================
+// notify tests
+// ===================
+
+func TestNotify_EveryChannel(t *testing.T) {
+	notifier := &MockNotifier{}
+	cfg := Config{Email: "test@example.com", notifiers: []Notifier{notifier}}
+	sender := &MockEmailSender{}
+
+	cfg.notify(sender, Alert{Subject: "Subject", Body: "Body"})
+
+	if len(sender.Sent) != 1 || len(notifier.Sent) != 1 {
+		t.Errorf("expected one email and one notification, got %d and %d", len(sender.Sent), len(notifier.Sent))
+	}
+}
+
+func TestNotify_EveryRecipient(t *testing.T) {
+	cfg := Config{Email: "me@vt.edu", Emails: []string{"friend@vt.edu"}, EmailCC: []string{"advisor@vt.edu"}}
+	sender := &MockEmailSender{}
+
+	cfg.notify(sender, Alert{Subject: "Subject", Body: "Body"})
+
+	if len(sender.Sent) != 1 {
+		t.Fatalf("expected one email to the group, got %d", len(sender.Sent))
+	}
+	sent := sender.Sent[0]
+	if strings.Join(sent.To, ",") != "me@vt.edu,friend@vt.edu" || strings.Join(sent.CC, ",") != "advisor@vt.edu" {
+		t.Errorf("unexpected recipients: to %v, cc %v", sent.To, sent.CC)
+	}
+}
+
+func TestNotify_WithoutEmail(t *testing.T) {
+	notifier := &MockNotifier{}
+	cfg := Config{notifiers: []Notifier{notifier}}
+	sender := &MockEmailSender{}
+
+	cfg.notify(sender, Alert{Subject: "Subject", Body: "Body"})
+
+	if len(sender.Sent) != 0 {
+		t.Errorf("expected no email without an address, got %d", len(sender.Sent))
+	}
+	if len(notifier.Sent) != 1 {
+		t.Errorf("expected one notification, got %d", len(notifier.Sent))
+	}
+}
+
+func TestNotify_FailedChannelDoesntStopOthers(t *testing.T) {
+	failing, working := &MockNotifier{ShouldError: true}, &MockNotifier{}
+	cfg := Config{Email: "test@example.com", notifiers: []Notifier{failing, working}}
+
+	cfg.notify(&MockEmailSender{ShouldError: true}, Alert{Subject: "Subject", Body: "Body"})
+
+	if len(working.Sent) != 1 {
+		t.Errorf("expected the working channel to get the alert, got %d", len(working.Sent))
+	}
+}
+
+func TestNotifying(t *testing.T) {
+	if (Config{}).notifying() {
+		t.Error("expected no alerts without any channel")
+	}
+	if !(Config{Email: "test@example.com"}).notifying() {
+		t.Error("expected alerts with an email address")
+	}
+	if !(Config{notifiers: []Notifier{&MockNotifier{}}}).notifying() {
+		t.Error("expected alerts with a notifier")
+	}
+}
+
+func TestNotifyOpen_WithoutEmail(t *testing.T) {
+	notifier := &MockNotifier{}
+	cfg := Config{Term: "202601", notifiers: []Notifier{notifier}}
+
+	cfg.notifyOpen(newMemoryStateStore(), &MockEmailSender{}, CourseStatus{CRN: "12345", Name: "Intro to Testing"})
+
+	if len(notifier.Sent) != 1 {
+		t.Errorf("expected the open alert without an email address, got %d", len(notifier.Sent))
+	}
+}
+
+func TestNotifyStarted_ListsChannels(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601", NotifyOnStart: true, notifiers: []Notifier{&MockNotifier{}}}
+	sender := &MockEmailSender{}
+
+	cfg.notifyStarted(sender, []CourseStatus{{CRN: "12345", Name: "Intro to Testing"}})
+
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "channels: email, Mock") {
+		t.Errorf("expected every channel in the summary, got %+v", sender.Sent)
+	}
+}
+
+func TestCheckFailed_WarnsOnceUntilACheckSucceeds(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601", MinSeverity: map[string]string{"email": "warning"}}
+	sender := &MockEmailSender{}
+	course := CourseStatus{CRN: "12345", Name: "Intro to Testing"}
+	err := errors.New("connection reset")
+
+	for range failingAfter - 1 {
+		cfg.checkFailed(sender, &course, err)
+	}
+	if len(sender.Sent) != 0 {
+		t.Fatalf("expected no alert before %d failures, got %+v", failingAfter, sender.Sent)
+	}
+	cfg.checkFailed(sender, &course, err)
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Subject, "12345") || !strings.Contains(sender.Sent[0].Body, "connection reset") {
+		t.Fatalf("expected a warning naming the CRN and the error, got %+v", sender.Sent)
+	}
+	cfg.checkFailed(sender, &course, err)
+	if len(sender.Sent) != 1 {
+		t.Errorf("expected one alert for a run of failures, got %d", len(sender.Sent))
+	}
+
+	course.failures = 0 // a check succeeded
+	for range failingAfter {
+		cfg.checkFailed(sender, &course, err)
+	}
+	if len(sender.Sent) != 2 {
+		t.Errorf("expected a new run of failures alerted again, got %d", len(sender.Sent))
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DefaultNtfyServer is the public ntfy server
+const DefaultNtfyServer = "https://ntfy.sh"
+
+// ntfyTopicPattern matches the topic names ntfy accepts
+var ntfyTopicPattern = regexp.MustCompile(`^[-_A-Za-z0-9]{1,64}$`)
+
+// ntfyPriorities maps alert severities to ntfy's priorities, so seat openings
+// ring through Do Not Disturb on phones that allow urgent notifications
+var ntfyPriorities = map[Severity]int{SeverityCritical: 5, SeverityWarning: 4, SeverityInfo: 3}
+
+// ntfyTags adds an emoji to alerts that matter, by severity
+var ntfyTags = map[Severity][]string{SeverityCritical: {"rotating_light"}, SeverityWarning: {"warning"}}
+
+// Ntfy configures push alerts published to an ntfy topic (ntfy.sh), which
+// phones subscribe to in the ntfy app without an account. Servers that
+// require a login take an access token from NTFY_TOKEN.
+type Ntfy struct {
+	Server string `json:"server"` // ntfy server (defaults to https://ntfy.sh)
+	Topic  string `json:"topic"`  // topic to publish to; anyone who knows it can subscribe, so make it hard to guess
+}
+
+// applyDefaults fills in the server and checks it and the topic.
+func (n *Ntfy) applyDefaults() error {
+	if n.Topic == "" {
+		if n.Server != "" {
+			return fmt.Errorf("ntfy: topic is required")
+		}
+		return nil
+	}
+	if !ntfyTopicPattern.MatchString(n.Topic) {
+		return fmt.Errorf("invalid ntfy topic %q: use up to 64 letters, digits, - and _", n.Topic)
+	}
+	if n.Server == "" {
+		n.Server = DefaultNtfyServer
+	}
+	u, err := url.Parse(n.Server)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid ntfy server %q: expected a URL like %s", n.Server, DefaultNtfyServer)
+	}
+	n.Server = strings.TrimSuffix(n.Server, "/")
+	return nil
+}
+
+// ntfyNotifier publishes alerts to an ntfy topic
+type ntfyNotifier struct {
+	Ntfy
+	token string // access token, for servers that require a login
+}
+
+// newNotifier reads the optional access token from the environment.
+func (n Ntfy) newNotifier() *ntfyNotifier {
+	return &ntfyNotifier{Ntfy: n, token: os.Getenv("NTFY_TOKEN")}
+}
+
+func (n *ntfyNotifier) Name() string { return "ntfy" }
+
+// Notify publishes the alert with a priority and tag for its severity.
+func (n *ntfyNotifier) Notify(a Alert) error {
+	severity := a.severity()
+	payload, err := json.Marshal(struct {
+		Topic    string   `json:"topic"`
+		Title    string   `json:"title"`
+		Message  string   `json:"message"`
+		Priority int      `json:"priority"`
+		Tags     []string `json:"tags,omitempty"`
+	}{n.Topic, a.Subject, a.Body, ntfyPriorities[severity], ntfyTags[severity]})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.Server, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if n.token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+	}
+	return sendAPIRequest("ntfy", req)
+}
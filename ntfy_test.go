@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ntfyMessage is what the ntfy server receives
+type ntfyMessage struct {
+	Topic    string   `json:"topic"`
+	Title    string   `json:"title"`
+	Message  string   `json:"message"`
+	Priority int      `json:"priority"`
+	Tags     []string `json:"tags"`
+
+	auth string // the Authorization header
+}
+
+// ntfyServer records every message published to it
+func ntfyServer(t *testing.T) (string, *[]ntfyMessage) {
+	var messages []ntfyMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m ntfyMessage
+		json.NewDecoder(r.Body).Decode(&m)
+		m.auth = r.Header.Get("Authorization")
+		messages = append(messages, m)
+		w.Write([]byte(`{"id":"abc","event":"message"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &messages
+}
+
+// ===================
+// ntfy config tests
+// ===================
+
+func TestNtfyApplyDefaults(t *testing.T) {
+	n := Ntfy{Topic: "openseat-x7q2"}
+	if err := n.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n.Server != DefaultNtfyServer {
+		t.Errorf("Server = %q, want %q", n.Server, DefaultNtfyServer)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidNtfy(t *testing.T) {
+	for _, ntfy := range []string{
+		`{"server": "https://ntfy.example.com"}`,
+		`{"topic": "seats/cs"}`,
+		`{"topic": "openseat", "server": "ntfy.example.com"}`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], "ntfy": `+ntfy+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", ntfy)
+		}
+	}
+}
+
+// ===================
+// ntfyNotifier tests
+// ===================
+
+func TestNtfyNotify_PriorityBySeverity(t *testing.T) {
+	server, messages := ntfyServer(t)
+	n := &ntfyNotifier{Ntfy: Ntfy{Server: server, Topic: "openseat-x7q2"}}
+
+	if err := n.Notify(Alert{Event: EventOpen, Subject: "VT Course Section Open!", Body: "OPEN SEAT: Intro to Testing"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := n.Notify(Alert{Event: AlertSummary, Subject: "Weekly summary"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*messages) != 2 {
+		t.Fatalf("expected two messages, got %d", len(*messages))
+	}
+	open := (*messages)[0]
+	if open.Topic != "openseat-x7q2" || open.Title != "VT Course Section Open!" || open.Priority != 5 || len(open.Tags) != 1 || open.auth != "" {
+		t.Errorf("expected an urgent opening without a login, got %+v", open)
+	}
+	if summary := (*messages)[1]; summary.Priority != 3 || len(summary.Tags) != 0 {
+		t.Errorf("expected a normal priority summary, got %+v", summary)
+	}
+}
+
+func TestNtfyNotify_Token(t *testing.T) {
+	server, messages := ntfyServer(t)
+	t.Setenv("NTFY_TOKEN", "tk_secret")
+
+	if err := (Ntfy{Server: server, Topic: "openseat"}).newNotifier().Notify(Alert{Subject: "Seat open"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := (*messages)[0].auth; got != "Bearer tk_secret" {
+		t.Errorf("expected the token sent, got %q", got)
+	}
+}
+
+func TestNtfyNotify_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"code":40301,"error":"forbidden"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := (&ntfyNotifier{Ntfy: Ntfy{Server: server.URL, Topic: "openseat"}}).Notify(Alert{Subject: "Seat open"})
+	if err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Errorf("expected ntfy's error, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// subjectPattern matches a subject code such as "CS" or "STAT"
+var subjectPattern = regexp.MustCompile(`^[A-Z]{2,5}$`)
+
+// runOpen lists every section with open seats in a subject.
+func runOpen(args []string) error {
+	fs := flag.NewFlagSet("open", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file` (optional, for term and campus)")
+	subject := fs.String("subject", "", "subject `code` to search, e.g. STAT")
+	level := fs.String("level", "", "only list undergrad or grad sections (overrides the config's level)")
+	fs.Parse(args)
+
+	subj := strings.ToUpper(strings.TrimSpace(*subject))
+	if !subjectPattern.MatchString(subj) {
+		return configError(fmt.Errorf("--subject must be a subject code like STAT, got %q", *subject))
+	}
+
+	cfg, err := loadSearchConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+	if *level != "" {
+		if err := validateLevel(*level); err != nil {
+			return configError(fmt.Errorf("--level: %w", err))
+		}
+		cfg.Level = *level
+	}
+	if err := cfg.resolveAutoTerms(time.Now()); err != nil {
+		return err
+	}
+	if err := cfg.resolveCampusNames(); err != nil {
+		return err
+	}
+
+	sections, err := cfg.findOpenSections(subj)
+	cfg.grades.annotate(sections)
+	cfg.RateMyProfessors.annotate(sections)
+	PrintOpenSections(subj, strings.Join(cfg.termList(), ", "), sections)
+	return err
+}
+
+// findOpenSections searches every term/campus variant for sections of the subject
+// with open seats at the configured level, sorted by course number and then CRN.
+func (c Config) findOpenSections(subject string) ([]Section, error) {
+	sections, err := c.findSections(searchQuery{Subject: subject, OpenOnly: true}, "subject "+subject)
+	return filterLevel(sections, c.Level), err
+}
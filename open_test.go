@@ -0,0 +1,126 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// ===================
+// findOpenSections tests
+// ===================
+
+func TestFindOpenSections_SortedByCourse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.FormValue("subj_code") != "STAT" || r.FormValue("open_only") != "on" || r.FormValue("crn") != "" {
+			t.Errorf("unexpected search: %v", r.Form)
+		}
+		w.Write([]byte(`
+			<table class="dataentrytable">
+				<tr><td>CRN</td><td>Course</td><td>Title</td></tr>
+				<tr><td>22222</td><td>STAT-4705</td><td>Probability and Statistics for Engineers</td></tr>
+				<tr><td>11111</td><td>STAT-3005</td><td>Statistical Methods</td></tr>
+				<tr><td>33333</td><td>STAT-3005</td><td>Statistical Methods</td></tr>
+			</table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601"}
+	sections, err := cfg.findOpenSections("STAT")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"11111", "33333", "22222"}
+	if len(sections) != len(want) {
+		t.Fatalf("got %d sections, want %d", len(sections), len(want))
+	}
+	for i, crn := range want {
+		if sections[i].CRN != crn {
+			t.Errorf("sections[%d].CRN = %s, want %s", i, sections[i].CRN, crn)
+		}
+	}
+}
+
+func TestFindOpenSections_Level(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`
+			<table class="dataentrytable">
+				<tr><td>11111</td><td>CS-4114</td><td>Introduction to Parallel Computation</td></tr>
+				<tr><td>22222</td><td>CS-5114</td><td>Theory of Algorithms</td></tr>
+			</table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", Level: LevelUndergrad}
+	sections, err := cfg.findOpenSections("CS")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sections) != 1 || sections[0].CRN != "11111" {
+		t.Errorf("expected only the 4000-level section, got %+v", sections)
+	}
+}
+
+func TestFindOpenSections_MergesVariants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		// both campuses list the same section
+		w.Write([]byte(`<table class="dataentrytable"><tr><td>11111</td><td>STAT-3005</td><td>Statistical Methods</td></tr></table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Term: "202601", Campuses: []string{"0", "10"}}
+	sections, err := cfg.findOpenSections("STAT")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sections) != 1 {
+		t.Errorf("expected duplicate sections merged, got %d", len(sections))
+	}
+}
+
+func TestFindOpenSections_NoneOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><b>NO SECTIONS FOUND FOR THIS INQUIRY.</b></html>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601"}
+	sections, err := cfg.findOpenSections("STAT")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sections) != 0 {
+		t.Errorf("expected no sections, got %d", len(sections))
+	}
+}
+
+// ===================
+// loadSearchConfig tests
+// ===================
+
+func TestLoadSearchConfig_MissingFileUsesDefaults(t *testing.T) {
+	cfg, err := loadSearchConfig("/nonexistent/config.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Term != "202601" || cfg.Campus != "0" {
+		t.Errorf("expected defaults, got term %q campus %q", cfg.Term, cfg.Campus)
+	}
+}
+
+func TestLoadSearchConfig_NoCRNsNeeded(t *testing.T) {
+	path := createTempConfig(t, `{"term": "202609"}`)
+	defer os.Remove(path)
+
+	cfg, err := loadSearchConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Term != "202609" {
+		t.Errorf("Term = %q, want %q", cfg.Term, "202609")
+	}
+}
@@ -3,16 +3,22 @@
 package main
 
 import (
+	"cmp"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
-	"github.com/resend/resend-go/v2"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Note: strings is still used by checkSectionOpen and getCourseName
@@ -20,35 +26,27 @@ import (
 // DefaultTimetableURL is the Virginia Tech timetable endpoint for course searches
 const DefaultTimetableURL = "https://selfservice.banner.vt.edu/ssb/HZSKVTSC.P_ProcRequest"
 
+// DefaultRequestTimeout bounds every timetable request when no timeout is configured
+const DefaultRequestTimeout = 20 * time.Second
+
+// defaultHTTPClient is used by configs that were not built through loadConfig
+var defaultHTTPClient = &http.Client{Timeout: DefaultRequestTimeout}
+
 // ===================================
 // Interfaces for dependency injection
 // ===================================
 
-// EmailSender abstracts email sending for testability
-type EmailSender interface {
-	Send(to, subject, body string) error
-}
-
-// ResendEmailSender is the production implementation using Resend API
-type ResendEmailSender struct {
-	APIKey string
+// EmailMessage is one plain-text email
+type EmailMessage struct {
+	To      []string
+	CC      []string // copied recipients (optional)
+	Subject string
+	Body    string
 }
 
-func (r *ResendEmailSender) Send(to, subject, body string) error {
-	if r.APIKey == "" {
-		return fmt.Errorf("RESEND_API_KEY not set")
-	}
-
-	client := resend.NewClient(r.APIKey)
-	params := &resend.SendEmailRequest{
-		From:    "onboarding@resend.dev",
-		To:      []string{to},
-		Subject: subject,
-		Text:    body,
-	}
-
-	_, err := client.Emails.Send(params)
-	return err
+// EmailSender abstracts email sending for testability
+type EmailSender interface {
+	Send(m EmailMessage) error
 }
 
 // ==================================
@@ -59,19 +57,134 @@ func (r *ResendEmailSender) Send(to, subject, body string) error {
 type Config struct {
 	CRNs          []string `json:"crns"`          // Course Reference Number(s) to monitor
 	Email         string   `json:"email"`         // Email address for notifications (optional)
+	Emails        []string `json:"emails"`        // More addresses the alert is sent to, e.g. a group sharing a backup plan (optional)
+	EmailCC       []string `json:"emailCc"`       // Addresses copied on every alert (optional)
 	CheckInterval int      `json:"checkInterval"` // Time between availability checks
-	Term          string   `json:"term"`          // Term code (e.g., 202601 = Spring 2026)
+	Term          string   `json:"term"`          // Term code (e.g., 202601 = Spring 2026, or "auto")
+	Terms         []string `json:"terms"`         // Term codes to search in parallel (optional, overrides term)
 	Campus        string   `json:"campus"`        // Campus code (0 = Blacksburg)
+	Campuses      []string `json:"campuses"`      // Campus codes to search in parallel (optional, overrides campus)
+	SessionCode   string   `json:"sessionCode"`   // Session within the term, e.g. summer I vs summer II (defaults to "%", every session)
 	BaseURL       string   `json:"baseUrl"`       // Timetable URL (optional, for testability) (defaults to timetable url)
+	DebugDir      string   `json:"debugDir"`      // Where unparseable responses are saved (defaults to a temp dir)
+	RecordDir     string   `json:"recordDir"`     // Record every timetable response to this directory (optional)
+	ReplayDir     string   `json:"replayDir"`     // Serve recorded responses from this directory instead of the network (optional)
+
+	RequestTimeoutSeconds int               `json:"requestTimeoutSeconds"` // Max time for a single timetable request (defaults to 20)
+	FormOverrides         map[string]string `json:"formOverrides"`         // Extra or replacement search form fields (optional)
+	Browser               string            `json:"browser"`               // Browser whose headers timetable requests imitate: "chrome", "firefox", or "safari" (optional)
+	Headers               map[string]string `json:"headers"`               // Extra headers for timetable requests, e.g. "Referer" or "Cookie" (optional)
+	StateBackend          string            `json:"stateBackend"`          // Shared state for redundant instances: "memory" (default), "file", or "redis"
+	StateDir              string            `json:"stateDir"`              // Shared directory for the "file" state backend
+	RedisURL              string            `json:"redisUrl"`              // Server for the "redis" state backend, e.g. redis://localhost:6379/0
+	DedupeWindowMinutes   int               `json:"dedupeWindowMinutes"`   // How long an alert claim blocks duplicates (defaults to 10)
+	Priorities            map[string]string `json:"priorities"`            // Priority tier per CRN: "high", "normal" (default), or "low"
+	CampusOverrides       map[string]string `json:"campusOverrides"`       // Campus code or name per CRN, e.g. "Virtual" for an online section (optional)
+	TermOverrides         map[string]string `json:"termOverrides"`         // Term code (or "auto") per CRN, for watching several terms at once (optional)
+	BurstMinutes          int               `json:"burstMinutes"`          // How long a SIGUSR1-triggered burst of fast checks lasts (defaults to 10)
+	Calendar              Calendar          `json:"calendar"`              // Registration and drop/add dates for the watched term (optional)
+	Locale                string            `json:"locale"`                // Language for terminal output and notifications (defaults to $LANG, then "en")
+	Accessible            bool              `json:"accessible"`            // Plain line-oriented output for screen readers
+	Theme                 string            `json:"theme"`                 // Terminal color theme: "vt" (default), "colorblind", or "mono"
+	HideBanner            bool              `json:"hideBanner"`            // Never show the ASCII art banner
+	BannerFile            string            `json:"bannerFile"`            // Text file with a banner to show instead of the built-in one
+	HideIcons             bool              `json:"hideIcons"`             // Leave out the Nerd Font icons
+	AlertSound            AlertSound        `json:"alertSound"`            // Sound file played when a seat opens (optional)
+	AfterOpen             string            `json:"afterOpen"`             // What to do once a section opens: "stop" (default), "keep", or "slow"
+	HistoryFile           string            `json:"historyFile"`           // Where openings and enrollments are recorded (defaults to history.jsonl next to the config)
+	HistoryRetention      HistoryRetention  `json:"historyRetention"`      // How long and how large the history file may grow before it's pruned (optional)
+	AuditFile             string            `json:"auditFile"`             // Where notifications and watch changes are logged (defaults to audit.jsonl next to the config)
+	GradesFile            string            `json:"gradesFile"`            // CSV of historical grade distributions, for average GPA by instructor (optional)
+	RateMyProfessors      RateMyProfessors  `json:"rateMyProfessors"`      // Instructor rating lookups (optional)
+	Courses               []string          `json:"courses"`               // Courses to watch for newly added sections, e.g. "CS-3114" (optional)
+	Level                 string            `json:"level"`                 // Only consider "undergrad" or "grad" sections in course and subject searches (optional)
+	WatchTerms            bool              `json:"watchTerms"`            // Alert when a new term's timetable is published
+	ObserveMinutes        int               `json:"observeMinutes"`        // How often seat counts are recorded for likelihood hints (defaults to 15)
+	NotifyHints           bool              `json:"notifyHints"`           // Email strong hints that a section may open soon, like a capacity increase
+	NotifyOnStart         bool              `json:"notifyOnStart"`         // Email a summary when monitoring starts, confirming the watcher is up
+	WeeklySummary         bool              `json:"weeklySummary"`         // Email a weekly summary of checks, openings, and errors
+	Backend               string            `json:"backend"`               // Where section data comes from: "html" (default) or "banner9"
+	Banner9URL            string            `json:"banner9Url"`            // Banner 9 StudentRegistrationSsb base URL, for the "banner9" backend
+	Telemetry             Telemetry         `json:"telemetry"`             // OpenTelemetry trace and metric export (optional)
+	ErrorReporting        ErrorReporting    `json:"errorReporting"`        // Opt-in crash and error reports to Sentry (optional)
+	StatusFile            string            `json:"statusFile"`            // Where a JSON snapshot of the watch is kept for status bars and scripts (optional)
+	SNS                   SNS               `json:"sns"`                   // AWS SNS topic alerts are also published to (optional)
+	EmailProvider         string            `json:"emailProvider"`         // Email service: "resend" (default), "sendgrid", "mailgun", or "ses"
+	EmailFrom             string            `json:"emailFrom"`             // Sender address (defaults to openseat@ emailDomain, or onboarding@resend.dev)
+	EmailFromName         string            `json:"emailFromName"`         // Display name alerts are sent under (defaults to "OpenSeat")
+	EmailReplyTo          string            `json:"emailReplyTo"`          // Where replies to alerts go (optional)
+	EmailDomain           string            `json:"emailDomain"`           // Sending domain verified with the email provider (optional)
+	MailgunDomain         string            `json:"mailgunDomain"`         // Sending domain for the "mailgun" provider
+	MailgunRegion         string            `json:"mailgunRegion"`         // Region of the Mailgun domain: "us" (default) or "eu"
+	SESRegion             string            `json:"sesRegion"`             // AWS region for the "ses" provider (defaults to the AWS environment's)
+	SMSGateway            SMSGateway        `json:"smsGateway"`            // Phone texted through its carrier's email-to-SMS gateway (optional)
+	NotifyCommands        []NotifyCommand   `json:"notifyCommands"`        // External programs run with each alert as JSON on stdin (optional)
+	WhatsApp              WhatsApp          `json:"whatsapp"`              // WhatsApp numbers messaged through Twilio (optional)
+	TwilioSMS             TwilioSMS         `json:"twilioSms"`             // Phones texted through Twilio (optional)
+	Signal                Signal            `json:"signal"`                // Signal recipients messaged through a signal-cli REST API server (optional)
+	Bark                  Bark              `json:"bark"`                  // iPhone push alerts through the Bark app (optional)
+	Slack                 Slack             `json:"slack"`                 // Slack channel posted to through an incoming webhook (optional)
+	Ntfy                  Ntfy              `json:"ntfy"`                  // Push alerts published to an ntfy topic (optional)
+	Webhooks              []Webhook         `json:"webhooks"`              // URLs each alert is POSTed to as JSON (optional)
+	RateLimits            ChannelLimits     `json:"rateLimits"`            // Most alerts each channel sends in a window, e.g. "sms" (optional)
+	MinSeverity           map[string]string `json:"minSeverity"`           // Least severe alert each channel gets: "info" (default), "warning", or "critical"
+	Slowdown              Slowdown          `json:"slowdown"`              // Alert when timetable searches get much slower than usual
+	IPVersion             string            `json:"ipVersion"`             // IP version for timetable requests: "4" or "6" (defaults to either)
+	DNS                   DNS               `json:"dns"`                   // Resolver and address caching for timetable requests (optional)
+	ReportFile            string            `json:"reportFile"`            // Where a JSON report is written when the watcher stops, "-" for stdout (optional)
+	Groups                []WatchGroup      `json:"groups"`                // Sets of CRNs only alerted when all are open at once, e.g. a lecture and its lab (optional)
+	Schedule              []string          `json:"schedule"`              // CRNs you're enrolled in, whose meeting times an open section shouldn't clash with (optional)
+	BusyTimes             []string          `json:"busyTimes"`             // Other weekly commitments, e.g. "T R 2:00PM-3:15PM" (optional)
+	Conflicts             string            `json:"conflicts"`             // What to do when an open section clashes with the schedule: "flag" (default) or "suppress"
+	Preferences           TimePreferences   `json:"preferences"`           // When you'd rather have class, for suggesting which open alternatives to take (optional)
+	TargetSchedule        []string          `json:"targetSchedule"`        // CRNs and group names alerted on again once all are open at the same time (optional)
+	CacheSeconds          int               `json:"cacheSeconds"`          // How long an identical timetable search reuses the last response (defaults to 5, -1 for never)
+	MaxRequestsPerMinute  int               `json:"maxRequestsPerMinute"`  // Most searches a minute sent to the timetable (optional)
+	Hosts                 []TimetableHost   `json:"hosts"`                 // Other schools' timetables to watch CRNs on, e.g. for a transfer student (optional)
+	Server                AgentServer       `json:"server"`                // Where `openseat server` listens for agents and the token they send (optional)
+
+	session       *session        // shared HTTP client and cookies for all timetable requests
+	traceCtx      context.Context // current trace span, see startSpan
+	grades        *gradeBook      // loaded from gradesFile, nil when not configured
+	notifiers     []Notifier      // alert channels besides email, see newNotifiers
+	limiter       *rateLimiter    // enforces rateLimits, nil when there are none
+	latency       *latencyTracker // search times for spotting slowdowns, nil when off
+	report        *runReport      // collects the exit report, nil when none is wanted
+	busy          []busyTime      // meeting times from schedule and busyTimes, see loadSchedule
+	duplicateCRNs []string        // CRNs listed more than once, reported once at startup
 }
 
 type CourseStatus struct {
-	CRN   string
-	Name  string
-	Found bool
+	CRN        string
+	Name       string
+	Course     string // subject and number, e.g. "CS-3114"
+	Term       string // term code the section was found in
+	Host       string // name of the other timetable it's watched on, "" for the main one
+	Instructor string
+	Meeting    string // days and times, e.g. "M W F 10:10AM-11:00AM"
+	Location   string
+	Found      bool // opened, and no longer watched
+	Open       bool // open as of the last check
+	Priority   Priority
+	Hint       hint // sign that the section may open soon
+
+	nextCheck   time.Time // when the course is next due to be checked
+	nextObserve time.Time // when its seat counts are next recorded
+	failures    int       // checks in a row that failed, see failingAfter
+}
+
+// courseStatus starts watching a section found in the timetable.
+func (c Config) courseStatus(s Section) CourseStatus {
+	return CourseStatus{CRN: s.CRN, Name: s.Title, Course: s.Course, Instructor: s.Instructor, Meeting: s.Meeting(), Location: s.Location, Term: s.Term, Priority: c.priority(s.CRN), Host: c.hostName(s.CRN)}
 }
 
 func loadConfig(path string) (Config, error) {
+	return loadConfigWithCRNs(path, nil)
+}
+
+// loadConfigWithCRNs loads the config and adds more CRNs to watch, such as those
+// from --crn-file, before the CRN list is validated and deduplicated.
+func loadConfigWithCRNs(path string, extra []string) (Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return Config{}, fmt.Errorf("failed to read config file: %w", err)
@@ -81,26 +194,283 @@ func loadConfig(path string) (Config, error) {
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return Config{}, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	cfg.CRNs = append(cfg.CRNs, extra...)
+	if err := cfg.validateHosts(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.validateGroups(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.validateTargetSchedule(); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.applyDefaults(); err != nil {
+		return Config{}, err
+	}
+	cfg.defaultHistoryFile(path)
+	cfg.defaultAuditFile(path)
+	cfg.RateMyProfessors.defaultCacheFile(path)
+
+	if len(cfg.CRNs) == 0 && len(cfg.Courses) == 0 && !cfg.WatchTerms {
+		return Config{}, fmt.Errorf("no CRNs or courses specified in config")
+	}
+	if err := cfg.validateCourses(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.validateCourseLevels(); err != nil {
+		return Config{}, err
+	}
+	for _, crn := range cfg.CRNs {
+		if err := validateCRN(crn); err != nil {
+			return Config{}, err
+		}
+	}
+	cfg.CRNs, cfg.duplicateCRNs = dedupeCRNs(cfg.CRNs)
+	if err := cfg.validateCampusOverrides(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.validateTermOverrides(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.validatePriorities(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
 
-	// set defaults
+// loadSearchConfig loads the config for commands that search the timetable
+// without watching CRNs, so no CRNs are required. A missing config file is
+// fine; the defaults are used instead.
+func loadSearchConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// no config file, use defaults
+	case err != nil:
+		return Config{}, fmt.Errorf("failed to read config file: %w", err)
+	default:
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return Config{}, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	if err := cfg.applyDefaults(); err != nil {
+		return Config{}, err
+	}
+	cfg.defaultHistoryFile(path)
+	cfg.defaultAuditFile(path)
+	cfg.RateMyProfessors.defaultCacheFile(path)
+	return cfg, nil
+}
+
+// applyDefaults fills in unset fields, validates the settings shared by every
+// command, and builds the HTTP session.
+func (cfg *Config) applyDefaults() error {
 	if cfg.CheckInterval == 0 {
 		cfg.CheckInterval = 30
 	}
+	if cfg.Campus == "" && len(cfg.Campuses) > 0 {
+		cfg.Campus = cfg.Campuses[0]
+	}
+	if cfg.Term == "" && len(cfg.Terms) > 0 {
+		cfg.Term = cfg.Terms[0]
+	}
 	if cfg.Campus == "" {
 		cfg.Campus = "0"
 	}
+	if err := validateSessionCode(cfg.getSessionCode()); err != nil {
+		return err
+	}
+	if err := validateLevel(cfg.Level); err != nil {
+		return err
+	}
 	if cfg.Term == "" {
 		cfg.Term = "202601"
 	}
 	if cfg.BaseURL == "" {
 		cfg.BaseURL = DefaultTimetableURL
 	}
+	if cfg.Backend == "" {
+		cfg.Backend = BackendHTML
+	}
+	if err := cfg.validateBackend(); err != nil {
+		return err
+	}
+	if cfg.EmailProvider == "" {
+		cfg.EmailProvider = ProviderResend
+	}
+	if cfg.MailgunRegion == "" {
+		cfg.MailgunRegion = "us"
+	}
+	if err := cfg.validateEmailProvider(); err != nil {
+		return err
+	}
+	if err := cfg.validateRecipients(); err != nil {
+		return err
+	}
+	if cfg.AfterOpen == "" {
+		cfg.AfterOpen = AfterOpenStop
+	}
+	if err := cfg.validateAfterOpen(); err != nil {
+		return err
+	}
+	if err := cfg.validateIPVersion(); err != nil {
+		return err
+	}
+	if err := cfg.DNS.applyDefaults(); err != nil {
+		return err
+	}
+	if cfg.RequestTimeoutSeconds == 0 {
+		cfg.RequestTimeoutSeconds = int(DefaultRequestTimeout / time.Second)
+	}
+	if cfg.RequestTimeoutSeconds < 0 {
+		return fmt.Errorf("requestTimeoutSeconds must be positive, got %d", cfg.RequestTimeoutSeconds)
+	}
+	for field := range cfg.FormOverrides {
+		if reason, reserved := reservedFormFields[field]; reserved {
+			return fmt.Errorf("formOverrides cannot set %q: %s", field, reason)
+		}
+	}
+	if err := cfg.validateHeaders(); err != nil {
+		return err
+	}
+	if cfg.BurstMinutes < 0 {
+		return fmt.Errorf("burstMinutes must be positive, got %d", cfg.BurstMinutes)
+	}
+	if cfg.ObserveMinutes < 0 {
+		return fmt.Errorf("observeMinutes must be positive, got %d", cfg.ObserveMinutes)
+	}
+	if err := cfg.HistoryRetention.validate(); err != nil {
+		return err
+	}
+	if err := cfg.Calendar.validate(); err != nil {
+		return err
+	}
+	if err := cfg.AlertSound.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.RateMyProfessors.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.Telemetry.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.ErrorReporting.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.SNS.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.SMSGateway.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.WhatsApp.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.TwilioSMS.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.Signal.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.Bark.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.Slack.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.Ntfy.applyDefaults(); err != nil {
+		return err
+	}
+	if err := applyWebhookDefaults(cfg.Webhooks); err != nil {
+		return err
+	}
+	if err := cfg.validateRateLimits(); err != nil {
+		return err
+	}
+	if err := cfg.validateMinSeverity(); err != nil {
+		return err
+	}
+	for i := range cfg.NotifyCommands {
+		if err := cfg.NotifyCommands[i].applyDefaults(); err != nil {
+			return err
+		}
+	}
+	if err := cfg.Slowdown.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.validateSchedule(); err != nil {
+		return err
+	}
+	if err := cfg.Preferences.applyDefaults(); err != nil {
+		return err
+	}
+	if err := cfg.validateCache(); err != nil {
+		return err
+	}
+	if cfg.MaxRequestsPerMinute < 0 {
+		return fmt.Errorf("maxRequestsPerMinute must be positive, got %d", cfg.MaxRequestsPerMinute)
+	}
+	if cfg.GradesFile != "" {
+		grades, err := loadGrades(cfg.GradesFile)
+		if err != nil {
+			return err
+		}
+		cfg.grades = grades
+	}
+	if err := setLocale(cfg.Locale); err != nil {
+		return err
+	}
+	if cfg.Accessible {
+		setAccessible(true)
+	}
+	if err := applyTheme(cfg.Theme); err != nil {
+		return err
+	}
+	if err := configureBanner(cfg.HideBanner, cfg.BannerFile); err != nil {
+		return err
+	}
+	if cfg.HideIcons {
+		disableIcons()
+	}
+	if cfg.RecordDir != "" && cfg.ReplayDir != "" {
+		return fmt.Errorf("recordDir and replayDir cannot both be set")
+	}
+	cfg.session = cfg.newSession()
+	return nil
+}
 
-	if len(cfg.CRNs) == 0 {
-		return Config{}, fmt.Errorf("no CRNs specified in config")
+// dedupeCRNs removes repeated CRNs, keeping the first occurrence of each.
+// Returns the unique CRNs in their original order and the CRNs that were repeated.
+func dedupeCRNs(crns []string) (unique, duplicates []string) {
+	seen := make(map[string]bool)
+	for _, crn := range crns {
+		if seen[crn] {
+			if !slices.Contains(duplicates, crn) {
+				duplicates = append(duplicates, crn)
+			}
+			continue
+		}
+		seen[crn] = true
+		unique = append(unique, crn)
 	}
+	return unique, duplicates
+}
 
-	return cfg, nil
+// validateCRN checks that a CRN has the shape VT uses: exactly five digits.
+func validateCRN(crn string) error {
+	if len(crn) != 5 {
+		return fmt.Errorf("invalid CRN %q: must be exactly 5 digits", crn)
+	}
+	for _, r := range crn {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("invalid CRN %q: must be exactly 5 digits", crn)
+		}
+	}
+	return nil
 }
 
 func (c Config) getBaseURL() string {
@@ -110,26 +480,108 @@ func (c Config) getBaseURL() string {
 	return DefaultTimetableURL
 }
 
+// termList returns the term codes to search, preferring the terms list over the single term.
+func (c Config) termList() []string {
+	if len(c.Terms) > 0 {
+		return c.Terms
+	}
+	return []string{c.Term}
+}
+
+// campusList returns the campus codes to search, preferring the campuses list over the single campus.
+func (c Config) campusList() []string {
+	if len(c.Campuses) > 0 {
+		return c.Campuses
+	}
+	return []string{c.Campus}
+}
+
+// variants expands the config into one single-term, single-campus config per
+// term/campus combination so each can be queried independently.
+func (c Config) variants() []Config {
+	var out []Config
+	for _, term := range c.termList() {
+		for _, campus := range c.campusList() {
+			v := c
+			v.Term, v.Campus = term, campus
+			v.Terms, v.Campuses = nil, nil
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// forCRN returns the config to search for crn, on its host and with its term
+// and campus overrides applied.
+func (c Config) forCRN(crn string) Config {
+	if h, ok := c.hostOf(crn); ok {
+		c = c.onHost(h)
+	}
+	if term, ok := c.TermOverrides[crn]; ok {
+		c.Term, c.Terms = term, nil
+	}
+	if campus, ok := c.CampusOverrides[crn]; ok {
+		c.Campus, c.Campuses = campus, nil
+	}
+	return c
+}
+
+// reservedFormFields are search form fields OpenSeat sets itself, which formOverrides may not replace
+var reservedFormFields = map[string]string{
+	"crn":       "it is set from each watched CRN",
+	"TERMYEAR":  `use "term" or "terms" instead`,
+	"CAMPUS":    `use "campus" or "campuses" instead`,
+	"open_only": "it is set by each check",
+	"sess_code": `use "sessionCode" instead`,
+}
+
+// searchQuery selects which sections a timetable search returns.
+// Empty fields match everything.
+type searchQuery struct {
+	CRN      string
+	Subject  string // subject code, e.g. "STAT"
+	Number   string // course number, e.g. "3114"
+	OpenOnly bool   // only sections with available seats
+	Comments bool   // include each section's comments row
+}
+
 // buildPayload constructs the form data for a timetable search request.
 // If openOnly is true, results are filtered to sections with available seats.
 func (c Config) buildPayload(crn string, openOnly bool) url.Values {
+	return c.buildQueryPayload(searchQuery{CRN: crn, OpenOnly: openOnly})
+}
+
+// buildQueryPayload constructs the form data for an arbitrary timetable search.
+// Any formOverrides from the config replace or add to the default fields.
+func (c Config) buildQueryPayload(q searchQuery) url.Values {
+	subject := q.Subject
+	if subject == "" {
+		subject = "%"
+	}
+
 	// Initialize as a standard Go map
 	rawMap := map[string][]string{
 		"CAMPUS":           {c.Campus},
 		"TERMYEAR":         {c.Term},
 		"CORE_CODE":        {"AR%"},
-		"subj_code":        {"%"},
+		"subj_code":        {subject},
 		"SCHDTYPE":         {"%"},
-		"CRSE_NUMBER":      {""},
-		"crn":              {crn},
-		"sess_code":        {"%"},
+		"CRSE_NUMBER":      {q.Number},
+		"crn":              {q.CRN},
+		"sess_code":        {c.getSessionCode()},
 		"BTN_PRESSED":      {"FIND class sections"},
 		"inst_name":        {""},
 		"disp_comments_in": {""},
 	}
-	if openOnly {
+	if q.OpenOnly {
 		rawMap["open_only"] = []string{"on"}
 	}
+	if q.Comments {
+		rawMap["disp_comments_in"] = []string{"Y"}
+	}
+	for field, value := range c.FormOverrides {
+		rawMap[field] = []string{value}
+	}
 	// Convert the map to the url.Values type so it can be passed into http methods
 	payload := url.Values(rawMap)
 
@@ -140,64 +592,324 @@ func (c Config) buildPayload(crn string, openOnly bool) url.Values {
 // HTTP / Scraping
 // ====================================
 
+// ErrLoginPage indicates the timetable answered with a login or landing page instead of
+// search results. This is a configuration/session problem, not a closed section.
+var ErrLoginPage = errors.New("timetable returned a login or landing page instead of search results")
+
+// page is a parsed timetable response. The raw bytes are kept so the response
+// can be saved for debugging when it doesn't parse the way we expect.
+type page struct {
+	*goquery.Document
+	raw []byte
+}
+
 // fetchDocument sends a POST request to the given URL and parses the response as HTML.
-// Returns the parsed document or an error if the request fails or returns non-200 status.
-func fetchDocument(targetUrl string, payload url.Values) (*goquery.Document, error) {
-	resp, err := http.PostForm(targetUrl, payload)
+// Returns the parsed page or an error if the request fails, times out, returns non-200 status,
+// lands on a login/landing page, or hits a bot check.
+func fetchDocument(client *http.Client, targetUrl string, payload url.Values) (*page, error) {
+	resp, err := client.PostForm(targetUrl, payload)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	raw, err := readResponse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bot checks usually come with a 403 or 503, so look for one before the status
+	if isChallenge(raw) {
+		return nil, fmt.Errorf("%w (status %d): open the timetable in a browser and complete the check", ErrChallenge, resp.StatusCode)
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %d %s", resp.StatusCode, resp.Status)
+		return nil, fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, resp.StatusCode, resp.Status)
+	}
+
+	// A search POST should never be redirected; if it was, we are looking at someone else's page
+	if wasRedirected(targetUrl, resp.Request.URL) {
+		return nil, fmt.Errorf("%w (redirected to %s): check baseUrl, or the timetable may now require signing in", ErrLoginPage, resp.Request.URL)
 	}
 
 	// Load the HTML document
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := parseResponse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if isLoginPage(doc) {
+		return nil, fmt.Errorf("%w: the timetable is asking for credentials, check baseUrl or whether the timetable now requires signing in", ErrLoginPage)
+	}
+
+	return &page{Document: doc, raw: raw}, nil
+}
+
+// wasRedirected reports whether the final response URL points somewhere other than the requested URL.
+func wasRedirected(targetUrl string, final *url.URL) bool {
+	if final == nil {
+		return false
+	}
+	target, err := url.Parse(targetUrl)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		return false
 	}
+	return target.Host != final.Host || target.Path != final.Path
+}
 
-	return doc, err
+// isLoginPage reports whether the document looks like a sign-in form rather than search results.
+func isLoginPage(doc *goquery.Document) bool {
+	if doc.Find(".dataentrytable").Length() > 0 {
+		return false
+	}
+	if doc.Find(`input[type="password"]`).Length() > 0 {
+		return true
+	}
+	title := strings.ToLower(doc.Find("title").Text())
+	return strings.Contains(title, "login") || strings.Contains(title, "sign in") || strings.Contains(title, "authentication")
 }
 
 // checkSectionOpen checks if the configured course section has available seats.
+// Every term/campus variant is queried in parallel and the section counts as open
+// if any variant reports it open. Errors are only returned when no variant is open.
+func (c Config) checkSectionOpen(crn string) (isOpen bool, err error) {
+	c, span := c.startSpan("check", attribute.String("crn", crn))
+	defer func() {
+		span.SetAttributes(attribute.Bool("open", isOpen))
+		endSpan(span, err)
+		c.recordCheck(isOpen, err)
+	}()
+
+	variants := c.forCRN(crn).variants()
+	open := make([]bool, len(variants))
+	errs := make([]error, len(variants))
+
+	var wg sync.WaitGroup
+	for i, v := range variants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			open[i], errs[i] = v.checkVariantOpen(crn)
+		}()
+	}
+	wg.Wait()
+
+	for _, o := range open {
+		if o {
+			return true, nil
+		}
+	}
+	return false, errors.Join(errs...)
+}
+
+// checkVariantOpen checks a single term/campus combination for available seats.
 // Returns true if the section appears in open-only search results.
-func (c Config) checkSectionOpen(crn string) (bool, error) {
-	payload := c.buildPayload(crn, true)
-	doc, err := fetchDocument(c.getBaseURL(), payload)
+func (c Config) checkVariantOpen(crn string) (bool, error) {
+	if sections, ok := c.searchBanner9(searchQuery{CRN: crn, OpenOnly: true}); ok {
+		return slices.ContainsFunc(sections, func(s Section) bool { return s.CRN == crn }), nil
+	}
+
+	doc, err := c.search(crn, true)
 	if err != nil {
 		return false, err
 	}
 
 	table := doc.Find(".dataentrytable").Text()
-	return strings.Contains(table, crn), nil
+	if strings.Contains(table, crn) {
+		return true, nil
+	}
+	if err := c.checkResults(doc, "CRN "+crn); err != nil {
+		return false, err
+	}
+	return false, nil
 }
 
 // getCourseName retrieves the course title for the configured CRN.
-// Returns an error if the CRN is not found in the timetable.
+// Returns an error if no term/campus variant finds it.
 func (c Config) getCourseName(crn string) (string, error) {
-	payload := c.buildPayload(crn, false)
-	doc, err := fetchDocument(c.BaseURL, payload)
+	section, err := c.getSection(crn)
+	return section.Title, err
+}
+
+// getSection retrieves the section listing for the configured CRN.
+// Every term/campus variant is searched in parallel and the first variant (in
+// config order) that lists the CRN wins. Returns an error if no variant finds it.
+func (c Config) getSection(crn string) (section Section, err error) {
+	c, span := c.startSpan("lookup", attribute.String("crn", crn))
+	defer func() { endSpan(span, err) }()
+
+	variants := c.forCRN(crn).variants()
+	sections := make([]Section, len(variants))
+	errs := make([]error, len(variants))
+
+	var wg sync.WaitGroup
+	for i, v := range variants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sections[i], errs[i] = v.getVariantSection(crn)
+		}()
+	}
+	wg.Wait()
+
+	for _, section := range sections {
+		if section.Title != "" {
+			return section, nil
+		}
+	}
+	return Section{}, errors.Join(errs...)
+}
+
+// getVariantSection retrieves the section listing from a single term/campus combination.
+// Returns an error if the CRN is not found in the timetable.
+func (c Config) getVariantSection(crn string) (Section, error) {
+	if sections, ok := c.searchBanner9(searchQuery{CRN: crn}); ok {
+		for _, s := range sections {
+			if s.CRN == crn && s.Title != "" {
+				s.Term = c.Term
+				return s, nil
+			}
+		}
+		return Section{}, fmt.Errorf("course not found for CRN: %s", crn)
+	}
+
+	doc, err := c.search(crn, false)
 	if err != nil {
-		return "", err
+		return Section{}, err
+	}
+
+	_, span := c.startSpan("parse")
+	section, ok := findSection(doc.Document, crn)
+	span.End()
+	if !ok {
+		if err := c.checkResults(doc, "CRN "+crn, "title"); err != nil {
+			return Section{}, err
+		}
+		return Section{}, fmt.Errorf("course not found for CRN: %s", crn)
+	}
+	if section.Title == "" {
+		return Section{}, c.unexpectedPage(doc, "CRN "+crn, "CRN row has no title")
+	}
+
+	section.Term = c.Term
+	return section, nil
+}
+
+// findSections runs the search against every term/campus variant in parallel, returning
+// the matching sections sorted by course number and then CRN. Sections found are
+// returned even if some variants failed; the failures are joined into the error.
+// what describes the search for error messages, e.g. "subject STAT".
+func (c Config) findSections(q searchQuery, what string) ([]Section, error) {
+	variants := c.variants()
+	results := make([][]Section, len(variants))
+	errs := make([]error, len(variants))
+
+	var wg sync.WaitGroup
+	for i, v := range variants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = v.findVariantSections(q, what)
+		}()
 	}
+	wg.Wait()
 
-	var courseName string
-	doc.Find(".dataentrytable tr").Each(func(i int, row *goquery.Selection) {
-		// check if the row contains the target crn
-		if strings.Contains(row.Find("td:nth-child(1)").Text(), crn) {
-			// the course title is in the 3rd td cell
-			courseName = strings.TrimSpace(row.Find("td:nth-child(3)").Text())
+	seen := make(map[string]bool)
+	var sections []Section
+	for _, found := range results {
+		for _, s := range found {
+			if !seen[s.CRN] {
+				seen[s.CRN] = true
+				sections = append(sections, s)
+			}
 		}
+	}
+
+	slices.SortFunc(sections, func(a, b Section) int {
+		return cmp.Or(cmp.Compare(a.Course, b.Course), cmp.Compare(a.CRN, b.CRN))
 	})
+	return sections, errors.Join(errs...)
+}
 
-	if courseName == "" {
-		return "", fmt.Errorf("course not found for CRN: %s", crn)
+// findVariantSections runs the search against a single term/campus combination.
+func (c Config) findVariantSections(q searchQuery, what string) ([]Section, error) {
+	sections, ok := c.searchBanner9(q)
+	if !ok {
+		doc, err := c.searchQuery(q)
+		if err != nil {
+			return nil, err
+		}
+
+		_, span := c.startSpan("parse")
+		sections = parseSections(doc.Document)
+		span.End()
+		if len(sections) == 0 {
+			if err := c.checkResults(doc, what); err != nil {
+				return nil, err
+			}
+		}
 	}
+	for i := range sections {
+		sections[i].Term = c.Term
+	}
+	return sections, nil
+}
+
+// notifyOpen sends the seat-open alert for a course, unless another instance sharing
+// the state store already sent it. If the store can't be reached the alert is sent
+// anyway, since a duplicate alert beats a missed one. High-priority alerts are
+// flagged as urgent in the subject line.
+func (c Config) notifyOpen(store StateStore, sender EmailSender, course CourseStatus) {
+	if !c.notifying() {
+		return
+	}
+
+	claimed, err := store.Claim(c.openEventID(course.CRN), c.dedupeWindow())
+	if err != nil {
+		PrintStateError(err)
+	} else if !claimed {
+		PrintAlreadyNotified(course.CRN)
+		return
+	}
+
+	subject := T("email.open.subject")
+	if course.Priority == PriorityHigh {
+		subject = T("email.open.urgent", subject)
+	}
+	body := T("email.open.body", course.Name, course.CRN, c.termOf(course))
+	if course.Host != "" {
+		body += "\n" + T("email.open.host", course.Host)
+	}
+	if gpa := c.grades.describe(course.Course, course.Instructor); gpa != "" {
+		body += "\n" + gpa
+	}
+	if rating := c.RateMyProfessors.describe(course.Instructor); rating != "" {
+		body += "\n" + rating
+	}
+	if clash, ok := c.conflict(course); ok {
+		subject = T("email.open.conflict_subject", subject)
+		body += "\n\n" + T("email.open.conflict", clash)
+	}
+	if g, others, ok := c.alternatives(course.CRN); ok {
+		body += "\n\n" + T("email.open.alternatives", g.Name, course.CRN, strings.Join(others, ", "))
+	}
+	c.notify(sender, c.courseAlert(EventOpen, course, subject, body))
+}
 
-	return courseName, nil
+// notifyStarted emails a summary of what is being watched, so a watcher left running
+// on a server gives positive confirmation that it came up with the right config.
+func (c Config) notifyStarted(sender EmailSender, courses []CourseStatus) {
+	if !c.notifying() || !c.NotifyOnStart {
+		return
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\n\n", T("email.started.body", len(courses), strings.Join(c.watchedTerms(), ", "), strings.Join(c.channels(), ", "), c.CheckInterval))
+	for _, course := range courses {
+		fmt.Fprintf(&body, "%s  %s  (%s)\n", course.CRN, course.Name, c.termOf(course))
+	}
+
+	c.notify(sender, Alert{Event: AlertStarted, Subject: T("email.started.subject"), Body: body.String()})
 }
 
 // ===================================
@@ -207,92 +919,503 @@ func (c Config) getCourseName(crn string) (string, error) {
 type RunOptions struct {
 	ConfigPath  string
 	EmailSender EmailSender
+	RecordDir   string // overrides the config's recordDir
+	ReplayDir   string // overrides the config's replayDir
+	Simulate    bool   // watch a built-in fake timetable instead of the real one
+	Accessible  bool   // plain line-oriented output for screen readers
+	HideBanner  bool   // never show the banner
+	HideIcons   bool   // leave out the Nerd Font icons
+	Once        bool   // check each CRN once and exit instead of watching
+	CRNFile     string // file of more CRNs to watch, or "-" for stdin
+	Force       bool   // start even if another instance holds the config's lock
+	ReportFile  string // overrides the config's reportFile
 }
 
-func Run(opts RunOptions) error {
-	cfg, err := loadConfig(opts.ConfigPath)
+func Run(opts RunOptions) (err error) {
+	var extra []string
+	if opts.CRNFile != "" {
+		crns, err := readCRNFile(opts.CRNFile)
+		if err != nil {
+			return configError(fmt.Errorf("--crn-file: %w", err))
+		}
+		extra = crns
+	}
+	cfg, err := loadConfigWithCRNs(opts.ConfigPath, extra)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+	unlock, err := acquireLock(lockPath(opts.ConfigPath), opts.Force)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	onInterrupt(unlock)
+	if opts.RecordDir != "" || opts.ReplayDir != "" {
+		cfg.RecordDir, cfg.ReplayDir = opts.RecordDir, opts.ReplayDir
+		cfg.session = cfg.newSession()
+	}
+	if opts.Accessible {
+		setAccessible(true)
+	}
+	if opts.HideBanner {
+		bannerHidden = true
+	}
+	if opts.HideIcons {
+		disableIcons()
+	}
+	if opts.Simulate {
+		stop, err := cfg.simulate(time.Now())
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+	if err := cfg.resolveAutoTerms(time.Now()); err != nil {
+		return err
+	}
+	if err := cfg.resolveCampusNames(); err != nil {
+		return err
+	}
+	if cfg.Calendar.dropAddOver(time.Now()) {
+		return fmt.Errorf("drop/add ended on %s, so there is nothing left to watch for", cfg.Calendar.DropAddEnds)
 	}
 
-	// use provided email sender or create default
+	// use provided email sender or create one for the configured provider
 	emailSender := opts.EmailSender
 	if emailSender == nil {
-		emailSender = &ResendEmailSender{APIKey: os.Getenv("RESEND_API_KEY")}
+		if emailSender, err = cfg.newEmailSender(); err != nil {
+			return err
+		}
+	}
+
+	store, err := cfg.newStateStore()
+	if err != nil {
+		return err
+	}
+	if cfg.notifiers, err = cfg.newNotifiers(emailSender); err != nil {
+		return err
+	}
+	if err := cfg.checkChannelNames(); err != nil {
+		return configError(err)
+	}
+	cfg.limiter = newRateLimiter(cfg.RateLimits, store)
+	cfg.latency = newLatencyTracker(cfg.Slowdown)
+	if opts.ReportFile != "" {
+		cfg.ReportFile = opts.ReportFile
+	}
+	cfg.report = newRunReport(cfg.ReportFile)
+
+	stopTelemetry, err := cfg.Telemetry.start()
+	if err != nil {
+		return err
+	}
+	defer stopTelemetry()
+	onInterrupt(stopTelemetry)
+	defer waitForCommands()
+
+	reporter, err := cfg.startErrorReporting()
+	if err != nil {
+		return err
+	}
+	defer reporter.flush()
+	defer reporter.recoverPanic()
+
+	session, err := cfg.sessionLabel()
+	if err != nil {
+		return configError(err)
 	}
 
 	// Display UI
 	PrintBanner()
-	PrintConfigBox(len(cfg.CRNs), cfg.Email, cfg.CheckInterval, cfg.Term)
+	PrintConfigBox(len(cfg.CRNs), strings.Join(cfg.recipients(), ", "), cfg.CheckInterval, strings.Join(cfg.watchedTerms(), ", "), session)
+	if opts.Simulate {
+		PrintSimulationNotice()
+	}
+	for _, crn := range cfg.duplicateCRNs {
+		PrintDuplicateCRN(crn)
+	}
+	if cfg.Calendar.beforeRegistration(time.Now()) {
+		PrintBeforeRegistration(cfg.Calendar.RegistrationOpens)
+	}
 
 	// Initialize course statuses - filter out invalid CRNs
 	PrintFetchingHeader()
 	var courses []CourseStatus
 	for _, crn := range cfg.CRNs {
-		name, err := cfg.getCourseName(crn)
+		section, err := cfg.getSection(crn)
+		if errors.Is(err, ErrLoginPage) || errors.Is(err, ErrChallenge) {
+			return err
+		}
 		if err != nil {
 			PrintCourseNotFound(crn)
 			continue
 		}
-		courses = append(courses, CourseStatus{CRN: crn, Name: name, Found: false})
-		PrintCourseFound(crn, name)
+		courses = append(courses, cfg.courseStatus(section))
+		PrintCourseFound(crn, section.Title, cfg.watchLabel(crn, section.Term))
+	}
+	var watches []*courseWatch
+	for _, code := range cfg.Courses {
+		w, err := cfg.startCourseWatch(code)
+		if errors.Is(err, ErrLoginPage) || errors.Is(err, ErrChallenge) {
+			return err
+		}
+		if err != nil {
+			PrintCourseNotFound(code)
+			continue
+		}
+		watches = append(watches, w)
+		PrintCourseWatch(w.Code, len(w.known))
+	}
+	var terms *termWatch
+	if cfg.WatchTerms {
+		if terms, err = cfg.startTermWatch(); err != nil {
+			return fmt.Errorf("failed to read the term list: %w", err)
+		}
+		PrintTermWatch(len(terms.known))
 	}
 
-	if len(courses) == 0 {
-		return fmt.Errorf("no valid CRNs to monitor")
+	if len(courses) == 0 && len(watches) == 0 && terms == nil {
+		return configError(fmt.Errorf("no valid CRNs to monitor"))
+	}
+	cfg.checkGroupMembers(courses)
+	if err := cfg.loadSchedule(); err != nil {
+		return err
+	}
+	if opts.Once {
+		return cfg.checkOnce(store, emailSender, courses)
 	}
 
+	cfg.loadWatchState(store, courses)
+	cfg.notifyStarted(emailSender, courses)
 	PrintDivider()
 
-	// Main monitoring loop
+	// SIGUSR1 starts a burst of fast checks
+	bursts := make(chan os.Signal, 1)
+	notifyBurst(bursts)
+	defer signal.Stop(bursts)
+	var fast burst
+
+	// Keyboard controls
+	keys, restoreTerminal := startKeys()
+	defer restoreTerminal()
+	var ctl controls
+	if keys != nil {
+		PrintKeyHelp()
+	}
+
+	// Control socket, for openseatctl
+	calls, stopControl := startControl(controlSocketPath(opts.ConfigPath))
+	defer stopControl()
+
+	// Main monitoring loop: each course is checked when it comes due for its priority
 	remaining := len(courses)
-	interval := time.Duration(cfg.CheckInterval) * time.Second
+	act := newActivity(time.Now(), courses)
+	groups := cfg.newGroupWatches()
+	var suggested []string // CRNs last suggested to register for, see checkSuggestion
+	var target targetWatch
+	cfg.recordWatching(courses, time.Now())
+	status := cfg.newStatusWriter(time.Now())
+	stopStatus := func() { status.write(StatusStopped, time.Now(), courses, watches) }
+	defer stopStatus()
+	onInterrupt(stopStatus)
+	finishReport := func(err error) { cfg.report.write(status.status(StatusStopped, time.Now(), courses, watches), err) }
+	defer func() {
+		waitForCommands() // so the report counts their outcomes
+		finishReport(err)
+	}()
+	onInterrupt(func() { finishReport(errInterrupted) })
+	challenged := false // a bot check has been reported and not yet cleared
+	var clock clockWatch
+	var nextPrune time.Time
 
 	for attempt := 1; ; attempt++ {
-		checkTime := time.Now().Format("15:04:05")
+		now := time.Now()
+		checkTime := now.Format("15:04:05")
+		if cfg.Calendar.dropAddOver(now) {
+			PrintDropAddOver(cfg.Calendar.DropAddEnds)
+			return nil
+		}
+		if !fast.until.IsZero() && !fast.active(now) {
+			fast = burst{}
+			PrintBurstEnded()
+		}
+		stopped, err := cfg.markEnrolled(courses)
+		if err != nil {
+			PrintHistoryError(err)
+		}
+		remaining -= stopped
+		if !now.Before(nextPrune) {
+			nextPrune = now.Add(pruneInterval)
+			cfg.autoPruneHistory(now)
+		}
 
+		checked := make(map[string]bool) // CRNs checked without error this round, for watch groups
+		alignGroups(groups, courses, now)
+		cfg.alignTarget(courses, now)
 		for i := range courses {
-			if courses[i].Found {
+			if ctl.holding(now) || courses[i].Found || now.Before(courses[i].nextCheck) {
 				continue
 			}
-
 			PrintCheckingStatus(attempt, attempt, courses[i].CRN)
 
 			open, err := cfg.checkSectionOpen(courses[i].CRN)
-			if err != nil {
+			wasOpen := courses[i].Open
+			act.record(courses[i].CRN, open, err, time.Now())
+			status.record(courses[i].CRN, err, time.Now())
+			cfg.report.checkError(err)
+			switch {
+			case errors.Is(err, ErrChallenge):
+				// every other CRN would hit the same check, so stop until someone gets past it
+				ctl.pauseUntil(time.Now().Add(ChallengePause))
+				PrintChallenge(err, ctl.resumeAt.Format("15:04"))
+				if !challenged {
+					cfg.notifyChallenge(emailSender, err)
+				}
+				challenged = true
+
+			case err != nil:
 				PrintCheckError(checkTime, courses[i].CRN, err)
-				continue
-			}
+				reporter.record(err)
+				cfg.checkFailed(emailSender, &courses[i], err)
 
-			if open {
-				courses[i].Found = true
-				remaining--
+			case open && !courses[i].Open && cfg.inGroup(courses[i].CRN):
+				// announced once the rest of its group is open too, see checkGroups
+				courses[i].Open = true
+				cfg.recordHistory(EventOpen, courses[i], time.Now())
 
-				PrintSeatAvailable(courses[i].Name, courses[i].CRN)
+			case open && !courses[i].Open && cfg.suppressed(courses[i]):
+				// a seat that clashes with the schedule isn't worth an alert
+				courses[i].Open = true
+				clash, _ := cfg.conflict(courses[i])
+				PrintConflictSuppressed(courses[i].Name, courses[i].CRN, clash)
+				cfg.recordHistory(EventOpen, courses[i], time.Now())
+
+			case open && !courses[i].Open:
+				courses[i].Open = true
+				if cfg.AfterOpen == AfterOpenStop && !cfg.inTarget(courses[i].CRN) {
+					courses[i].Found = true
+					remaining--
+				}
 
-				if cfg.Email != "" {
-					emailSender.Send(cfg.Email, "VT Course Section Open!", fmt.Sprintf("OPEN SEAT: %s (CRN: %s)", courses[i].Name, courses[i].CRN))
-					PrintEmailSent(cfg.Email)
+				PrintSeatAvailable(courses[i].Name, courses[i].CRN, courses[i].Priority == PriorityHigh && !ctl.muted)
+				if clash, ok := cfg.conflict(courses[i]); ok {
+					PrintConflict(clash)
 				}
+				cfg.recordHistory(EventOpen, courses[i], time.Now())
+				if !ctl.muted {
+					go func() {
+						if err := cfg.AlertSound.play(); err != nil {
+							PrintSoundFailed(err)
+						}
+					}()
+				}
+				cfg.notifyOpen(store, emailSender, courses[i])
+
+			case !open && courses[i].Open:
+				courses[i].Open = false
+				PrintSeatClosed(courses[i].Name, courses[i].CRN)
+				cfg.recordHistory(EventClosed, courses[i], time.Now())
+			}
+			if courses[i].Open != wasOpen {
+				cfg.saveWatchState(store, courses[i].CRN, courses[i].Open)
+			}
+			if err == nil {
+				challenged = false
+				courses[i].failures = 0
+				checked[courses[i].CRN] = true
+			}
+			if err == nil && !now.Before(courses[i].nextObserve) {
+				courses[i].nextObserve = now.Add(cfg.observeInterval())
+				cfg.observe(&courses[i], emailSender, time.Now())
 			}
+			courses[i].nextCheck = now.Add(fast.interval(now, cfg.slowdownInterval(cfg.courseInterval(courses[i]))))
 
 			time.Sleep(500 * time.Millisecond) // Small delay between requests
 		}
+		_, stopped = cfg.checkGroups(groups, courses, checked, ctl.muted, store, emailSender)
+		remaining -= stopped
+		remaining -= cfg.checkTarget(&target, courses, checked, ctl.muted, store, emailSender)
+		suggested = cfg.checkSuggestion(courses, suggested, emailSender)
 
-		if remaining == 0 {
+		for _, w := range watches {
+			if !ctl.holding(now) && !now.Before(w.nextCheck) {
+				cfg.checkCourseWatch(w, emailSender, checkTime, time.Now())
+			}
+		}
+		if terms != nil && !ctl.holding(now) && !now.Before(terms.nextCheck) {
+			cfg.checkTermWatch(terms, emailSender, checkTime, time.Now())
+		}
+		cfg.checkSlowdowns(emailSender)
+		status.write(ctl.statusState(time.Now()), time.Now(), courses, watches)
+
+		if remaining == 0 && len(watches) == 0 && terms == nil {
 			PrintAllCoursesFound()
 			return nil
 		}
+		cfg.sendSummary(emailSender, act, courses, time.Now())
 
-		// Animate spinner while waiting
-		waitUntil := time.Now().Add(interval)
+		// Animate spinner while waiting; checks stay on hold while paused
+		waitUntil := nextDue(courses)
+		if remaining == 0 {
+			waitUntil = time.Time{} // every CRN is done, only course watches are left
+		}
+		waitUntil = earliest(waitUntil, nextWatchDue(watches))
+		if terms != nil {
+			waitUntil = earliest(waitUntil, terms.nextCheck)
+		}
 		i := 0
-		for time.Now().Before(waitUntil) {
+		clock.reset(time.Now())
+		for ctl.holding(time.Now()) || time.Now().Before(waitUntil) {
+			var action keyAction
+			var typed string
+			var call *controlCall
+			var err error
+			select {
+			case <-bursts:
+				// check everything right away, then keep the fast cadence until the burst ends
+				fast.start(time.Now(), cfg.burstDuration())
+				PrintBurstStarted(fast.until.Format("15:04:05"))
+				checkNow(courses)
+				waitUntil = time.Now()
+				continue
+
+			case k := <-keys:
+				action, typed = ctl.handleKey(k, courses)
+
+			case c := <-calls:
+				call = &c
+				action, typed, err = ctl.handleControl(c.Request, courses)
+
+			default:
+			}
+
+			// keypresses and control socket commands are handled alike
+			via := ViaKeyboard
+			if call != nil {
+				via = ViaCtl
+			}
+			switch action {
+			case keyCheckNow:
+				ctl.resume()
+				checkNow(courses)
+				waitUntil = time.Now()
+			case keyAdd:
+				var course CourseStatus
+				course, err = cfg.watchCRN(typed, courses)
+				clock.reset(time.Now()) // the lookup may take a while
+				if err != nil {
+					PrintAddFailed(typed, err)
+				} else {
+					courses = append(courses, course)
+					act.add(course)
+					cfg.recordWatching([]CourseStatus{course}, time.Now())
+					cfg.audit(AuditEntry{Action: AuditWatchAdded, CRN: course.CRN, Actor: localActor(), Via: via})
+					remaining++
+					ClearLine()
+					PrintCourseFound(course.CRN, course.Name, cfg.watchLabel(course.CRN, course.Term))
+					waitUntil = time.Now()
+				}
+			case keyRemove:
+				if h, ok := ctl.highlighted(courses); ok {
+					courses[h].Found = true
+					remaining--
+					PrintCRNRemoved(courses[h].CRN)
+					cfg.audit(AuditEntry{Action: AuditWatchRemoved, CRN: courses[h].CRN, Actor: localActor(), Via: via})
+				}
+			case keyBrowse:
+				err = ctl.browsing.load(cfg, typed)
+				clock.reset(time.Now()) // the search may take a while
+				if err != nil {
+					PrintBrowseFailed(typed, err)
+				} else {
+					PrintBrowse(ctl.browsing)
+				}
+			case keyList:
+				PrintBrowse(ctl.browsing)
+			}
+			if call != nil {
+				call.respond(err, status.status(ctl.statusState(time.Now()), time.Now(), courses, watches))
+			}
+			switch {
+			case action == keyQuit:
+				ClearLine()
+				return nil
+			case action == keyRemove && remaining == 0 && len(watches) == 0 && terms == nil:
+				PrintAllCoursesFound()
+				return nil
+			case action == keyCheckNow, action == keyAdd && err == nil:
+				continue
+			}
+
+			if status.due(time.Now()) {
+				status.write(ctl.statusState(time.Now()), time.Now(), courses, watches)
+			}
 			timeLeft := time.Until(waitUntil).Round(time.Second)
-			found := len(courses) - remaining
-			PrintWaitingStatus(i, attempt, found, len(courses), timeLeft.String(), checkTime)
+			found := openCount(courses)
+			note := joinNotes(ctl.status(courses), cfg.Calendar.status(time.Now()))
+			PrintWaitingStatus(i, attempt, found, len(courses), timeLeft.String(), checkTime, note)
 			time.Sleep(100 * time.Millisecond)
 			i++
+
+			// after sleep or a paused VM, check everything now instead of picking up where the timers left off
+			if gap, jumped := clock.tick(time.Now(), 100*time.Millisecond); jumped {
+				PrintClockJump(humanDuration(gap.Abs()))
+				resumeAfterJump(courses, watches, terms, &ctl, &fast, act)
+				waitUntil = time.Now()
+			}
+		}
+	}
+}
+
+// checkOnce checks each course a single time, announcing any open section, or
+// watch group with every section open, as the watch loop would. Returns nil if a
+// section is open, ErrNoSeat if every check succeeded and none are, or the check
+// errors otherwise.
+func (c Config) checkOnce(store StateStore, sender EmailSender, courses []CourseStatus) error {
+	var errs []error
+	found := false
+	checked := make(map[string]bool)
+	for i, course := range courses {
+		PrintCheckingStatus(1, i+1, course.CRN)
+		open, err := c.checkSectionOpen(course.CRN)
+		if err == nil {
+			checked[course.CRN] = true
+			courses[i].Open = open
 		}
+		switch {
+		case err != nil:
+			PrintCheckError(time.Now().Format("15:04:05"), course.CRN, err)
+			errs = append(errs, fmt.Errorf("CRN %s: %w", course.CRN, err))
+		case open && c.inGroup(course.CRN):
+			c.recordHistory(EventOpen, course, time.Now())
+		case open && c.suppressed(course):
+			clash, _ := c.conflict(course)
+			PrintConflictSuppressed(course.Name, course.CRN, clash)
+			c.recordHistory(EventOpen, course, time.Now())
+		case open:
+			found = true
+			course.Open = true
+			PrintSeatAvailable(course.Name, course.CRN, course.Priority == PriorityHigh)
+			if clash, ok := c.conflict(course); ok {
+				PrintConflict(clash)
+			}
+			c.recordHistory(EventOpen, course, time.Now())
+			c.notifyOpen(store, sender, course)
+		}
+	}
+	if opened, _ := c.checkGroups(c.newGroupWatches(), courses, checked, false, store, sender); opened > 0 {
+		found = true
+	}
+	c.checkTarget(&targetWatch{}, courses, checked, false, store, sender)
+	c.checkSuggestion(courses, nil, sender)
+	ClearLine()
+
+	switch {
+	case found:
+		return nil
+	case len(errs) > 0:
+		return errors.Join(errs...)
 	}
+	PrintNoSeat()
+	return ErrNoSeat
 }
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 // ===================
@@ -14,23 +17,15 @@ import (
 // ===================
 
 type MockEmailSender struct {
-	Sent []struct {
-		To      string
-		Subject string
-		Body    string
-	}
+	Sent        []EmailMessage
 	ShouldError bool
 }
 
-func (m *MockEmailSender) Send(to, subject, body string) error {
+func (m *MockEmailSender) Send(msg EmailMessage) error {
 	if m.ShouldError {
 		return fmt.Errorf("mock email error")
 	}
-	m.Sent = append(m.Sent, struct {
-		To      string
-		Subject string
-		Body    string
-	}{to, subject, body})
+	m.Sent = append(m.Sent, msg)
 	return nil
 }
 
@@ -99,6 +94,70 @@ func TestLoadConfig_AppliesDefaults(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_RequestTimeout(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "requestTimeoutSeconds": 5}`)
+	defer os.Remove(path)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := cfg.httpClient().Timeout; got != 5*time.Second {
+		t.Errorf("client timeout = %v, want 5s", got)
+	}
+}
+
+func TestLoadConfig_DefaultRequestTimeout(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"]}`)
+	defer os.Remove(path)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := cfg.httpClient().Timeout; got != DefaultRequestTimeout {
+		t.Errorf("client timeout = %v, want %v", got, DefaultRequestTimeout)
+	}
+}
+
+func TestLoadConfig_ErrorNegativeTimeout(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "requestTimeoutSeconds": -1}`)
+	defer os.Remove(path)
+
+	_, err := loadConfig(path)
+	if err == nil {
+		t.Error("expected error for negative timeout")
+	}
+}
+
+func TestLoadConfig_ErrorReservedFormOverride(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "formOverrides": {"crn": "99999"}}`)
+	defer os.Remove(path)
+
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error when formOverrides sets crn")
+	}
+}
+
+func TestLoadConfig_TermAndCampusFromLists(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "terms": ["202606", "202607"], "campuses": ["10"]}`)
+	defer os.Remove(path)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Term != "202606" {
+		t.Errorf("expected term '202606' from terms list, got '%s'", cfg.Term)
+	}
+	if cfg.Campus != "10" {
+		t.Errorf("expected campus '10' from campuses list, got '%s'", cfg.Campus)
+	}
+}
+
 func TestLoadConfig_ErrorNoCRNs(t *testing.T) {
 	path := createTempConfig(t, `{"email": "test@example.com"}`)
 	defer os.Remove(path)
@@ -109,6 +168,58 @@ func TestLoadConfig_ErrorNoCRNs(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_ErrorInvalidCRN(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345", "1234o"]}`)
+	defer os.Remove(path)
+
+	_, err := loadConfig(path)
+	if err == nil {
+		t.Fatal("expected error for malformed CRN")
+	}
+	if !strings.Contains(err.Error(), "1234o") {
+		t.Errorf("error %q should name the bad CRN", err)
+	}
+}
+
+func TestLoadConfig_DedupesCRNs(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345", "67890", "12345", "12345"]}`)
+	defer os.Remove(path)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cfg.CRNs) != 2 || cfg.CRNs[0] != "12345" || cfg.CRNs[1] != "67890" {
+		t.Errorf("CRNs = %v, want [12345 67890]", cfg.CRNs)
+	}
+	if len(cfg.duplicateCRNs) != 1 || cfg.duplicateCRNs[0] != "12345" {
+		t.Errorf("duplicateCRNs = %v, want [12345]", cfg.duplicateCRNs)
+	}
+}
+
+func TestValidateCRN(t *testing.T) {
+	tests := []struct {
+		crn   string
+		valid bool
+	}{
+		{"12345", true},
+		{"00001", true},
+		{"1234", false},
+		{"123456", false},
+		{"1234o", false},
+		{" 1234", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		err := validateCRN(tt.crn)
+		if (err == nil) != tt.valid {
+			t.Errorf("validateCRN(%q) error = %v, want valid=%v", tt.crn, err, tt.valid)
+		}
+	}
+}
+
 func TestLoadConfig_ErrorFileNotFound(t *testing.T) {
 	_, err := loadConfig("/nonexistent/config.json")
 	if err == nil {
@@ -169,6 +280,21 @@ func TestBuildPayload_OpenOnlyTrue(t *testing.T) {
 	}
 }
 
+func TestBuildPayload_FormOverrides(t *testing.T) {
+	cfg := Config{Campus: "0", Term: "202601", FormOverrides: map[string]string{"SCHDTYPE": "L", "extra_field": "x"}}
+	payload := cfg.buildPayload("12345", false)
+
+	if got := payload.Get("SCHDTYPE"); got != "L" {
+		t.Errorf("SCHDTYPE = %q, want %q", got, "L")
+	}
+	if got := payload.Get("extra_field"); got != "x" {
+		t.Errorf("extra_field = %q, want %q", got, "x")
+	}
+	if got := payload.Get("CORE_CODE"); got != "AR%" {
+		t.Errorf("CORE_CODE = %q, want default %q", got, "AR%")
+	}
+}
+
 // ===================
 // fetchDocument tests
 // ===================
@@ -180,7 +306,7 @@ func TestFetchDocument_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	doc, err := fetchDocument(server.URL, url.Values{})
+	doc, err := fetchDocument(http.DefaultClient, server.URL, url.Values{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -196,19 +322,78 @@ func TestFetchDocument_Non200Status(t *testing.T) {
 	}))
 	defer server.Close()
 
-	_, err := fetchDocument(server.URL, url.Values{})
+	_, err := fetchDocument(http.DefaultClient, server.URL, url.Values{})
 	if err == nil {
 		t.Error("expected error for 500 status")
 	}
 }
 
 func TestFetchDocument_NetworkError(t *testing.T) {
-	_, err := fetchDocument("http://localhost:99999", url.Values{})
+	_, err := fetchDocument(http.DefaultClient, "http://localhost:99999", url.Values{})
 	if err == nil {
 		t.Error("expected error for connection refused")
 	}
 }
 
+func TestFetchDocument_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(`<html></html>`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Timeout: 20 * time.Millisecond}
+	_, err := fetchDocument(client, server.URL, url.Values{})
+	if err == nil {
+		t.Error("expected error when request exceeds timeout")
+	}
+}
+
+func TestFetchDocument_RedirectToLogin(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/login", http.StatusFound)
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body>Welcome</body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	_, err := fetchDocument(http.DefaultClient, server.URL+"/search", url.Values{})
+	if !errors.Is(err, ErrLoginPage) {
+		t.Errorf("expected ErrLoginPage, got %v", err)
+	}
+}
+
+func TestFetchDocument_LoginForm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><title>Login</title><form><input type="password" name="pw"></form></html>`))
+	}))
+	defer server.Close()
+
+	_, err := fetchDocument(http.DefaultClient, server.URL, url.Values{})
+	if !errors.Is(err, ErrLoginPage) {
+		t.Errorf("expected ErrLoginPage, got %v", err)
+	}
+}
+
+func TestCheckSectionOpen_LoginPageIsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><title>Central Authentication Service</title></html>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601"}
+	open, err := cfg.checkSectionOpen("12345")
+	if !errors.Is(err, ErrLoginPage) {
+		t.Errorf("expected ErrLoginPage, got %v", err)
+	}
+	if open {
+		t.Error("expected open=false for login page")
+	}
+}
+
 // ===================
 // checkSectionOpen tests
 // ===================
@@ -264,6 +449,71 @@ func TestCheckSectionOpen_ServerError(t *testing.T) {
 	}
 }
 
+func TestCheckSectionOpen_AnyVariantOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		// Only the virtual campus has the section open
+		if r.FormValue("CAMPUS") == "10" {
+			w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td></tr></table>`))
+			return
+		}
+		w.Write([]byte(`<table class="dataentrytable"></table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campuses: []string{"0", "10"}, Terms: []string{"202601", "202606"}}
+	open, err := cfg.checkSectionOpen("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !open {
+		t.Error("expected open=true when any variant has the CRN")
+	}
+}
+
+func TestCheckSectionOpen_VariantErrorWhenNoneOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.FormValue("CAMPUS") == "10" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`<table class="dataentrytable"></table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Term: "202601", Campuses: []string{"0", "10"}}
+	_, err := cfg.checkSectionOpen("12345")
+	if err == nil {
+		t.Error("expected error when a variant fails and none are open")
+	}
+}
+
+// ===================
+// variants tests
+// ===================
+
+func TestVariants_CrossProduct(t *testing.T) {
+	cfg := Config{Term: "202601", Campus: "0", Terms: []string{"202601", "202606"}, Campuses: []string{"0", "10"}}
+	variants := cfg.variants()
+
+	if len(variants) != 4 {
+		t.Fatalf("expected 4 variants, got %d", len(variants))
+	}
+	if variants[3].Term != "202606" || variants[3].Campus != "10" {
+		t.Errorf("last variant = %s/%s, want 202606/10", variants[3].Term, variants[3].Campus)
+	}
+}
+
+func TestVariants_SingleWhenNoLists(t *testing.T) {
+	cfg := Config{Term: "202601", Campus: "0"}
+	variants := cfg.variants()
+
+	if len(variants) != 1 || variants[0].Term != "202601" || variants[0].Campus != "0" {
+		t.Errorf("got %+v, want single 202601/0 variant", variants)
+	}
+}
+
 // ===================
 // getCourseName tests
 // ===================
@@ -301,15 +551,56 @@ func TestGetCourseName_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetCourseName_FirstVariantWins(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.FormValue("TERMYEAR") == "202606" {
+			w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td><td>001</td><td>Summer Testing</td></tr></table>`))
+			return
+		}
+		w.Write([]byte(`<table class="dataentrytable"></table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Terms: []string{"202601", "202606"}}
+	name, err := cfg.getCourseName("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "Summer Testing" {
+		t.Errorf("got %q, want %q", name, "Summer Testing")
+	}
+}
+
 // ===================
-// ResendEmailSender tests
+// notifyStarted tests
 // ===================
 
-func TestResendEmailSender_NoAPIKey(t *testing.T) {
-	sender := &ResendEmailSender{APIKey: ""}
-	err := sender.Send("to@example.com", "Subject", "Body")
-	if err == nil {
-		t.Error("expected error when API key is empty")
+func TestNotifyStarted_SummarizesWatch(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601", CheckInterval: 30, NotifyOnStart: true}
+	sender := &MockEmailSender{}
+
+	cfg.notifyStarted(sender, []CourseStatus{{CRN: "12345", Name: "Intro to Testing"}, {CRN: "67890", Name: "Advanced Testing"}})
+
+	if len(sender.Sent) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(sender.Sent))
+	}
+	body := sender.Sent[0].Body
+	for _, want := range []string{"Watching 2 CRNs for term 202601", "interval 30s", "67890  Advanced Testing"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestNotifyStarted_OffByDefault(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601"}
+	sender := &MockEmailSender{}
+
+	cfg.notifyStarted(sender, []CourseStatus{{CRN: "12345", Name: "Intro to Testing"}})
+
+	if len(sender.Sent) != 0 {
+		t.Errorf("expected no email without notifyOnStart, got %d", len(sender.Sent))
 	}
 }
 
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"slices"
+	"time"
+)
+
+// openingStats is how long one CRN took to first open after watching started,
+// and how long its openings lasted, from the whole history file
+type openingStats struct {
+	CRN       string
+	Name      string
+	Watched   time.Time       // when watching started, zero if the history predates it
+	FirstOpen time.Time       // first opening since watching started, zero if none yet
+	Durations []time.Duration // how long each opening that has closed lasted, oldest first
+	opened    time.Time       // when the current opening started, zero while closed
+}
+
+// firstOpenAfter returns how long after watching started the section first opened.
+func (s *openingStats) firstOpenAfter() (time.Duration, bool) {
+	if s.Watched.IsZero() || s.FirstOpen.IsZero() {
+		return 0, false
+	}
+	return s.FirstOpen.Sub(s.Watched), true
+}
+
+// typical returns the median and shortest time an opening lasted.
+func (s *openingStats) typical() (usual, shortest time.Duration) {
+	return median(s.Durations), slices.Min(s.Durations)
+}
+
+// describe explains how long the section took to first open, or how long it has
+// been watched without opening, and how long its openings lasted.
+func (s *openingStats) describe(now time.Time) []string {
+	var lines []string
+	if after, ok := s.firstOpenAfter(); ok {
+		lines = append(lines, T("openings.first_after", humanDuration(after)))
+	} else if !s.Watched.IsZero() {
+		lines = append(lines, T("openings.not_yet", humanDuration(now.Sub(s.Watched))))
+	}
+	if len(s.Durations) > 0 {
+		usual, shortest := s.typical()
+		lines = append(lines, T("openings.lasted", len(s.Durations), humanDuration(usual), humanDuration(shortest)))
+	}
+	return lines
+}
+
+// buildOpeningStats gathers opening stats for crn (or every CRN when empty), in
+// the order CRNs first appear in the history.
+func buildOpeningStats(events []HistoryEvent, crn string) []*openingStats {
+	var order []*openingStats
+	byCRN := make(map[string]*openingStats)
+	for _, e := range events {
+		if (crn != "" && e.CRN != crn) || (e.Event != EventWatching && e.Event != EventOpen && e.Event != EventClosed) {
+			continue
+		}
+		s, ok := byCRN[e.CRN]
+		if !ok {
+			s = &openingStats{CRN: e.CRN}
+			byCRN[e.CRN] = s
+			order = append(order, s)
+		}
+		if e.Name != "" {
+			s.Name = e.Name
+		}
+
+		switch e.Event {
+		case EventWatching:
+			if s.Watched.IsZero() {
+				s.Watched = e.Time
+			}
+		case EventOpen:
+			if s.opened.IsZero() {
+				s.opened = e.Time
+			}
+			if s.FirstOpen.IsZero() && !s.Watched.IsZero() {
+				s.FirstOpen = e.Time
+			}
+		case EventClosed:
+			if !s.opened.IsZero() {
+				s.Durations = append(s.Durations, e.Time.Sub(s.opened))
+				s.opened = time.Time{}
+			}
+		}
+	}
+	return order
+}
+
+// recordWatching adds a watching event to the history for each course it hasn't
+// recorded being watched in that term, so time to first opening counts from
+// when the CRN was first watched rather than from the latest restart.
+func (c Config) recordWatching(courses []CourseStatus, now time.Time) {
+	if c.HistoryFile == "" {
+		return
+	}
+	events, err := readHistory(c.HistoryFile)
+	if err != nil {
+		PrintHistoryError(err)
+		return
+	}
+	watched := make(map[string]bool)
+	for _, e := range events {
+		if e.Event == EventWatching {
+			watched[e.CRN+"/"+e.Term] = true
+		}
+	}
+	for _, course := range courses {
+		if !watched[course.CRN+"/"+c.termOf(course)] {
+			c.recordHistory(EventWatching, course, now)
+		}
+	}
+}
+
+// openingStatsByCRN reads the opening stats for the weekly summary, or nil when
+// there's no history to read.
+func (c Config) openingStatsByCRN() map[string]*openingStats {
+	if c.HistoryFile == "" {
+		return nil
+	}
+	events, err := readHistory(c.HistoryFile)
+	if err != nil {
+		PrintHistoryError(err)
+		return nil
+	}
+	stats := make(map[string]*openingStats)
+	for _, s := range buildOpeningStats(events, "") {
+		stats[s.CRN] = s
+	}
+	return stats
+}
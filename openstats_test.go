@@ -0,0 +1,120 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// buildOpeningStats tests
+// ===================
+
+func TestBuildOpeningStats(t *testing.T) {
+	start := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	events := []HistoryEvent{
+		{Time: start, Event: EventWatching, CRN: "12345", Name: "Intro to Testing"},
+		{Time: start, Event: EventWatching, CRN: "67890"},
+		{Time: start.Add(50 * time.Hour), Event: EventOpen, CRN: "12345"},
+		{Time: start.Add(50*time.Hour + 4*time.Minute), Event: EventClosed, CRN: "12345"},
+		{Time: start.Add(70 * time.Hour), Event: EventOpen, CRN: "12345"},
+		{Time: start.Add(70*time.Hour + 45*time.Second), Event: EventClosed, CRN: "12345"},
+		{Time: start.Add(80 * time.Hour), Event: EventOpen, CRN: "12345"},
+		{Time: start.Add(80*time.Hour + 10*time.Minute), Event: EventClosed, CRN: "12345"},
+	}
+
+	stats := buildOpeningStats(events, "")
+	if len(stats) != 2 || stats[0].CRN != "12345" || stats[1].CRN != "67890" {
+		t.Fatalf("got %+v, want stats for 12345 then 67890", stats)
+	}
+	s := stats[0]
+	if after, ok := s.firstOpenAfter(); !ok || after != 50*time.Hour {
+		t.Errorf("first opening after %v, want 50h", after)
+	}
+	if usual, shortest := s.typical(); usual != 4*time.Minute || shortest != 45*time.Second {
+		t.Errorf("openings usually lasted %v, shortest %v; want 4m, 45s", usual, shortest)
+	}
+
+	lines := stats[1].describe(start.Add(100 * time.Hour))
+	if len(lines) != 1 || lines[0] != "Not open yet after 4d 4h of watching" {
+		t.Errorf("got %q for a CRN that never opened", lines)
+	}
+}
+
+func TestBuildOpeningStats_OpeningsBeforeWatchingDontCountAsFirst(t *testing.T) {
+	start := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	events := []HistoryEvent{
+		{Time: start, Event: EventOpen, CRN: "12345"},
+		{Time: start.Add(time.Minute), Event: EventClosed, CRN: "12345"},
+		{Time: start.Add(time.Hour), Event: EventWatching, CRN: "12345"},
+	}
+
+	s := buildOpeningStats(events, "12345")[0]
+	if _, ok := s.firstOpenAfter(); ok {
+		t.Error("an opening from before watching started shouldn't count as the first")
+	}
+	if len(s.Durations) != 1 {
+		t.Errorf("expected the earlier opening's duration to count, got %v", s.Durations)
+	}
+}
+
+// ===================
+// recordWatching tests
+// ===================
+
+func TestRecordWatching_OncePerCRN(t *testing.T) {
+	cfg := Config{Term: "202601", HistoryFile: filepath.Join(t.TempDir(), "history.jsonl")}
+	start := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	courses := []CourseStatus{{CRN: "12345"}}
+
+	cfg.recordWatching(courses, start)
+	cfg.recordWatching(append(courses, CourseStatus{CRN: "67890"}), start.Add(time.Hour))
+
+	events, err := readHistory(cfg.HistoryFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0].CRN != "12345" || !events[0].Time.Equal(start) || events[1].CRN != "67890" {
+		t.Errorf("got %+v, want one watching event for each CRN, the first kept", events)
+	}
+}
+
+func TestActivitySummary_OpeningStats(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	a := newActivity(start, []CourseStatus{{CRN: "12345"}})
+	stats := map[string]*openingStats{"12345": {
+		CRN:       "12345",
+		Watched:   start,
+		FirstOpen: start.Add(26 * time.Hour),
+		Durations: []time.Duration{3 * time.Minute},
+	}}
+
+	summary := a.summary(start.Add(SummaryPeriod), nil, stats)
+	for _, want := range []string{"First opened 1d 2h after watching started", "usually after 3m 0s"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+}
+
+func TestBuildTimeline_FirstOpening(t *testing.T) {
+	start := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	events := []HistoryEvent{
+		{Time: start, Event: EventWatching, CRN: "12345"},
+		{Time: start.Add(3 * time.Hour), Event: EventOpen, CRN: "12345"},
+		{Time: start.Add(4 * time.Hour), Event: EventClosed, CRN: "12345"},
+		{Time: start.Add(5 * time.Hour), Event: EventOpen, CRN: "12345"},
+	}
+
+	got := buildTimeline(events, "", time.Time{})
+	want := []string{"watching started", "opened, 3h 0m after watching started", "closed after 1h 0m open", "opened"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries %+v, want %d", len(got), got, len(want))
+	}
+	for i, text := range want {
+		if got[i].Text != text {
+			t.Errorf("entry %d = %q, want %q", i, got[i].Text, text)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Section is one section row from timetable search results.
+// Fields the results table doesn't include are left empty.
+type Section struct {
+	CRN        string `json:"crn"`
+	Course     string `json:"course,omitempty"` // subject and number, e.g. "CS-3114"
+	Title      string `json:"title,omitempty"`
+	Type       string `json:"type,omitempty"` // schedule type, e.g. "L" for lecture
+	Modality   string `json:"modality,omitempty"`
+	Credits    string `json:"credits,omitempty"`
+	Seats      string `json:"seats,omitempty"`
+	Capacity   string `json:"capacity,omitempty"`
+	Waitlist   string `json:"waitlist,omitempty"`
+	Instructor string `json:"instructor,omitempty"`
+	Days       string `json:"days,omitempty"`
+	Begin      string `json:"begin,omitempty"`
+	End        string `json:"end,omitempty"`
+	Location   string `json:"location,omitempty"`
+	Exam       string `json:"exam,omitempty"`
+	Comments   string `json:"comments,omitempty"` // from the comments row under the section, when the search asks for comments
+	GPA        string `json:"gpa,omitempty"`      // historical average GPA for the course and instructor, from gradesFile
+	Rating     string `json:"rating,omitempty"`   // instructor's RateMyProfessors rating
+	Term       string `json:"term,omitempty"`     // term code the section was found in, when searched for
+}
+
+// courseCodePattern matches the subject/number cell, e.g. "CS-3114" or "STAT-4705"
+var courseCodePattern = regexp.MustCompile(`^[A-Z]{2,5}-\d{4}`)
+
+// columns maps each known results-table column to its cell index
+type columns map[string]int
+
+// headerNames maps normalized header text to the column it labels
+var headerNames = map[string]string{
+	"crn":           "crn",
+	"course":        "course",
+	"title":         "title",
+	"schedule type": "type",
+	"modality":      "modality",
+	"cr hrs":        "credits",
+	"seats":         "seats",
+	"capacity":      "capacity",
+	"waitlist":      "waitlist",
+	"wait list":     "waitlist",
+	"instructor":    "instructor",
+	"days":          "days",
+	"begin":         "begin",
+	"end":           "end",
+	"location":      "location",
+	"exam":          "exam",
+}
+
+// defaultColumns is the layout assumed when the results table has no header row
+var defaultColumns = columns{"crn": 0, "course": 1, "title": 2}
+
+// detectColumns finds the header row of the results table and maps column names to cell indexes.
+// Falls back to defaultColumns when no header row is found.
+func detectColumns(table *goquery.Selection) columns {
+	if layout, ok := findHeader(table); ok {
+		return layout
+	}
+	return defaultColumns
+}
+
+// findHeader looks for a header row naming at least the CRN and title columns.
+func findHeader(table *goquery.Selection) (columns, bool) {
+	var layout columns
+	table.Find("tr").EachWithBreak(func(i int, row *goquery.Selection) bool {
+		found := columns{}
+		row.Children().Each(func(j int, cell *goquery.Selection) {
+			name := strings.ToLower(strings.Join(strings.Fields(cell.Text()), " "))
+			if col, ok := headerNames[name]; ok {
+				found[col] = j
+			}
+		})
+		if _, ok := found["crn"]; ok {
+			if _, ok := found["title"]; ok {
+				layout = found
+				return false
+			}
+		}
+		return true
+	})
+	return layout, layout != nil
+}
+
+// cellText returns the trimmed, whitespace-collapsed text of the named column, or "" if absent.
+func (cols columns) cellText(cells *goquery.Selection, col string) string {
+	idx, ok := cols[col]
+	if !ok || idx >= cells.Length() {
+		return ""
+	}
+	return strings.Join(strings.Fields(cells.Eq(idx).Text()), " ")
+}
+
+// commentPrefix matches the label the timetable puts in front of a section's comments
+var commentPrefix = regexp.MustCompile(`^Comments for CRN \d+:\s*`)
+
+// parseSections extracts every section row from the results table.
+// Header rows, comment rows, and "Additional Times" continuation rows are skipped
+// because their first cell is not a CRN. A comment row, a single cell spanning
+// the table, is kept as the comments of the section above it.
+func parseSections(doc *goquery.Document) []Section {
+	table := doc.Find(".dataentrytable")
+	cols := detectColumns(table)
+
+	var sections []Section
+	table.Find("tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.ChildrenFiltered("td")
+		crn := cols.cellText(cells, "crn")
+		if validateCRN(crn) != nil {
+			if cells.Length() == 1 && len(sections) > 0 {
+				sections[len(sections)-1].addComment(cells.Text())
+			}
+			return
+		}
+
+		s := Section{
+			CRN:        crn,
+			Course:     cols.cellText(cells, "course"),
+			Title:      cols.cellText(cells, "title"),
+			Type:       cols.cellText(cells, "type"),
+			Modality:   cols.cellText(cells, "modality"),
+			Credits:    cols.cellText(cells, "credits"),
+			Seats:      cols.cellText(cells, "seats"),
+			Capacity:   cols.cellText(cells, "capacity"),
+			Waitlist:   cols.cellText(cells, "waitlist"),
+			Instructor: cols.cellText(cells, "instructor"),
+			Days:       cols.cellText(cells, "days"),
+			Begin:      cols.cellText(cells, "begin"),
+			End:        cols.cellText(cells, "end"),
+			Location:   cols.cellText(cells, "location"),
+			Exam:       cols.cellText(cells, "exam"),
+		}
+		s.verifyTitle(cells)
+		sections = append(sections, s)
+	})
+	return sections
+}
+
+// addComment appends a comment row's text to the section's comments.
+func (s *Section) addComment(text string) {
+	text = commentPrefix.ReplaceAllString(strings.Join(strings.Fields(text), " "), "")
+	if text == "" {
+		return
+	}
+	if s.Comments != "" {
+		s.Comments += " "
+	}
+	s.Comments += text
+}
+
+// verifyTitle checks the title against the subject/number cell. If the title cell
+// holds a course code, the row is shifted, so the title is taken from the cell
+// following whichever cell holds the course code.
+func (s *Section) verifyTitle(cells *goquery.Selection) {
+	if courseCodePattern.MatchString(s.Course) && !courseCodePattern.MatchString(s.Title) {
+		return
+	}
+
+	cells.EachWithBreak(func(i int, cell *goquery.Selection) bool {
+		text := strings.Join(strings.Fields(cell.Text()), " ")
+		if !courseCodePattern.MatchString(text) || i+1 >= cells.Length() {
+			return true
+		}
+		s.Course = text
+		s.Title = strings.Join(strings.Fields(cells.Eq(i+1).Text()), " ")
+		return false
+	})
+}
+
+// Meeting describes when the section meets, e.g. "M W F 10:10AM-11:00AM".
+// Returns just the days (or "") for sections without scheduled times.
+func (s Section) Meeting() string {
+	if s.Begin == "" {
+		return s.Days
+	}
+	return fmt.Sprintf("%s %s-%s", s.Days, s.Begin, s.End)
+}
+
+// findSection returns the section whose CRN cell exactly matches crn.
+func findSection(doc *goquery.Document, crn string) (Section, bool) {
+	for _, s := range parseSections(doc) {
+		if s.CRN == crn {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// resultsHTML mirrors the timetable results layout: a header row, a section row,
+// an "Additional Times" continuation row, and a comments row.
+const resultsHTML = `
+<table class="dataentrytable">
+	<tr>
+		<td class="dedefault">CRN</td><td class="dedefault">Course</td><td class="dedefault">Title</td>
+		<td class="dedefault">Schedule Type</td><td class="dedefault">Modality</td><td class="dedefault">Cr Hrs</td>
+		<td class="dedefault">Capacity</td><td class="dedefault">Instructor</td><td class="dedefault">Days</td>
+		<td class="dedefault">Begin</td><td class="dedefault">End</td><td class="dedefault">Location</td>
+		<td class="dedefault">Exam</td>
+	</tr>
+	<tr>
+		<td><a href="#"><b>13466</b></a></td><td>CS-3114</td><td>Data Structures and Algorithms</td>
+		<td>L</td><td>Face-to-Face Instruction</td><td>3</td>
+		<td>120</td><td>C Shaffer</td><td>M W F</td>
+		<td>10:10AM</td><td>11:00AM</td><td>MCB 100</td>
+		<td>08M</td>
+	</tr>
+	<tr>
+		<td></td><td></td><td></td><td></td><td></td><td>* Additional Times *</td>
+		<td></td><td></td><td>T</td><td>2:00PM</td><td>2:50PM</td><td>MCB 200</td><td></td>
+	</tr>
+	<tr><td colspan="13">Comments for CRN 13466: Restricted to majors</td></tr>
+</table>`
+
+func parseHTML(t *testing.T, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// ===================
+// parseSections tests
+// ===================
+
+func TestParseSections_HeaderLayout(t *testing.T) {
+	sections := parseSections(parseHTML(t, resultsHTML))
+
+	if len(sections) != 1 {
+		t.Fatalf("expected 1 section (continuation and comment rows skipped), got %d", len(sections))
+	}
+
+	s := sections[0]
+	if s.CRN != "13466" || s.Course != "CS-3114" || s.Title != "Data Structures and Algorithms" {
+		t.Errorf("got %+v", s)
+	}
+	if s.Comments != "Restricted to majors" {
+		t.Errorf("Comments = %q, want %q", s.Comments, "Restricted to majors")
+	}
+	if s.Instructor != "C Shaffer" || s.Days != "M W F" || s.Location != "MCB 100" {
+		t.Errorf("schedule fields not parsed: %+v", s)
+	}
+}
+
+func TestParseSections_NoHeaderUsesDefaultLayout(t *testing.T) {
+	sections := parseSections(parseHTML(t, `<table class="dataentrytable"><tr><td>12345</td><td>001</td><td>Intro to Testing</td></tr></table>`))
+
+	if len(sections) != 1 || sections[0].Title != "Intro to Testing" {
+		t.Errorf("got %+v, want one section titled Intro to Testing", sections)
+	}
+}
+
+func TestParseSections_ShiftedRowUsesCourseCodeCell(t *testing.T) {
+	// an extra leading cell pushes the course code into the title column
+	sections := parseSections(parseHTML(t, `
+		<table class="dataentrytable">
+			<tr><td>CRN</td><td>Course</td><td>Title</td></tr>
+			<tr><td>12345</td><td>*</td><td>MATH-1225</td><td>Calculus of a Single Variable</td></tr>
+		</table>`))
+
+	if len(sections) != 1 {
+		t.Fatalf("expected 1 section, got %d", len(sections))
+	}
+	if sections[0].Course != "MATH-1225" || sections[0].Title != "Calculus of a Single Variable" {
+		t.Errorf("got %+v", sections[0])
+	}
+}
+
+// ===================
+// findSection tests
+// ===================
+
+func TestFindSection_ExactCRNMatch(t *testing.T) {
+	doc := parseHTML(t, `
+		<table class="dataentrytable">
+			<tr><td>112345</td><td>CS-1000</td><td>Wrong Course</td></tr>
+			<tr><td>12345</td><td>CS-2000</td><td>Right Course</td></tr>
+		</table>`)
+
+	s, ok := findSection(doc, "12345")
+	if !ok {
+		t.Fatal("expected to find CRN 12345")
+	}
+	if s.Title != "Right Course" {
+		t.Errorf("got %q, want %q", s.Title, "Right Course")
+	}
+}
+
+func TestFindSection_Missing(t *testing.T) {
+	doc := parseHTML(t, resultsHTML)
+	if _, ok := findSection(doc, "99999"); ok {
+		t.Error("expected CRN 99999 to be missing")
+	}
+}
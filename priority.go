@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Priority is how urgently a watched CRN is checked and announced
+type Priority string
+
+const (
+	PriorityHigh   Priority = "high"
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low"
+)
+
+// MinCheckInterval is the shortest time allowed between checks of one CRN.
+// High-priority CRNs are checked more often, but never faster than this,
+// so raising a CRN's priority can't hammer the timetable.
+const MinCheckInterval = 10 * time.Second
+
+// validatePriorities checks that every priority names a known tier and a watched CRN.
+func (c Config) validatePriorities() error {
+	for crn, p := range c.Priorities {
+		switch Priority(p) {
+		case PriorityHigh, PriorityNormal, PriorityLow:
+		default:
+			return fmt.Errorf("invalid priority %q for CRN %s: must be high, normal, or low", p, crn)
+		}
+		if !slices.Contains(c.CRNs, crn) {
+			return fmt.Errorf("priorities lists CRN %s, which is not in crns", crn)
+		}
+	}
+	return nil
+}
+
+// priority returns the configured tier for a CRN, defaulting to normal.
+func (c Config) priority(crn string) Priority {
+	if p, ok := c.Priorities[crn]; ok {
+		return Priority(p)
+	}
+	return PriorityNormal
+}
+
+// checkInterval returns how long to wait between checks of a CRN with the given priority.
+// Normal CRNs use checkInterval, high-priority CRNs are checked three times as often
+// (but no faster than MinCheckInterval), and low-priority CRNs a third as often.
+func (c Config) checkInterval(p Priority) time.Duration {
+	interval := time.Duration(c.CheckInterval) * time.Second
+	switch p {
+	case PriorityHigh:
+		return min(interval, max(interval/3, MinCheckInterval))
+	case PriorityLow:
+		return interval * 3
+	default:
+		return interval
+	}
+}
+
+// nextDue returns when the next unfound course is due to be checked.
+func nextDue(courses []CourseStatus) time.Time {
+	var next time.Time
+	for _, course := range courses {
+		if course.Found {
+			continue
+		}
+		if next.IsZero() || course.nextCheck.Before(next) {
+			next = course.nextCheck
+		}
+	}
+	return next
+}
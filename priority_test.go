@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// checkInterval tests
+// ===================
+
+func TestCheckInterval_Tiers(t *testing.T) {
+	cfg := Config{CheckInterval: 60}
+	tests := []struct {
+		priority Priority
+		want     time.Duration
+	}{
+		{PriorityHigh, 20 * time.Second},
+		{PriorityNormal, 60 * time.Second},
+		{PriorityLow, 180 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.checkInterval(tt.priority); got != tt.want {
+			t.Errorf("checkInterval(%s) = %v, want %v", tt.priority, got, tt.want)
+		}
+	}
+}
+
+func TestCheckInterval_HighRespectsMinimum(t *testing.T) {
+	cfg := Config{CheckInterval: 15}
+	if got := cfg.checkInterval(PriorityHigh); got != MinCheckInterval {
+		t.Errorf("got %v, want %v", got, MinCheckInterval)
+	}
+
+	// never slower than a normal CRN, even when checkInterval is below the minimum
+	cfg.CheckInterval = 5
+	if got := cfg.checkInterval(PriorityHigh); got != 5*time.Second {
+		t.Errorf("got %v, want %v", got, 5*time.Second)
+	}
+}
+
+// ===================
+// priorities config tests
+// ===================
+
+func TestLoadConfig_Priorities(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345", "67890"], "priorities": {"12345": "high"}}`)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.priority("12345"); got != PriorityHigh {
+		t.Errorf("priority(12345) = %q, want high", got)
+	}
+	if got := cfg.priority("67890"); got != PriorityNormal {
+		t.Errorf("priority(67890) = %q, want normal", got)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidPriority(t *testing.T) {
+	tests := map[string]string{
+		"unknown tier":  `{"crns": ["12345"], "priorities": {"12345": "urgent"}}`,
+		"unwatched CRN": `{"crns": ["12345"], "priorities": {"67890": "high"}}`,
+	}
+
+	for name, content := range tests {
+		if _, err := loadConfig(createTempConfig(t, content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// ===================
+// nextDue tests
+// ===================
+
+func TestNextDue_SkipsFoundCourses(t *testing.T) {
+	now := time.Now()
+	courses := []CourseStatus{
+		{CRN: "11111", Found: true, nextCheck: now},
+		{CRN: "22222", nextCheck: now.Add(30 * time.Second)},
+		{CRN: "33333", nextCheck: now.Add(10 * time.Second)},
+	}
+
+	if got := nextDue(courses); !got.Equal(now.Add(10 * time.Second)) {
+		t.Errorf("got %v, want the 33333 check time", got)
+	}
+}
+
+// ===================
+// urgent alert tests
+// ===================
+
+func TestNotifyOpen_HighPriorityIsUrgent(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601"}
+	sender := &MockEmailSender{}
+
+	cfg.notifyOpen(newMemoryStateStore(), sender, CourseStatus{CRN: "12345", Name: "Intro to Testing", Priority: PriorityHigh})
+
+	if len(sender.Sent) != 1 || !strings.HasPrefix(sender.Sent[0].Subject, "[URGENT]") {
+		t.Errorf("expected one urgent email, got %+v", sender.Sent)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RateLimit caps how many alerts one channel sends in a window, so a flapping
+// seat or a big course watch can't run up a paid channel's bill or trip a
+// provider's spam limits.
+type RateLimit struct {
+	Max     int  `json:"max"`     // most alerts sent per window
+	Minutes int  `json:"minutes"` // length of the window
+	PerCRN  bool `json:"perCrn"`  // count each CRN's alerts separately
+}
+
+// ChannelLimits are the rate limits by channel name, e.g. "sms" or "email"
+type ChannelLimits map[string]RateLimit
+
+// channelKey is how a channel is named in rateLimits.
+func channelKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// validateRateLimits checks each limit's window.
+func (c Config) validateRateLimits() error {
+	for channel, limit := range c.RateLimits {
+		if limit.Max < 1 || limit.Minutes < 1 {
+			return fmt.Errorf("rateLimits: %s needs a max and minutes of at least 1", channel)
+		}
+	}
+	return nil
+}
+
+// checkChannelNames checks that every rate limit and minimum severity names a
+// configured channel, once the channels are set up, so a typo doesn't silently
+// leave a channel unlimited or unfiltered. Webhooks, which are named in the
+// config, mustn't take another channel's name.
+func (c Config) checkChannelNames() error {
+	if err := c.checkWebhookNames(); err != nil {
+		return err
+	}
+	var channels []string
+	for _, name := range c.channels() {
+		channels = append(channels, channelKey(name))
+	}
+	check := func(setting, channel string) error {
+		if !slices.Contains(channels, channelKey(channel)) {
+			return fmt.Errorf("%s: %q is not a configured channel (have %s)", setting, channel, strings.Join(channels, ", "))
+		}
+		return nil
+	}
+	for channel := range c.RateLimits {
+		if err := check("rateLimits", channel); err != nil {
+			return err
+		}
+	}
+	for channel := range c.MinSeverity {
+		if err := check("minSeverity", channel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rateLimiter tracks recent alerts per channel to enforce rateLimits. Alerts are
+// counted in a StateStore, so redundant instances sharing one spend a single budget.
+type rateLimiter struct {
+	limits map[string]RateLimit // by channelKey
+	store  StateStore
+}
+
+// newRateLimiter returns a limiter for the given limits that counts alerts in
+// store, or in this process when store is nil. Returns nil when there are no limits.
+func newRateLimiter(limits ChannelLimits, store StateStore) *rateLimiter {
+	if len(limits) == 0 {
+		return nil
+	}
+	if store == nil {
+		store = newMemoryStateStore()
+	}
+	r := &rateLimiter{limits: make(map[string]RateLimit), store: store}
+	for channel, limit := range limits {
+		r.limits[channelKey(channel)] = limit
+	}
+	return r
+}
+
+// allow reports whether the channel can send an alert about crn at now, and if so
+// counts it. A nil limiter allows everything. If the store can't be reached the
+// alert is allowed, since an alert over the limit beats a missed one.
+func (r *rateLimiter) allow(channel, crn string, now time.Time) bool {
+	if r == nil {
+		return true
+	}
+	limit, ok := r.limits[channelKey(channel)]
+	if !ok {
+		return true
+	}
+
+	key := "ratelimit:" + channelKey(channel)
+	if limit.PerCRN {
+		key += "/" + crn
+	}
+	ok, err := r.store.Spend(key, limit.Max, time.Duration(limit.Minutes)*time.Minute, now)
+	if err != nil {
+		PrintStateError(err)
+		return true
+	}
+	return ok
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// ===================
+// Rate limit config tests
+// ===================
+
+func TestLoadConfig_ErrorInvalidRateLimit(t *testing.T) {
+	for _, limit := range []string{`{"max": 0, "minutes": 10}`, `{"max": 1}`} {
+		path := createTempConfig(t, `{"crns": ["12345"], "rateLimits": {"email": `+limit+`}}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", limit)
+		}
+	}
+}
+
+func TestCheckRateLimitChannels(t *testing.T) {
+	cfg := Config{Email: "test@example.com", notifiers: []Notifier{&MockNotifier{}}, RateLimits: ChannelLimits{"Email": {Max: 1, Minutes: 1}, "mock": {Max: 1, Minutes: 1}}}
+	if err := cfg.checkChannelNames(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cfg.RateLimits = ChannelLimits{"sms": {Max: 1, Minutes: 10}}
+	if err := cfg.checkChannelNames(); err == nil {
+		t.Error("expected error for a limit on a channel that isn't configured")
+	}
+}
+
+// ===================
+// rateLimiter tests
+// ===================
+
+func TestRateLimiter_Window(t *testing.T) {
+	r := newRateLimiter(ChannelLimits{"SMS": {Max: 2, Minutes: 10}}, nil)
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	if !r.allow("SMS", "12345", now) || !r.allow("SMS", "67890", now.Add(time.Minute)) {
+		t.Fatal("expected the first two alerts to be allowed")
+	}
+	if r.allow("sms", "12345", now.Add(2*time.Minute)) {
+		t.Error("expected the third alert in the window to be held back")
+	}
+	if !r.allow("SMS", "12345", now.Add(10*time.Minute)) {
+		t.Error("expected an alert once the first one left the window")
+	}
+	if !r.allow("Bark", "12345", now) {
+		t.Error("expected channels without a limit to be unlimited")
+	}
+}
+
+func TestRateLimiter_PerCRN(t *testing.T) {
+	r := newRateLimiter(ChannelLimits{"sms": {Max: 1, Minutes: 10, PerCRN: true}}, nil)
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	if !r.allow("SMS", "12345", now) || !r.allow("SMS", "67890", now) {
+		t.Fatal("expected one alert for each CRN")
+	}
+	if r.allow("SMS", "12345", now.Add(5*time.Minute)) {
+		t.Error("expected a second alert for the same CRN to be held back")
+	}
+}
+
+func TestRateLimiter_Nil(t *testing.T) {
+	var r *rateLimiter
+	if !r.allow("SMS", "12345", time.Now()) || newRateLimiter(nil, nil) != nil {
+		t.Error("expected no limiter without limits")
+	}
+}
+
+func TestRateLimiter_SharedBetweenInstances(t *testing.T) {
+	store := &fileStateStore{dir: t.TempDir()}
+	limits := ChannelLimits{"sms": {Max: 1, Minutes: 10}}
+	a, b := newRateLimiter(limits, store), newRateLimiter(limits, store)
+
+	if !a.allow("SMS", "12345", time.Now()) {
+		t.Fatal("expected the first alert to be allowed")
+	}
+	if b.allow("SMS", "67890", time.Now()) {
+		t.Error("expected the other instance to find the budget spent")
+	}
+}
+
+func TestNotify_RateLimited(t *testing.T) {
+	limited, unlimited := &MockNotifier{}, &countingNotifier{name: "Other"}
+	cfg := Config{
+		Email:     "test@example.com",
+		notifiers: []Notifier{limited, unlimited},
+		limiter:   newRateLimiter(ChannelLimits{"mock": {Max: 1, Minutes: 10, PerCRN: true}, "email": {Max: 1, Minutes: 10}}, nil),
+	}
+	sender := &MockEmailSender{}
+
+	for range 3 {
+		cfg.notify(sender, Alert{Event: EventOpen, Subject: "Open", CRN: "12345"})
+	}
+
+	if len(sender.Sent) != 1 || len(limited.Sent) != 1 {
+		t.Errorf("expected limited channels to send once, got %d emails and %d notifications", len(sender.Sent), len(limited.Sent))
+	}
+	if unlimited.count != 3 {
+		t.Errorf("expected the unlimited channel to send every alert, got %d", unlimited.count)
+	}
+}
+
+// countingNotifier counts alerts under a configurable channel name
+type countingNotifier struct {
+	name  string
+	count int
+}
+
+func (n *countingNotifier) Name() string { return n.name }
+
+func (n *countingNotifier) Notify(a Alert) error {
+	n.count++
+	return nil
+}
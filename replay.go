@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// recording is every response seen for one request, stored as one JSON file per request
+type recording struct {
+	Method    string             `json:"method"`
+	URL       string             `json:"url"`
+	Body      string             `json:"body"`
+	Responses []recordedResponse `json:"responses"`
+}
+
+// recordedResponse is a single timetable response captured by record mode
+type recordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// requestKey identifies a request by method, URL, and body, so identical searches share a recording.
+// Form bodies built with url.Values.Encode are sorted, so equal payloads give equal keys.
+func requestKey(req *http.Request) (key string, body []byte, err error) {
+	if req.Body != nil {
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return "", nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String() + "\n" + string(body)))
+	return hex.EncodeToString(sum[:8]), body, nil
+}
+
+// recordingTransport passes requests through to the timetable and appends every
+// response to a recording file in dir.
+type recordingTransport struct {
+	base http.RoundTripper
+	dir  string
+	mu   sync.Mutex
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, reqBody, err := requestKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	path := filepath.Join(t.dir, key+".json")
+	rec := recording{Method: req.Method, URL: req.URL.String(), Body: string(reqBody)}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("record: corrupt recording %s: %w", path, err)
+		}
+	}
+	rec.Responses = append(rec.Responses, recordedResponse{Status: resp.StatusCode, Header: resp.Header, Body: string(respBody)})
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+
+	return resp, nil
+}
+
+// replayTransport answers requests from recordings in dir without touching the network.
+// Repeated requests step through the recorded responses in order and then keep
+// returning the last one, so a recorded session plays back the way it happened.
+type replayTransport struct {
+	dir string
+
+	mu     sync.Mutex
+	served map[string]int
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, _, err := requestKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(t.dir, key+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("replay: no recorded response for %s %s", req.Method, req.URL)
+	}
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("replay: corrupt recording %s: %w", path, err)
+	}
+	if len(rec.Responses) == 0 {
+		return nil, fmt.Errorf("replay: recording %s has no responses", path)
+	}
+
+	t.mu.Lock()
+	if t.served == nil {
+		t.served = make(map[string]int)
+	}
+	i := min(t.served[key], len(rec.Responses)-1)
+	t.served[key]++
+	t.mu.Unlock()
+
+	r := rec.Responses[i]
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(r.Body))),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}, nil
+}
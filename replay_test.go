@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// ===================
+// record/replay tests
+// ===================
+
+func TestRecordThenReplay(t *testing.T) {
+	responses := []string{
+		`<table class="dataentrytable"></table>`,
+		`<table class="dataentrytable"><tr><td>12345</td></tr></table>`,
+	}
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`<html><form></form></html>`))
+			return
+		}
+		w.Write([]byte(responses[min(hits, len(responses)-1)]))
+		hits++
+	}))
+
+	dir := t.TempDir()
+	recorder := Config{BaseURL: server.URL, Campus: "0", Term: "202601", RequestTimeoutSeconds: 5, RecordDir: dir}
+	recorder.session = recorder.newSession()
+	for _, want := range []bool{false, true} {
+		open, err := recorder.checkSectionOpen("12345")
+		if err != nil {
+			t.Fatalf("record: unexpected error: %v", err)
+		}
+		if open != want {
+			t.Errorf("record: open = %v, want %v", open, want)
+		}
+	}
+	server.Close()
+
+	// replay without the server, stepping through the recorded responses
+	player := Config{BaseURL: server.URL, Campus: "0", Term: "202601", RequestTimeoutSeconds: 5, ReplayDir: dir}
+	player.session = player.newSession()
+	for _, want := range []bool{false, true, true} {
+		open, err := player.checkSectionOpen("12345")
+		if err != nil {
+			t.Fatalf("replay: unexpected error: %v", err)
+		}
+		if open != want {
+			t.Errorf("replay: open = %v, want %v", open, want)
+		}
+	}
+}
+
+func TestReplay_MissingRecording(t *testing.T) {
+	cfg := Config{BaseURL: "http://timetable.invalid/search", Campus: "0", Term: "202601", RequestTimeoutSeconds: 5, ReplayDir: t.TempDir()}
+	cfg.session = cfg.newSession()
+
+	if _, err := cfg.checkSectionOpen("12345"); err == nil {
+		t.Error("expected error for request with no recording")
+	}
+}
+
+func TestLoadConfig_ErrorRecordAndReplay(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "recordDir": "a", "replayDir": "b"}`)
+	defer os.Remove(path)
+
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error when both recordDir and replayDir are set")
+	}
+}
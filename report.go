@@ -0,0 +1,191 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxReportErrors is how many distinct errors the exit report lists; the rest are counted under "other"
+const maxReportErrors = 20
+
+// errInterrupted is the outcome reported when the watcher is stopped by Ctrl-C or SIGTERM
+var errInterrupted = exitError{code: ExitInterrupted, err: errors.New("interrupted")}
+
+// exitReport is written when the watcher stops, for looking back on a
+// registration period once drop/add is over
+type exitReport struct {
+	Started       time.Time                  `json:"started"`
+	Stopped       time.Time                  `json:"stopped"`
+	ExitCode      int                        `json:"exitCode"`
+	Error         string                     `json:"error,omitempty"` // why the watcher stopped, if it failed or was interrupted
+	Checks        int                        `json:"checks"`
+	CheckErrors   int                        `json:"checkErrors"`
+	CRNs          []crnStatus                `json:"crns"`
+	Courses       []courseState              `json:"courses,omitempty"`
+	Events        []HistoryEvent             `json:"events"`        // openings, closings, and listing changes, oldest first
+	Notifications map[string]*deliveryCounts `json:"notifications"` // by channel
+	Errors        []errorCount               `json:"errors"`        // check errors, most frequent first
+}
+
+// deliveryCounts are one channel's alert outcomes
+type deliveryCounts struct {
+	Sent    int `json:"sent"`
+	Failed  int `json:"failed"`
+	Limited int `json:"limited"` // held back by the channel's rate limit
+}
+
+// errorCount is how often one check error happened
+type errorCount struct {
+	Error string `json:"error"`
+	Count int    `json:"count"`
+}
+
+// runReport collects what the exit report needs over the whole run. It is shared
+// by every copy of the config, so alerts sent from anywhere are counted.
+type runReport struct {
+	path string // where the report goes, "-" for stdout
+
+	mu            sync.Mutex
+	events        []HistoryEvent
+	notifications map[string]*deliveryCounts
+	errors        map[string]int
+	written       bool
+}
+
+// newRunReport returns a report written to path, or nil when no report is wanted.
+func newRunReport(path string) *runReport {
+	if path == "" {
+		return nil
+	}
+	return &runReport{path: path, notifications: make(map[string]*deliveryCounts), errors: make(map[string]int)}
+}
+
+// event records an opening, closing, or listing change. A nil report ignores it.
+func (r *runReport) event(e HistoryEvent) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+// checkError counts a failed check.
+func (r *runReport) checkError(err error) {
+	if r == nil || err == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors[err.Error()]++
+}
+
+// delivered counts one alert sent, or attempted, on a channel.
+func (r *runReport) delivered(channel string, err error) {
+	r.count(channel, func(d *deliveryCounts) {
+		if err != nil {
+			d.Failed++
+		} else {
+			d.Sent++
+		}
+	})
+}
+
+// limited counts one alert a channel's rate limit held back.
+func (r *runReport) limited(channel string) {
+	r.count(channel, func(d *deliveryCounts) { d.Limited++ })
+}
+
+// count updates a channel's delivery counts.
+func (r *runReport) count(channel string, update func(*deliveryCounts)) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.notifications[channel]
+	if !ok {
+		d = &deliveryCounts{}
+		r.notifications[channel] = d
+	}
+	update(d)
+}
+
+// build assembles the report from what was collected and the watch's final status.
+func (r *runReport) build(status watchStatus, stopErr error) exitReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := exitReport{
+		Started:       status.Started,
+		Stopped:       status.Updated,
+		ExitCode:      exitCode(stopErr),
+		CRNs:          status.CRNs,
+		Courses:       status.Courses,
+		Events:        slices.Clone(r.events),
+		Notifications: make(map[string]*deliveryCounts),
+		Errors:        []errorCount{},
+	}
+	for channel, d := range r.notifications {
+		counts := *d
+		report.Notifications[channel] = &counts
+	}
+	if stopErr != nil {
+		report.Error = stopErr.Error()
+	}
+	if report.Events == nil {
+		report.Events = []HistoryEvent{}
+	}
+	for _, crn := range status.CRNs {
+		report.Checks += crn.Checks
+		report.CheckErrors += crn.Errors
+	}
+
+	for msg, n := range r.errors {
+		report.Errors = append(report.Errors, errorCount{Error: msg, Count: n})
+	}
+	slices.SortFunc(report.Errors, func(a, b errorCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Error, b.Error))
+	})
+	if len(report.Errors) > maxReportErrors {
+		other := errorCount{Error: "other"}
+		for _, e := range report.Errors[maxReportErrors:] {
+			other.Count += e.Count
+		}
+		report.Errors = append(report.Errors[:maxReportErrors], other)
+	}
+	return report
+}
+
+// write writes the report once; stopping by Ctrl-C and returning from Run both
+// try, and only the first counts.
+func (r *runReport) write(status watchStatus, stopErr error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	if r.written {
+		r.mu.Unlock()
+		return
+	}
+	r.written = true
+	r.mu.Unlock()
+
+	report := r.build(status, stopErr)
+	if r.path != "-" {
+		if err := writeFileAtomic(r.path, report); err != nil {
+			PrintReportError(err)
+		}
+		return
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		PrintReportError(err)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ===================
+// runReport tests
+// ===================
+
+func TestRunReport_Nil(t *testing.T) {
+	var r *runReport
+	r.event(HistoryEvent{Event: EventOpen})
+	r.checkError(errors.New("boom"))
+	r.delivered("email", nil)
+	r.limited("sms")
+	r.write(watchStatus{}, nil)
+	if newRunReport("") != nil {
+		t.Error("expected no report without a path")
+	}
+}
+
+func TestRunReport_Build(t *testing.T) {
+	r := newRunReport("-")
+	opened := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	r.event(HistoryEvent{Time: opened, Event: EventOpen, CRN: "12345"})
+	r.delivered("email", nil)
+	r.delivered("email", errors.New("rejected"))
+	r.limited("SMS")
+	r.checkError(errors.New("request failed"))
+	r.checkError(errors.New("request failed"))
+	r.checkError(errors.New("unexpected status"))
+	r.checkError(nil)
+
+	status := watchStatus{
+		Started: opened.Add(-time.Hour),
+		Updated: opened.Add(time.Hour),
+		CRNs:    []crnStatus{{CRN: "12345", Checks: 10, Errors: 2}, {CRN: "67890", Checks: 5, Errors: 1}},
+	}
+	report := r.build(status, nil)
+
+	if report.ExitCode != ExitOK || report.Error != "" {
+		t.Errorf("expected a clean exit, got %d %q", report.ExitCode, report.Error)
+	}
+	if report.Checks != 15 || report.CheckErrors != 3 {
+		t.Errorf("expected 15 checks with 3 errors, got %d with %d", report.Checks, report.CheckErrors)
+	}
+	if len(report.Events) != 1 || !report.Events[0].Time.Equal(opened) {
+		t.Errorf("expected the opening, got %+v", report.Events)
+	}
+	if email := report.Notifications["email"]; email == nil || email.Sent != 1 || email.Failed != 1 {
+		t.Errorf("expected one email sent and one failed, got %+v", email)
+	}
+	if sms := report.Notifications["SMS"]; sms == nil || sms.Limited != 1 {
+		t.Errorf("expected one text held back, got %+v", sms)
+	}
+	want := []errorCount{{"request failed", 2}, {"unexpected status", 1}}
+	if fmt.Sprint(report.Errors) != fmt.Sprint(want) {
+		t.Errorf("expected errors %v, got %v", want, report.Errors)
+	}
+}
+
+func TestRunReport_TooManyErrors(t *testing.T) {
+	r := newRunReport("-")
+	for i := range maxReportErrors + 5 {
+		r.checkError(fmt.Errorf("error %d", i))
+	}
+	report := r.build(watchStatus{}, nil)
+	if len(report.Errors) != maxReportErrors+1 {
+		t.Fatalf("expected %d errors and other, got %d", maxReportErrors, len(report.Errors))
+	}
+	if other := report.Errors[maxReportErrors]; other.Error != "other" || other.Count != 5 {
+		t.Errorf("expected 5 other errors, got %+v", other)
+	}
+}
+
+func TestRunReport_WriteOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	r := newRunReport(path)
+
+	r.write(watchStatus{}, errInterrupted)
+	r.write(watchStatus{}, nil)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report exitReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("report isn't JSON: %v", err)
+	}
+	if report.ExitCode != ExitInterrupted || report.Error != "interrupted" {
+		t.Errorf("expected the first outcome to win, got %d %q", report.ExitCode, report.Error)
+	}
+	if report.Events == nil || report.Errors == nil {
+		t.Error("expected empty lists rather than null")
+	}
+}
+
+// ===================
+// Recording into the report
+// ===================
+
+func TestRecordHistory_Report(t *testing.T) {
+	cfg := Config{report: newRunReport("-")}
+	course := CourseStatus{CRN: "12345", Name: "Data Structures"}
+	cfg.recordHistory(EventOpen, course, time.Now())
+	cfg.recordChange(EventInstructorChanged, course, "Smith", "Jones", time.Now())
+
+	if len(cfg.report.events) != 2 {
+		t.Fatalf("expected both events recorded without a history file, got %+v", cfg.report.events)
+	}
+	if cfg.report.events[1].Current != "Jones" {
+		t.Errorf("expected the change recorded, got %+v", cfg.report.events[1])
+	}
+}
+
+func TestNotify_Report(t *testing.T) {
+	cfg := Config{Email: "test@example.com", report: newRunReport("-"), notifiers: []Notifier{&MockNotifier{ShouldError: true}}}
+	cfg.notify(&MockEmailSender{}, Alert{Event: EventOpen, Subject: "open"})
+
+	if d := cfg.report.notifications["email"]; d == nil || d.Sent != 1 {
+		t.Errorf("expected the email counted, got %+v", d)
+	}
+	if d := cfg.report.notifications["Mock"]; d == nil || d.Failed != 1 {
+		t.Errorf("expected the failure counted, got %+v", d)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// pruneInterval is how often a running watcher prunes the history file
+const pruneInterval = 24 * time.Hour
+
+// HistoryRetention limits how much the history file keeps. Events that mark when
+// watching a CRN started, and enrollments, are kept for every watched term so that
+// pruning never changes what's watched or resets the time to first opening.
+type HistoryRetention struct {
+	Days  int `json:"days"`  // Drop events older than this many days (0 keeps them all)
+	MaxMB int `json:"maxMB"` // Drop the oldest events once the file is larger than this (0 for no limit)
+}
+
+// validate checks the limits aren't negative.
+func (r HistoryRetention) validate() error {
+	if r.Days < 0 {
+		return fmt.Errorf("historyRetention days must be positive, got %d", r.Days)
+	}
+	if r.MaxMB < 0 {
+		return fmt.Errorf("historyRetention maxMB must be positive, got %d", r.MaxMB)
+	}
+	return nil
+}
+
+// enabled reports whether any limit is set.
+func (r HistoryRetention) enabled() bool {
+	return r.Days > 0 || r.MaxMB > 0
+}
+
+// prune returns the events the limits keep, oldest first. protect reports whether
+// an event must be kept whatever its age or the file's size.
+func (r HistoryRetention) prune(events []HistoryEvent, protect func(HistoryEvent) bool, now time.Time) ([]HistoryEvent, error) {
+	kept := make([]HistoryEvent, 0, len(events))
+	sizes := make([]int, 0, len(events))
+	total := 0
+	for _, e := range events {
+		if r.Days > 0 && e.Time.Before(now.AddDate(0, 0, -r.Days)) && !protect(e) {
+			continue
+		}
+		line, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		kept = append(kept, e)
+		sizes = append(sizes, len(line)+1)
+		total += len(line) + 1
+	}
+
+	// then drop the oldest events until the file fits
+	limit := r.MaxMB << 20
+	if r.MaxMB == 0 || total <= limit {
+		return kept, nil
+	}
+	fitted := kept[:0]
+	for i, e := range kept {
+		if total > limit && !protect(e) {
+			total -= sizes[i]
+			continue
+		}
+		fitted = append(fitted, e)
+	}
+	return fitted, nil
+}
+
+// keepForever reports whether pruning must keep an event: when watching started, or
+// an enrollment, in a term still watched.
+func (c Config) keepForever(e HistoryEvent) bool {
+	if e.Event != EventWatching && e.Event != EventEnrolled {
+		return false
+	}
+	return e.Term == "" || slices.Contains(c.watchedTerms(), e.Term)
+}
+
+// pruneHistory rewrites the history file without the events the retention limits
+// drop, returning how many were removed and how many kept. It holds the history
+// lock from reading the file to replacing it, so an event appended meanwhile, by
+// this process or another, isn't lost.
+func (c Config) pruneHistory(r HistoryRetention, now time.Time) (removed, kept int, err error) {
+	unlock, err := lockFile(historyLockPath(c.HistoryFile))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prune history: %w", err)
+	}
+	defer unlock()
+
+	events, err := readHistory(c.HistoryFile)
+	if err != nil {
+		return 0, 0, err
+	}
+	pruned, err := r.prune(events, c.keepForever, now)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(pruned) == len(events) {
+		return 0, len(events), nil
+	}
+	if err := writeHistory(c.HistoryFile, pruned); err != nil {
+		return 0, 0, err
+	}
+	return len(events) - len(pruned), len(pruned), nil
+}
+
+// writeHistory replaces the history file with events. The new file is written
+// beside the old one and renamed over it, so a crash never leaves half a history.
+// The caller holds the history lock.
+func writeHistory(path string, events []HistoryEvent) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".history-*.jsonl")
+	if err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	enc := json.NewEncoder(f)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to prune history: %w", err)
+		}
+	}
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	return nil
+}
+
+// autoPruneHistory prunes the history file by the configured retention while
+// watching. Failures are reported but never stop monitoring.
+func (c Config) autoPruneHistory(now time.Time) {
+	if c.HistoryFile == "" || !c.HistoryRetention.enabled() {
+		return
+	}
+	removed, _, err := c.pruneHistory(c.HistoryRetention, now)
+	if err != nil {
+		PrintHistoryError(err)
+	} else if removed > 0 {
+		PrintHistoryPruned(removed)
+	}
+}
+
+// runHistoryPrune prunes the history file now, by the configured retention or the
+// limits given as flags.
+func runHistoryPrune(args []string) error {
+	fs := flag.NewFlagSet("history prune", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file`")
+	days := fs.Int("days", 0, "drop events older than this many `days` (defaults to historyRetention.days)")
+	maxMB := fs.Int("max-mb", 0, "drop the oldest events once the file is larger than this many `MB` (defaults to historyRetention.maxMB)")
+	fs.Parse(args)
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+	if err := cfg.resolveAutoTerms(time.Now()); err != nil {
+		return err
+	}
+	r := cfg.HistoryRetention
+	if *days != 0 {
+		r.Days = *days
+	}
+	if *maxMB != 0 {
+		r.MaxMB = *maxMB
+	}
+	if err := r.validate(); err != nil {
+		return configError(err)
+	}
+	if !r.enabled() {
+		return configError(fmt.Errorf("nothing to prune by: set --days or --max-mb, or historyRetention in the config"))
+	}
+
+	removed, kept, err := cfg.pruneHistory(r, time.Now())
+	if err != nil {
+		return err
+	}
+	PrintHistoryPruneResult(cfg.HistoryFile, removed, kept)
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ===================
+// retention tests
+// ===================
+
+func TestHistoryRetention_ByAge(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	events := []HistoryEvent{
+		{Time: now.AddDate(0, 0, -100), Event: EventWatching, CRN: "12345", Term: "202601"},
+		{Time: now.AddDate(0, 0, -90), Event: EventObserved, CRN: "12345", Term: "202601", Seats: "0"},
+		{Time: now.AddDate(0, 0, -1), Event: EventObserved, CRN: "12345", Term: "202601", Seats: "1"},
+	}
+	protect := func(e HistoryEvent) bool { return e.Event == EventWatching }
+
+	kept, err := (HistoryRetention{Days: 30}).prune(events, protect, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(kept) != 2 || kept[0].Event != EventWatching || kept[1].Seats != "1" {
+		t.Errorf("expected the old observation dropped and the start of watching kept, got %+v", kept)
+	}
+}
+
+func TestHistoryRetention_BySize(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	var events []HistoryEvent
+	for i := range 20000 { // well over 1 MB
+		events = append(events, HistoryEvent{Time: now.Add(time.Duration(i) * time.Minute), Event: EventObserved, CRN: "12345", Term: "202601", Seats: "0", Capacity: "30"})
+	}
+	kept, err := (HistoryRetention{MaxMB: 1}).prune(events, func(HistoryEvent) bool { return false }, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(kept) == 0 || len(kept) == len(events) {
+		t.Fatalf("expected some events dropped, kept %d of %d", len(kept), len(events))
+	}
+	if last := kept[len(kept)-1]; !last.Time.Equal(events[len(events)-1].Time) {
+		t.Errorf("expected the newest events kept, last is %v", last.Time)
+	}
+}
+
+func TestPruneHistory_KeepsWatchedTermEnrollments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	old := now.AddDate(-1, 0, 0)
+	appendHistory(path, HistoryEvent{Time: old, Event: EventEnrolled, CRN: "12345", Term: "202601"})
+	appendHistory(path, HistoryEvent{Time: old, Event: EventEnrolled, CRN: "12346", Term: "202501"})
+	appendHistory(path, HistoryEvent{Time: old, Event: EventOpen, CRN: "12345", Term: "202601"})
+
+	cfg := Config{Term: "202601", HistoryFile: path}
+	removed, kept, err := cfg.pruneHistory(HistoryRetention{Days: 30}, now)
+	if err != nil || removed != 2 || kept != 1 {
+		t.Fatalf("expected two events removed and one kept, got %d, %d, %v", removed, kept, err)
+	}
+	enrolled, _ := cfg.enrolledCRNs()
+	if !enrolled["12345"] {
+		t.Error("expected the enrollment in the watched term to survive pruning")
+	}
+}
+
+func TestPruneHistory_KeepsConcurrentAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Now()
+	appendHistory(path, HistoryEvent{Time: now.AddDate(-1, 0, 0), Event: EventOpen, CRN: "12345", Term: "202601"})
+
+	unlock, err := lockFile(historyLockPath(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := Config{Term: "202601", HistoryFile: path}
+	pruned := make(chan error)
+	go func() {
+		_, _, err := cfg.pruneHistory(HistoryRetention{Days: 30}, now)
+		pruned <- err
+	}()
+	appended := make(chan error)
+	go func() {
+		appended <- appendHistory(path, HistoryEvent{Time: now, Event: EventOpen, CRN: "12346", Term: "202601"})
+	}()
+
+	select {
+	case <-pruned:
+		t.Fatal("expected pruning to wait for the history lock")
+	case <-appended:
+		t.Fatal("expected appending to wait for the history lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	if err := <-pruned; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := <-appended; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events, _ := readHistory(path)
+	if len(events) != 1 || events[0].CRN != "12346" {
+		t.Errorf("expected the old event pruned and the new one kept, got %+v", events)
+	}
+}
+
+func TestLoadConfig_HistoryRetentionErrors(t *testing.T) {
+	for _, config := range []string{
+		`{"crns": ["12345"], "historyRetention": {"days": -1}}`,
+		`{"crns": ["12345"], "historyRetention": {"maxMB": -5}}`,
+	} {
+		if _, err := loadConfig(createTempConfig(t, config)); err == nil {
+			t.Errorf("%s: expected an error", config)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRMPURL is the RateMyProfessors GraphQL endpoint used by its own website
+const DefaultRMPURL = "https://www.ratemyprofessors.com/graphql"
+
+// DefaultRMPCacheFile is the rating cache file name, kept next to the config file
+const DefaultRMPCacheFile = "ratemyprofessors.json"
+
+// rmpAuth is the fixed credential the RateMyProfessors website sends with every GraphQL request
+const rmpAuth = "Basic dGVzdDp0ZXN0"
+
+// RateMyProfessors configures instructor rating lookups. Lookups are off unless schoolId is set.
+type RateMyProfessors struct {
+	SchoolID  string `json:"schoolId"`  // RateMyProfessors school ID, the number in the school page's URL
+	CacheFile string `json:"cacheFile"` // Where ratings are cached (defaults to ratemyprofessors.json next to the config)
+	CacheDays int    `json:"cacheDays"` // How long a cached rating is used before looking it up again (defaults to 30)
+	URL       string `json:"url"`       // GraphQL endpoint (optional, for testability)
+
+	cache *rmpCache
+}
+
+// rmpRating is one instructor's rating, or a record that they weren't found
+type rmpRating struct {
+	Found      bool      `json:"found"`
+	Rating     float64   `json:"rating"`
+	Ratings    int       `json:"ratings"`
+	Difficulty float64   `json:"difficulty"`
+	Fetched    time.Time `json:"fetched"`
+}
+
+// rmpCache holds ratings by lowercase instructor name, loaded from the cache file on first use
+type rmpCache struct {
+	mu      sync.Mutex
+	loaded  bool
+	ratings map[string]rmpRating
+}
+
+func (r *RateMyProfessors) applyDefaults() error {
+	if r.SchoolID == "" {
+		return nil
+	}
+	if r.CacheDays == 0 {
+		r.CacheDays = 30
+	}
+	if r.CacheDays < 0 {
+		return fmt.Errorf("rateMyProfessors.cacheDays must be positive, got %d", r.CacheDays)
+	}
+	if r.URL == "" {
+		r.URL = DefaultRMPURL
+	}
+	// the GraphQL API wants the school's global ID rather than the number in its URL
+	if _, err := strconv.Atoi(r.SchoolID); err == nil {
+		r.SchoolID = base64.StdEncoding.EncodeToString([]byte("School-" + r.SchoolID))
+	}
+	r.cache = &rmpCache{}
+	return nil
+}
+
+// defaultCacheFile places the cache next to the config file unless one is configured.
+func (r *RateMyProfessors) defaultCacheFile(configPath string) {
+	if r.CacheFile == "" {
+		r.CacheFile = filepath.Join(filepath.Dir(configPath), DefaultRMPCacheFile)
+	}
+}
+
+// describe looks up the instructor's rating, returning "" when lookups are off,
+// the instructor isn't found, or the lookup fails.
+func (r RateMyProfessors) describe(instructor string) string {
+	rating, err := r.lookup(instructor)
+	if err != nil || !rating.Found {
+		return ""
+	}
+	return T("rmp.rating", rating.Rating, rating.Ratings, rating.Difficulty)
+}
+
+// lookup returns the instructor's rating from the cache, or from RateMyProfessors
+// when it isn't cached or the cached rating is older than cacheDays.
+func (r RateMyProfessors) lookup(instructor string) (rmpRating, error) {
+	if r.cache == nil || lastName(instructor) == "" {
+		return rmpRating{}, nil
+	}
+	key := strings.ToLower(strings.Join(strings.Fields(instructor), " "))
+
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+	if !r.cache.loaded {
+		r.cache.ratings = readRMPCache(r.CacheFile)
+		r.cache.loaded = true
+	}
+	if cached, ok := r.cache.ratings[key]; ok && time.Since(cached.Fetched) < time.Duration(r.CacheDays)*24*time.Hour {
+		return cached, nil
+	}
+
+	rating, err := r.fetch(instructor)
+	if err != nil {
+		return rmpRating{}, err
+	}
+	r.cache.ratings[key] = rating
+	if data, err := json.MarshalIndent(r.cache.ratings, "", "  "); err == nil {
+		os.WriteFile(r.CacheFile, data, 0o644)
+	}
+	return rating, nil
+}
+
+// readRMPCache loads the cache file. A missing or damaged cache is treated as empty.
+func readRMPCache(path string) map[string]rmpRating {
+	ratings := make(map[string]rmpRating)
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &ratings)
+	}
+	return ratings
+}
+
+const rmpQuery = `query TeacherSearch($text: String!, $schoolID: ID!) {
+  newSearch {
+    teachers(query: {text: $text, schoolID: $schoolID}, first: 10) {
+      edges { node { firstName lastName avgRating numRatings avgDifficulty } }
+    }
+  }
+}`
+
+type rmpTeacher struct {
+	FirstName     string  `json:"firstName"`
+	LastName      string  `json:"lastName"`
+	AvgRating     float64 `json:"avgRating"`
+	NumRatings    int     `json:"numRatings"`
+	AvgDifficulty float64 `json:"avgDifficulty"`
+}
+
+type rmpResponse struct {
+	Data struct {
+		NewSearch struct {
+			Teachers struct {
+				Edges []struct {
+					Node rmpTeacher `json:"node"`
+				} `json:"edges"`
+			} `json:"teachers"`
+		} `json:"newSearch"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// fetch searches RateMyProfessors for the instructor at the configured school.
+func (r RateMyProfessors) fetch(instructor string) (rmpRating, error) {
+	last := lastName(instructor)
+	body, _ := json.Marshal(map[string]any{
+		"query":     rmpQuery,
+		"variables": map[string]string{"text": last, "schoolID": r.SchoolID},
+	})
+
+	req, err := http.NewRequest(http.MethodPost, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return rmpRating{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", rmpAuth)
+
+	resp, err := rmpClient.Do(req)
+	if err != nil {
+		return rmpRating{}, fmt.Errorf("RateMyProfessors request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return rmpRating{}, fmt.Errorf("RateMyProfessors: %w: %d %s", ErrUnexpectedStatus, resp.StatusCode, resp.Status)
+	}
+
+	var result rmpResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return rmpRating{}, fmt.Errorf("RateMyProfessors: failed to parse response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return rmpRating{}, errors.New("RateMyProfessors: " + result.Errors[0].Message)
+	}
+
+	rating := rmpRating{Fetched: time.Now()}
+	for _, edge := range result.Data.NewSearch.Teachers.Edges {
+		if t := edge.Node; matchesInstructor(t, instructor) && t.NumRatings > rating.Ratings {
+			rating = rmpRating{Found: true, Rating: t.AvgRating, Ratings: t.NumRatings, Difficulty: t.AvgDifficulty, Fetched: rating.Fetched}
+		}
+	}
+	return rating, nil
+}
+
+// rmpClient bounds rating lookups so a slow RateMyProfessors never holds up an alert for long
+var rmpClient = &http.Client{Timeout: 5 * time.Second}
+
+// matchesInstructor reports whether a RateMyProfessors teacher is the timetable's instructor:
+// same last name, and the same first initial when the timetable gives one.
+func matchesInstructor(t rmpTeacher, instructor string) bool {
+	if !strings.EqualFold(t.LastName, lastName(instructor)) {
+		return false
+	}
+	first := instructor
+	if _, after, ok := strings.Cut(instructor, ","); ok {
+		first = after // "Shaffer, Clifford"
+	} else if fields := strings.Fields(instructor); len(fields) > 1 {
+		first = fields[0] // "CA Shaffer"
+	} else {
+		return true
+	}
+	first = strings.TrimSpace(first)
+	return first == "" || t.FirstName == "" || strings.EqualFold(first[:1], t.FirstName[:1])
+}
+
+// annotate fills in each section's instructor rating, looking up each instructor once.
+func (r RateMyProfessors) annotate(sections []Section) {
+	if r.cache == nil {
+		return
+	}
+	for i := range sections {
+		sections[i].Rating = r.describe(sections[i].Instructor)
+	}
+}
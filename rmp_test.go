@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// rmpServer fakes the RateMyProfessors GraphQL endpoint, counting the searches it answers.
+func rmpServer(t *testing.T, searches *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*searches++
+		var req struct {
+			Variables map[string]string `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.Header.Get("Authorization") != rmpAuth || req.Variables["schoolID"] != "U2Nob29sLTE=" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data": {"newSearch": {"teachers": {"edges": [
+			{"node": {"firstName": "Mary", "lastName": "Shaffer", "avgRating": 2.1, "numRatings": 40, "avgDifficulty": 4.0}},
+			{"node": {"firstName": "Clifford", "lastName": "Shaffer", "avgRating": 4.3, "numRatings": 87, "avgDifficulty": 3.6}}
+		]}}}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func rmpConfig(t *testing.T, url string) RateMyProfessors {
+	t.Helper()
+	r := RateMyProfessors{SchoolID: "U2Nob29sLTE=", URL: url, CacheFile: filepath.Join(t.TempDir(), "rmp.json")}
+	if err := r.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return r
+}
+
+// ===================
+// RateMyProfessors tests
+// ===================
+
+func TestRateMyProfessors_MatchesFirstInitial(t *testing.T) {
+	var searches int
+	r := rmpConfig(t, rmpServer(t, &searches).URL)
+
+	if got := r.describe("CA Shaffer"); got != "RateMyProfessors 4.3/5 (87 ratings, difficulty 3.6)" {
+		t.Errorf("got %q", got)
+	}
+	if got := r.describe("Shaffer, Mary"); got != "RateMyProfessors 2.1/5 (40 ratings, difficulty 4.0)" {
+		t.Errorf("got %q", got)
+	}
+	if got := r.describe("Staff"); got != "" {
+		t.Errorf("expected no lookup for Staff, got %q", got)
+	}
+}
+
+func TestRateMyProfessors_CachesLookups(t *testing.T) {
+	var searches int
+	r := rmpConfig(t, rmpServer(t, &searches).URL)
+
+	r.describe("CA Shaffer")
+	r.describe("CA Shaffer")
+	if searches != 1 {
+		t.Errorf("got %d searches, want 1", searches)
+	}
+
+	// a later run reads the cache file instead of searching again
+	again := rmpConfig(t, r.URL)
+	again.CacheFile = r.CacheFile
+	if got := again.describe("CA Shaffer"); got == "" || searches != 1 {
+		t.Errorf("got %q after %d searches, want the cached rating", got, searches)
+	}
+}
+
+func TestRateMyProfessors_SchoolNumberFromURL(t *testing.T) {
+	r := RateMyProfessors{SchoolID: "1"}
+	r.applyDefaults()
+	if r.SchoolID != "U2Nob29sLTE=" {
+		t.Errorf("SchoolID = %q, want the GraphQL ID for School-1", r.SchoolID)
+	}
+}
+
+func TestRateMyProfessors_OffWithoutSchool(t *testing.T) {
+	var r RateMyProfessors
+	r.applyDefaults()
+	if got := r.describe("CA Shaffer"); got != "" {
+		t.Errorf("expected no rating without a schoolId, got %q", got)
+	}
+}
+
+func TestRateMyProfessors_LookupFailure(t *testing.T) {
+	var searches int
+	r := rmpConfig(t, rmpServer(t, &searches).URL)
+	r.SchoolID = "wrong"
+
+	if got := r.describe("CA Shaffer"); got != "" {
+		t.Errorf("expected no rating when the lookup fails, got %q", got)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// AllSessions is the session code that searches every session in a term
+const AllSessions = "%"
+
+// sessionCodePattern matches a timetable session code, e.g. "S1"
+var sessionCodePattern = regexp.MustCompile(`^[A-Za-z0-9]{1,4}$`)
+
+// sessionOption is one entry in the timetable's session dropdown
+type sessionOption struct {
+	Code string // e.g. "S1"
+	Name string // e.g. "Summer I"
+}
+
+// getSessionCode returns the configured session code, defaulting to every session.
+func (c Config) getSessionCode() string {
+	if c.SessionCode != "" {
+		return c.SessionCode
+	}
+	return AllSessions
+}
+
+// validateSessionCode checks that a session code is "%" or a short alphanumeric code.
+func validateSessionCode(code string) error {
+	if code == AllSessions || sessionCodePattern.MatchString(code) {
+		return nil
+	}
+	return fmt.Errorf("invalid sessionCode %q: must be %q or a session code like \"S1\"", code, AllSessions)
+}
+
+// fetchSessionOptions retrieves the sessions offered in the timetable's session dropdown,
+// leaving out the "all sessions" entry.
+func (c Config) fetchSessionOptions() ([]sessionOption, error) {
+	doc, err := c.fetchRequestForm()
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []sessionOption
+	doc.Find(`select[name="sess_code"] option`).Each(func(i int, opt *goquery.Selection) {
+		value := strings.TrimSpace(opt.AttrOr("value", ""))
+		if value != "" && value != AllSessions {
+			sessions = append(sessions, sessionOption{Code: value, Name: strings.Join(strings.Fields(opt.Text()), " ")})
+		}
+	})
+
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("no sessions found in timetable session dropdown")
+	}
+	return sessions, nil
+}
+
+// sessionLabel checks that the configured session is offered by the timetable and returns
+// how to show it, e.g. "Summer I (S1)". It returns "" when every session is searched.
+// If the dropdown can't be read, the code alone is shown so a slow timetable doesn't
+// stop monitoring from starting.
+func (c Config) sessionLabel() (string, error) {
+	code := c.getSessionCode()
+	if code == AllSessions {
+		return "", nil
+	}
+
+	options, err := c.fetchSessionOptions()
+	if err != nil {
+		return code, nil
+	}
+
+	codes := make([]string, len(options))
+	for i, opt := range options {
+		if opt.Code == code {
+			if opt.Name == "" || opt.Name == code {
+				return code, nil
+			}
+			return fmt.Sprintf("%s (%s)", opt.Name, code), nil
+		}
+		codes[i] = opt.Code
+	}
+	return "", fmt.Errorf("sessionCode %q is not offered by the timetable (available: %s)", code, strings.Join(codes, ", "))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ===================
+// sessionCode config tests
+// ===================
+
+func TestLoadConfig_SessionCode(t *testing.T) {
+	cfg, err := loadConfig(createTempConfig(t, `{"crns": ["12345"], "sessionCode": "S2"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.buildPayload("12345", false).Get("sess_code"); got != "S2" {
+		t.Errorf("sess_code = %q, want %q", got, "S2")
+	}
+}
+
+func TestBuildPayload_DefaultsToAllSessions(t *testing.T) {
+	cfg := Config{Campus: "0", Term: "202606"}
+	if got := cfg.buildPayload("12345", false).Get("sess_code"); got != AllSessions {
+		t.Errorf("sess_code = %q, want %q", got, AllSessions)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidSessionCode(t *testing.T) {
+	tests := map[string]string{
+		"too long":          `{"crns": ["12345"], "sessionCode": "SUMMER1"}`,
+		"punctuation":       `{"crns": ["12345"], "sessionCode": "S-1"}`,
+		"set through forms": `{"crns": ["12345"], "formOverrides": {"sess_code": "S1"}}`,
+	}
+
+	for name, content := range tests {
+		if _, err := loadConfig(createTempConfig(t, content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// ===================
+// sessionLabel tests
+// ===================
+
+const sessionDropdownHTML = `<html><form>
+	<select name="sess_code">
+		<option value="%">All Sessions</option>
+		<option value="S1">Summer I</option>
+		<option value="S2">Summer II</option>
+	</select>
+</form></html>`
+
+func newSessionDropdownServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(sessionDropdownHTML))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSessionLabel_AllSessionsSkipsRequest(t *testing.T) {
+	cfg := Config{BaseURL: "http://localhost:99999"}
+	label, err := cfg.sessionLabel()
+	if err != nil || label != "" {
+		t.Errorf("got (%q, %v), want no label and no error", label, err)
+	}
+}
+
+func TestSessionLabel_NamesOfferedSession(t *testing.T) {
+	cfg := Config{BaseURL: newSessionDropdownServer(t).URL, SessionCode: "S2"}
+	label, err := cfg.sessionLabel()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if label != "Summer II (S2)" {
+		t.Errorf("label = %q, want %q", label, "Summer II (S2)")
+	}
+}
+
+func TestSessionLabel_ErrorListsOfferedSessions(t *testing.T) {
+	cfg := Config{BaseURL: newSessionDropdownServer(t).URL, SessionCode: "S3"}
+	_, err := cfg.sessionLabel()
+	if err == nil {
+		t.Fatal("expected error for a session the timetable doesn't offer")
+	}
+	if !strings.Contains(err.Error(), "S1, S2") {
+		t.Errorf("error %q should list the offered sessions", err)
+	}
+}
+
+func TestSessionLabel_UnreadableDropdownShowsCode(t *testing.T) {
+	cfg := Config{BaseURL: "http://localhost:99999", SessionCode: "S1"}
+	label, err := cfg.sessionLabel()
+	if err != nil || label != "S1" {
+		t.Errorf("got (%q, %v), want (%q, nil)", label, err, "S1")
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// session holds the HTTP client and Banner session cookies shared by every
+// timetable request made with a config. Some SSB deployments reject search
+// POSTs that don't carry the cookies set by the search form page, so the
+// session visits that page once before the first search ("priming").
+type session struct {
+	client   *http.Client
+	primeURL string
+
+	mu     sync.Mutex
+	primed bool
+
+	banner9         map[string]*banner9Term // Banner 9 sessions by term
+	banner9Fallback sync.Once               // reports the first Banner 9 failure
+
+	pacer *pacer              // spaces out searches, nil when unlimited
+	hosts map[string]*session // sessions for the other timetables in hosts, by name
+}
+
+// newSession builds the session shared by every timetable request made with
+// this config, and one for each of its other timetables.
+func (c Config) newSession() *session {
+	s := &session{
+		client:   c.newHTTPClient(),
+		primeURL: c.termsURL(),
+		pacer:    newPacer(c.MaxRequestsPerMinute),
+	}
+	for _, h := range c.Hosts {
+		if s.hosts == nil {
+			s.hosts = make(map[string]*session)
+		}
+		s.hosts[h.Name] = c.onHost(h).newSession()
+	}
+	return s
+}
+
+// pace waits until the timetable's next search may be sent.
+func (s *session) pace() {
+	if s != nil {
+		s.pacer.wait()
+	}
+}
+
+// newHTTPClient builds the client shared by every timetable request made with this config.
+func (c Config) newHTTPClient() *http.Client {
+	// cookiejar.New only fails when given options with a bad public suffix list
+	jar, _ := cookiejar.New(nil)
+	return &http.Client{
+		Timeout:   time.Duration(c.RequestTimeoutSeconds) * time.Second,
+		Jar:       jar,
+		Transport: c.newTransport(),
+	}
+}
+
+// newTransport returns the transport for timetable requests, wrapped for record
+// or replay mode and to send the configured headers.
+func (c Config) newTransport() http.RoundTripper {
+	var t http.RoundTripper
+	switch {
+	case c.ReplayDir != "":
+		t = &replayTransport{dir: c.ReplayDir}
+	case c.RecordDir != "":
+		t = &recordingTransport{base: c.baseTransport(), dir: c.RecordDir}
+	default:
+		t = c.baseTransport()
+	}
+	return c.withHeaders(t)
+}
+
+// httpClient returns the shared client, falling back to a default for hand-built configs.
+func (c Config) httpClient() *http.Client {
+	if c.session != nil {
+		return c.session.current()
+	}
+	return defaultHTTPClient
+}
+
+// current returns the client for the session's current cookies.
+func (s *session) current() *http.Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client
+}
+
+// prime visits the search form page to pick up session cookies, once per session.
+func (s *session) prime() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.primed {
+		return nil
+	}
+
+	resp, err := s.client.Get(s.primeURL)
+	if err != nil {
+		return fmt.Errorf("failed to start timetable session: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to start timetable session: %w: %d %s", ErrUnexpectedStatus, resp.StatusCode, resp.Status)
+	}
+
+	s.primed = true
+	return nil
+}
+
+// reset drops the session cookies so the next search starts a fresh session.
+// The client is replaced rather than modified since other searches may be using it.
+func (s *session) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client := *s.client
+	client.Jar, _ = cookiejar.New(nil)
+	s.client = &client
+	s.primed = false
+}
+
+// search posts a timetable search for the CRN through the shared session.
+func (c Config) search(crn string, openOnly bool) (*page, error) {
+	return c.searchQuery(searchQuery{CRN: crn, OpenOnly: openOnly})
+}
+
+// searchQuery posts a timetable search through the shared session, reusing the
+// response to an identical search made within cacheSeconds. Searches that are
+// sent wait their turn under maxRequestsPerMinute.
+func (c Config) searchQuery(q searchQuery) (*page, error) {
+	payload := c.buildQueryPayload(q)
+	return cachedSearch(c, "html "+c.getBaseURL()+"?"+payload.Encode(), func() (*page, error) {
+		c.session.pace()
+		return c.fetchQuery(q, payload)
+	})
+}
+
+// fetchQuery sends a timetable search. If the timetable bounces the search to a
+// login/landing page, the session may have expired, so it is restarted and the
+// search retried once.
+func (c Config) fetchQuery(q searchQuery, payload url.Values) (doc *page, err error) {
+	c, span := c.startSpan("search", attribute.String("term", c.Term), attribute.String("campus", c.Campus),
+		attribute.String("crn", q.CRN), attribute.String("subject", q.Subject))
+	defer func(start time.Time) {
+		endSpan(span, err)
+		c.recordSearch(start, err)
+		c.latency.record(c.Term, c.Campus, time.Since(start), err)
+	}(time.Now())
+
+	if c.session == nil {
+		return fetchDocument(c.httpClient(), c.getBaseURL(), payload)
+	}
+
+	if err := c.session.prime(); err != nil {
+		return nil, err
+	}
+	doc, err = fetchDocument(c.session.current(), c.getBaseURL(), payload)
+	if !errors.Is(err, ErrLoginPage) {
+		return doc, err
+	}
+
+	c.session.reset()
+	span.AddEvent("session restarted")
+	if err := c.session.prime(); err != nil {
+		return nil, err
+	}
+	return fetchDocument(c.session.current(), c.getBaseURL(), payload)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ===================
+// session tests
+// ===================
+
+// newSessionServer returns a timetable that only answers searches carrying the
+// cookie set by its search form page, and counts how often the form was visited.
+func newSessionServer(t *testing.T, primes *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			*primes++
+			http.SetCookie(w, &http.Cookie{Name: "SESSID", Value: "abc", Path: "/"})
+			w.Write([]byte(`<html><form></form></html>`))
+			return
+		}
+		if _, err := r.Cookie("SESSID"); err != nil {
+			w.Write([]byte(`<html><title>Login</title></html>`))
+			return
+		}
+		w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td><td>001</td><td>Intro to Testing</td></tr></table>`))
+	}))
+}
+
+func TestSearch_PrimesSessionOnce(t *testing.T) {
+	primes := 0
+	server := newSessionServer(t, &primes)
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", RequestTimeoutSeconds: 5}
+	cfg.session = cfg.newSession()
+
+	for i := 0; i < 3; i++ {
+		open, err := cfg.checkSectionOpen("12345")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !open {
+			t.Error("expected open=true with session cookies")
+		}
+	}
+
+	if primes != 1 {
+		t.Errorf("expected 1 priming request, got %d", primes)
+	}
+}
+
+func TestSearch_WithoutSessionGetsLoginPage(t *testing.T) {
+	primes := 0
+	server := newSessionServer(t, &primes)
+	defer server.Close()
+
+	// hand-built configs skip priming, so the bare POST is rejected
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601"}
+	_, err := cfg.checkSectionOpen("12345")
+	if !errors.Is(err, ErrLoginPage) {
+		t.Errorf("expected ErrLoginPage, got %v", err)
+	}
+}
+
+func TestSearch_RestartsExpiredSession(t *testing.T) {
+	primes := 0
+	server := newSessionServer(t, &primes)
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601", RequestTimeoutSeconds: 5}
+	cfg.session = cfg.newSession()
+	// pretend an earlier priming happened but its cookies are gone
+	cfg.session.primed = true
+
+	name, err := cfg.getCourseName("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "Intro to Testing" {
+		t.Errorf("got %q, want %q", name, "Intro to Testing")
+	}
+	if primes != 1 {
+		t.Errorf("expected session restart to prime once, got %d", primes)
+	}
+}
+
+func TestSessionPrime_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, RequestTimeoutSeconds: 5}
+	cfg.session = cfg.newSession()
+	if err := cfg.session.prime(); err == nil {
+		t.Error("expected error when session page fails")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Severity is how much an alert matters, so channels can skip the less important ones
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"     // changes worth knowing about, like a new instructor
+	SeverityWarning  Severity = "warning"  // OpenSeat can't check, like a bot check or checks that keep failing
+	SeverityCritical Severity = "critical" // a seat opened
+)
+
+// severities lists the levels from least to most severe
+var severities = []Severity{SeverityInfo, SeverityWarning, SeverityCritical}
+
+// atLeast reports whether s is as severe as min.
+func (s Severity) atLeast(min Severity) bool {
+	return slices.Index(severities, s) >= slices.Index(severities, min)
+}
+
+// severity returns how much the alert matters, from its event.
+func (a Alert) severity() Severity {
+	switch a.Event {
+	case EventOpen, AlertGroupOpen, AlertSuggestion, AlertAllClear:
+		return SeverityCritical
+	case AlertChallenge, AlertSlowdown, AlertFailing:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// validateMinSeverity checks that every channel's minimum is a known severity.
+func (c Config) validateMinSeverity() error {
+	for channel, s := range c.MinSeverity {
+		if !slices.Contains(severities, Severity(s)) {
+			return fmt.Errorf("invalid minSeverity %q for %s: must be info, warning, or critical", s, channel)
+		}
+	}
+	return nil
+}
+
+// wants reports whether a channel gets alerts of the given severity. Channels
+// without a minimum get everything.
+func (c Config) wants(channel string, s Severity) bool {
+	for name, min := range c.MinSeverity {
+		if strings.EqualFold(name, channel) {
+			return s.atLeast(Severity(min))
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+// ===================
+// Severity tests
+// ===================
+
+func TestAlertSeverity(t *testing.T) {
+	tests := map[string]Severity{
+		EventOpen:              SeverityCritical,
+		AlertChallenge:         SeverityWarning,
+		AlertFailing:           SeverityWarning,
+		AlertHint:              SeverityInfo,
+		EventInstructorChanged: SeverityInfo,
+		AlertSummary:           SeverityInfo,
+	}
+	for event, want := range tests {
+		if got := (Alert{Event: event}).severity(); got != want {
+			t.Errorf("%s: got %s, want %s", event, got, want)
+		}
+	}
+}
+
+func TestLoadConfig_ErrorInvalidMinSeverity(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "minSeverity": {"email": "urgent"}}`)
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error for an unknown severity")
+	}
+}
+
+func TestWants(t *testing.T) {
+	cfg := Config{MinSeverity: map[string]string{"SMS": "critical", "email": "warning"}}
+
+	if cfg.wants("SMS", SeverityWarning) || !cfg.wants("SMS", SeverityCritical) {
+		t.Error("expected SMS to get only critical alerts")
+	}
+	if cfg.wants("email", SeverityInfo) || !cfg.wants("email", SeverityWarning) {
+		t.Error("expected email to get warnings and up")
+	}
+	if !cfg.wants("Bark", SeverityInfo) {
+		t.Error("expected channels without a minimum to get everything")
+	}
+}
+
+func TestNotify_RoutesBySeverity(t *testing.T) {
+	phone, everything := &MockNotifier{}, &countingNotifier{name: "Discord"}
+	cfg := Config{notifiers: []Notifier{phone, everything}, MinSeverity: map[string]string{"mock": "critical"}}
+
+	cfg.notify(&MockEmailSender{}, Alert{Event: EventInstructorChanged, Subject: "Instructor changed"})
+	cfg.notify(&MockEmailSender{}, Alert{Event: EventOpen, Subject: "Open"})
+
+	if len(phone.Sent) != 1 || phone.Sent[0] != "Open" {
+		t.Errorf("expected only the opening on the critical channel, got %v", phone.Sent)
+	}
+	if everything.count != 2 {
+		t.Errorf("expected every alert on the other channel, got %d", everything.count)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Signal configures alerts sent as Signal messages through a signal-cli REST API
+// server (github.com/bbernhard/signal-cli-rest-api) that you run yourself, with
+// Number registered or linked on it.
+type Signal struct {
+	URL        string   `json:"url"`        // signal-cli REST API server, e.g. http://localhost:8080
+	Number     string   `json:"number"`     // registered number messages are sent from, e.g. +15405551234
+	Recipients []string `json:"recipients"` // numbers or group IDs to message
+}
+
+// applyDefaults checks the server URL and numbers.
+func (s *Signal) applyDefaults() error {
+	if s.URL == "" && s.Number == "" && len(s.Recipients) == 0 {
+		return nil
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid signal url %q: expected the signal-cli REST API server, e.g. http://localhost:8080", s.URL)
+	}
+	s.URL = strings.TrimSuffix(s.URL, "/")
+	if !isE164(s.Number) {
+		return fmt.Errorf("invalid signal number %q: expected the international format, e.g. +15405551234", s.Number)
+	}
+	if len(s.Recipients) == 0 {
+		return fmt.Errorf("signal: recipients needs at least one number or group")
+	}
+	return nil
+}
+
+// signalNotifier sends alerts through a signal-cli REST API server
+type signalNotifier struct {
+	Signal
+}
+
+func (n *signalNotifier) Name() string { return "Signal" }
+
+// Notify sends the alert to every recipient in one request.
+func (n *signalNotifier) Notify(a Alert) error {
+	payload, err := json.Marshal(struct {
+		Message    string   `json:"message"`
+		Number     string   `json:"number"`
+		Recipients []string `json:"recipients"`
+	}{a.Subject + "\n\n" + a.Body, n.Number, n.Recipients})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.URL+"/v2/send", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return sendAPIRequest("signal", req)
+}
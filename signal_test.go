@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ===================
+// Signal config tests
+// ===================
+
+func TestSignalApplyDefaults(t *testing.T) {
+	s := Signal{URL: "http://localhost:8080/", Number: "+15405551234", Recipients: []string{"+15405559876"}}
+	if err := s.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.URL != "http://localhost:8080" {
+		t.Errorf("URL = %q, want the trailing slash trimmed", s.URL)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidSignal(t *testing.T) {
+	for _, signal := range []string{
+		`{"number": "+15405551234", "recipients": ["+15405559876"]}`,
+		`{"url": "localhost:8080", "number": "+15405551234", "recipients": ["+15405559876"]}`,
+		`{"url": "http://localhost:8080", "number": "5405551234", "recipients": ["+15405559876"]}`,
+		`{"url": "http://localhost:8080", "number": "+15405551234"}`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], "signal": `+signal+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", signal)
+		}
+	}
+}
+
+// ===================
+// signalNotifier tests
+// ===================
+
+func TestSignalNotify(t *testing.T) {
+	var path string
+	var got struct {
+		Message    string   `json:"message"`
+		Number     string   `json:"number"`
+		Recipients []string `json:"recipients"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	n := &signalNotifier{Signal{URL: server.URL, Number: "+15405551234", Recipients: []string{"+15405559876", "group.abc="}}}
+	if err := n.Notify(Alert{Subject: "VT Course Section Open!", Body: "OPEN SEAT: Intro to Testing (CRN: 12345)"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if path != "/v2/send" {
+		t.Errorf("path = %q, want /v2/send", path)
+	}
+	if got.Number != "+15405551234" || len(got.Recipients) != 2 {
+		t.Errorf("unexpected sender or recipients: %+v", got)
+	}
+	if !strings.HasPrefix(got.Message, "VT Course Section Open!") || !strings.Contains(got.Message, "CRN: 12345") {
+		t.Errorf("unexpected message %q", got.Message)
+	}
+}
+
+func TestSignalNotify_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"User +15405551234 is not registered."}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	n := &signalNotifier{Signal{URL: server.URL, Number: "+15405551234", Recipients: []string{"+15405559876"}}}
+	if err := n.Notify(Alert{Subject: "Subject"}); err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Errorf("expected the server's explanation in the error, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SimulatedCheckInterval is the check interval used in simulation mode so the
+// whole alert flow plays out in under a minute
+const SimulatedCheckInterval = 5
+
+// simulatedPattern is the open/closed script each simulated section follows, one
+// entry per open-only check, repeating. Each CRN starts one step later than the
+// one before it so openings are staggered across the watch list.
+var simulatedPattern = []bool{false, false, true, true, false, true}
+
+// simulatedTimetable is a built-in fake timetable that serves the same pages as
+// the real one, with sections opening and closing on simulatedPattern.
+type simulatedTimetable struct {
+	term string
+
+	mu     sync.Mutex
+	order  map[string]int // CRN -> position in the order first seen
+	checks map[string]int // CRN -> open-only checks served
+}
+
+// startSimulatedTimetable serves a simulated timetable on a local port.
+// Returns its search URL and a function that shuts it down.
+func startSimulatedTimetable(term string) (string, func(), error) {
+	sim := &simulatedTimetable{term: term, order: map[string]int{}, checks: map[string]int{}}
+	searchURL, stop, err := serveTimetable(sim)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to start simulated timetable: %w", err)
+	}
+	return searchURL, stop, nil
+}
+
+// serveTimetable serves a fake timetable on a local port.
+// Returns its search URL and a function that shuts it down.
+func serveTimetable(h http.Handler) (string, func(), error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	server := &http.Server{Handler: h}
+	go server.Serve(listener)
+
+	searchURL := fmt.Sprintf("http://%s/HZSKVTSC.P_ProcRequest", listener.Addr())
+	return searchURL, func() { server.Close() }, nil
+}
+
+func (s *simulatedTimetable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		fmt.Fprintf(w, `<html><form><select name="TERMYEAR"><option value="%s">Simulated Term</option></select></form></html>`, s.term)
+		return
+	}
+
+	r.ParseForm()
+	crn := r.FormValue("crn")
+	if r.FormValue("open_only") == "on" && !s.nextOpen(crn) {
+		fmt.Fprint(w, `<html><b>NO SECTIONS FOUND FOR THIS INQUIRY.</b></html>`)
+		return
+	}
+
+	fmt.Fprintf(w, `<table class="dataentrytable">
+		<tr><td>CRN</td><td>Course</td><td>Title</td></tr>
+		<tr><td>%s</td><td>SIM-%s</td><td>Simulated Course %s</td></tr>
+	</table>`, crn, crn[1:], crn)
+}
+
+// nextOpen advances the CRN's script by one check and reports whether it is open.
+func (s *simulatedTimetable) nextOpen(crn string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.order[crn]; !ok {
+		s.order[crn] = len(s.order)
+	}
+	step := s.checks[crn] - s.order[crn]
+	s.checks[crn]++
+
+	if step < 0 {
+		return false
+	}
+	return simulatedPattern[step%len(simulatedPattern)]
+}
+
+// simulate points the config at a freshly started simulated timetable.
+// Returns a function that shuts the simulated timetable down.
+func (c *Config) simulate(now time.Time) (func(), error) {
+	term := c.Term
+	if term == AutoTerm {
+		term = expectedTerm(now)
+	}
+
+	searchURL, stop, err := startSimulatedTimetable(term)
+	if err != nil {
+		return nil, err
+	}
+
+	c.BaseURL = searchURL
+	c.ReplayDir = ""
+	c.CheckInterval = SimulatedCheckInterval
+	c.session = c.newSession()
+	return stop, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// ===================
+// simulated timetable tests
+// ===================
+
+func TestSimulatedTimetable_FollowsScript(t *testing.T) {
+	cfg := Config{Campus: "0", Term: "202601", RequestTimeoutSeconds: 5}
+	stop, err := cfg.simulate(time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stop()
+
+	for i, want := range simulatedPattern {
+		open, err := cfg.checkSectionOpen("12345")
+		if err != nil {
+			t.Fatalf("check %d: unexpected error: %v", i, err)
+		}
+		if open != want {
+			t.Errorf("check %d: open = %v, want %v", i, open, want)
+		}
+	}
+}
+
+func TestSimulatedTimetable_StaggersCRNs(t *testing.T) {
+	sim := &simulatedTimetable{order: map[string]int{}, checks: map[string]int{}}
+
+	// the second CRN seen runs one step behind the first
+	sim.nextOpen("11111")
+	if sim.nextOpen("22222") {
+		t.Error("expected second CRN to start closed")
+	}
+	for i := 0; i < 2; i++ {
+		sim.nextOpen("11111")
+		sim.nextOpen("22222")
+	}
+	if !sim.nextOpen("22222") {
+		t.Error("expected second CRN to open one check after the first")
+	}
+}
+
+func TestSimulatedTimetable_CourseNameAndAutoTerm(t *testing.T) {
+	cfg := Config{Campus: "0", Term: AutoTerm, RequestTimeoutSeconds: 5}
+	now := time.Now()
+	stop, err := cfg.simulate(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stop()
+
+	if err := cfg.resolveAutoTerms(now); err != nil {
+		t.Fatalf("unexpected error resolving term: %v", err)
+	}
+	if cfg.Term != expectedTerm(now) {
+		t.Errorf("Term = %q, want %q", cfg.Term, expectedTerm(now))
+	}
+
+	name, err := cfg.getCourseName("12345")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "Simulated Course 12345" {
+		t.Errorf("got %q, want %q", name, "Simulated Course 12345")
+	}
+	if cfg.CheckInterval != SimulatedCheckInterval {
+		t.Errorf("CheckInterval = %d, want %d", cfg.CheckInterval, SimulatedCheckInterval)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Slack configures alerts posted to a Slack channel through an incoming webhook.
+// The webhook decides the channel, so one set up for #seat-watch always posts there.
+type Slack struct {
+	WebhookURL string `json:"webhookUrl"` // Incoming webhook, e.g. https://hooks.slack.com/services/T.../B.../...
+	MentionAll bool   `json:"mentionAll"` // Mention @here on critical alerts, such as seat openings, so everyone active in the channel is notified
+}
+
+// applyDefaults checks the webhook URL.
+func (s *Slack) applyDefaults() error {
+	if s.WebhookURL == "" {
+		if s.MentionAll {
+			return fmt.Errorf("slack: webhookUrl is required")
+		}
+		return nil
+	}
+	u, err := url.Parse(s.WebhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		// the URL is a secret, so it's left out of the error
+		return fmt.Errorf("invalid slack webhookUrl: expected a URL like https://hooks.slack.com/services/...")
+	}
+	return nil
+}
+
+// slackNotifier posts alerts to a Slack incoming webhook
+type slackNotifier struct {
+	Slack
+}
+
+func (n *slackNotifier) Name() string { return "Slack" }
+
+// Notify posts the alert with its subject in bold, mentioning @here when it's critical.
+func (n *slackNotifier) Notify(a Alert) error {
+	text := "*" + escapeSlack(a.Subject) + "*\n" + escapeSlack(a.Body)
+	if n.MentionAll && a.severity() == SeverityCritical {
+		text = "<!here> " + text
+	}
+	payload, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	return sendAPIRequest("slack", req)
+}
+
+// escapeSlack escapes the characters Slack treats as markup, so course titles
+// like "Law & Society" show as written.
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
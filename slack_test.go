@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// slackServer records the text of every message posted to it
+func slackServer(t *testing.T) (string, *[]string) {
+	var posts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			Text string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&msg)
+		posts = append(posts, msg.Text)
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &posts
+}
+
+// ===================
+// Slack config tests
+// ===================
+
+func TestLoadConfig_ErrorInvalidSlack(t *testing.T) {
+	for _, slack := range []string{
+		`{"webhookUrl": "hooks.slack.com/services/T0/B0/x"}`,
+		`{"mentionAll": true}`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], "slack": `+slack+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", slack)
+		}
+	}
+}
+
+func TestLoadConfig_SlackChannel(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "slack": {"webhookUrl": "https://hooks.slack.com/services/T0/B0/x"}, "minSeverity": {"slack": "critical"}}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.notifiers, err = cfg.newNotifiers(&MockEmailSender{}); err != nil || len(cfg.notifiers) != 1 || cfg.notifiers[0].Name() != "Slack" {
+		t.Fatalf("expected a Slack channel, got %v, %v", cfg.notifiers, err)
+	}
+	if err := cfg.checkChannelNames(); err != nil {
+		t.Errorf("expected slack to name the channel, got %v", err)
+	}
+}
+
+// ===================
+// slackNotifier tests
+// ===================
+
+func TestSlackNotify(t *testing.T) {
+	server, posts := slackServer(t)
+	n := &slackNotifier{Slack{WebhookURL: server, MentionAll: true}}
+
+	if err := n.Notify(Alert{Event: EventOpen, Subject: "Seat open", Body: "Law & Society <CRN 12345>"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := n.Notify(Alert{Event: "summary", Subject: "Weekly summary", Body: "3 checks"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*posts) != 2 {
+		t.Fatalf("expected two posts, got %v", *posts)
+	}
+	if got := (*posts)[0]; got != "<!here> *Seat open*\nLaw &amp; Society &lt;CRN 12345&gt;" {
+		t.Errorf("expected an escaped opening that mentions the channel, got %q", got)
+	}
+	if strings.Contains((*posts)[1], "<!here>") {
+		t.Errorf("expected only critical alerts to mention the channel, got %q", (*posts)[1])
+	}
+	n.Notify(Alert{Event: AlertAllClear, Subject: "Whole schedule open"})
+	if !strings.HasPrefix((*posts)[2], "<!here>") {
+		t.Errorf("expected the all-clear to mention the channel, got %q", (*posts)[2])
+	}
+}
+
+func TestSlackNotify_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := (&slackNotifier{Slack{WebhookURL: server.URL}}).Notify(Alert{Subject: "Seat open"})
+	if err == nil || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("expected Slack's error, got %v", err)
+	}
+}
+
+func TestSlackNotify_ConnectionErrorHidesWebhook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	n := &slackNotifier{Slack{WebhookURL: "http://127.0.0.1:1/services/T000/B000/SECRETTOKEN"}}
+
+	err := n.Notify(Alert{Subject: "Seat open"})
+	if err == nil || strings.Contains(err.Error(), "SECRETTOKEN") || !strings.Contains(err.Error(), "127.0.0.1:1") {
+		t.Errorf("expected an error naming only the host, got %v", err)
+	}
+
+	cfg := Config{AuditFile: path, notifiers: []Notifier{n}}
+	cfg.notify(&MockEmailSender{}, Alert{Event: EventOpen, CRN: "12345", Subject: "Seat open"})
+	audit, _ := os.ReadFile(path)
+	if len(audit) == 0 || strings.Contains(string(audit), "SECRETTOKEN") || strings.Contains(string(audit), "/services/") {
+		t.Errorf("expected the failure audited without the webhook path, got %s", audit)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Slowdown detection defaults
+const (
+	DefaultSlowdownFactor = 3.0
+	SlowdownRelaxFactor   = 2               // how much longer checks wait while searches are slow, with relax
+	slowdownWindow        = 5               // recent searches whose median is compared to the usual time
+	slowdownMinSamples    = 20              // searches needed before the usual time is trusted
+	slowdownBaselineSpan  = 20              // searches the usual time is averaged over
+	slowdownMinLatency    = 2 * time.Second // searches faster than this never count as slow
+)
+
+// Slowdown configures the alert sent when timetable searches get much slower than
+// usual, which tends to happen as a registration rush starts and before searches
+// start timing out.
+type Slowdown struct {
+	Factor float64 `json:"factor"` // how many times slower than usual counts as slow (defaults to 3, -1 to turn off)
+	Relax  bool    `json:"relax"`  // check less often while searches are slow
+}
+
+// applyDefaults fills in the factor.
+func (s *Slowdown) applyDefaults() error {
+	switch {
+	case s.Factor == 0:
+		s.Factor = DefaultSlowdownFactor
+	case s.Factor < 0:
+		s.Factor = -1
+	case s.Factor <= 1:
+		return fmt.Errorf("slowdown: factor must be more than 1, got %v", s.Factor)
+	}
+	return nil
+}
+
+// latencyTracker keeps rolling search times per term and campus to spot slowdowns
+type latencyTracker struct {
+	factor float64
+
+	mu    sync.Mutex
+	terms map[string]*latency // by term and campus
+}
+
+// latency is the search history for one term and campus
+type latency struct {
+	term, campus string
+	usual        time.Duration   // moving average of the recent median while not slow
+	samples      int             // searches averaged into usual
+	recent       []time.Duration // the last slowdownWindow search times, oldest first
+	slow         bool
+	changed      bool // slow has changed since the last report; flipping back and forth cancels out
+}
+
+// slowdownChange reports that searches for a term and campus became slow or recovered
+type slowdownChange struct {
+	Term, Campus string
+	Slow         bool
+	Recent       time.Duration // median of the recent searches
+	Usual        time.Duration
+}
+
+// newLatencyTracker returns a tracker for the settings, or nil when slowdown detection is off.
+func newLatencyTracker(s Slowdown) *latencyTracker {
+	if s.Factor < 0 {
+		return nil
+	}
+	return &latencyTracker{factor: s.Factor, terms: make(map[string]*latency)}
+}
+
+// record adds a search's time. Failed searches are left out, since many fail
+// fast and timeouts only say how long the timeout is. A nil tracker ignores it.
+func (t *latencyTracker) record(term, campus string, d time.Duration, err error) {
+	if t == nil || err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := term + "/" + campus
+	l, ok := t.terms[key]
+	if !ok {
+		l = &latency{term: term, campus: campus}
+		t.terms[key] = l
+	}
+
+	l.recent = append(l.recent, d)
+	if len(l.recent) > slowdownWindow {
+		l.recent = l.recent[1:]
+	}
+	if l.samples >= slowdownMinSamples && len(l.recent) == slowdownWindow {
+		recent := median(l.recent)
+		switch {
+		case !l.slow && recent >= slowdownMinLatency && float64(recent) >= t.factor*float64(l.usual):
+			l.slow, l.changed = true, !l.changed
+		case l.slow && float64(recent) < (1+t.factor)/2*float64(l.usual):
+			// back to within halfway of usual, so one quick search doesn't end it
+			l.slow, l.changed = false, !l.changed
+		}
+	}
+
+	// the usual time learns from the recent median, so a few stray slow searches
+	// don't drag it up, and only while not slow, so a long slowdown isn't taken as
+	// the new normal
+	if !l.slow {
+		if l.samples == 0 {
+			l.usual = d
+		} else {
+			l.usual += (median(l.recent) - l.usual) / slowdownBaselineSpan
+		}
+		l.samples++
+	}
+}
+
+// changes returns the terms and campuses that became slow or recovered since the last call.
+func (t *latencyTracker) changes() []slowdownChange {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var out []slowdownChange
+	for _, l := range t.terms {
+		if l.changed {
+			l.changed = false
+			out = append(out, slowdownChange{Term: l.term, Campus: l.campus, Slow: l.slow, Recent: median(l.recent), Usual: l.usual})
+		}
+	}
+	slices.SortFunc(out, func(a, b slowdownChange) int {
+		return cmp.Or(cmp.Compare(a.Term, b.Term), cmp.Compare(a.Campus, b.Campus))
+	})
+	return out
+}
+
+// slow reports whether searches for any term and campus are currently slow.
+func (t *latencyTracker) slow() bool {
+	if t == nil {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, l := range t.terms {
+		if l.slow {
+			return true
+		}
+	}
+	return false
+}
+
+// median returns the middle of the durations.
+func median(ds []time.Duration) time.Duration {
+	sorted := slices.Clone(ds)
+	slices.Sort(sorted)
+	return sorted[len(sorted)/2]
+}
+
+// slowdownInterval returns the check interval to use, which is stretched while
+// searches are slow if the config asks for it.
+func (c Config) slowdownInterval(normal time.Duration) time.Duration {
+	if c.Slowdown.Relax && c.latency.slow() {
+		return normal * SlowdownRelaxFactor
+	}
+	return normal
+}
+
+// checkSlowdowns reports terms and campuses whose searches became slow or
+// recovered since the last round of checks, alerting when they slow down.
+func (c Config) checkSlowdowns(sender EmailSender) {
+	for _, change := range c.latency.changes() {
+		where := change.Term
+		if change.Campus != "" {
+			where = T("slowdown.campus", where, change.Campus)
+		}
+		recent, usual := change.Recent.Round(100*time.Millisecond), change.Usual.Round(100*time.Millisecond)
+
+		if !change.Slow {
+			PrintSlowdownEnded(where, recent)
+			continue
+		}
+		PrintSlowdown(where, recent, usual, c.Slowdown.Relax)
+		if c.notifying() {
+			body := T("email.slowdown.body", where, recent, usual)
+			if c.Slowdown.Relax {
+				body += "\n\n" + T("email.slowdown.relaxed", SlowdownRelaxFactor)
+			}
+			c.notify(sender, Alert{Event: AlertSlowdown, Subject: T("email.slowdown.subject", where), Body: body, Term: change.Term})
+		}
+	}
+}
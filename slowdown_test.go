@@ -0,0 +1,181 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// Slowdown config tests
+// ===================
+
+func TestLoadConfig_SlowdownDefaults(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"]}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Slowdown.Factor != DefaultSlowdownFactor {
+		t.Errorf("expected factor %v, got %v", DefaultSlowdownFactor, cfg.Slowdown.Factor)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidSlowdownFactor(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "slowdown": {"factor": 0.5}}`)
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error for a factor that isn't a slowdown")
+	}
+}
+
+func TestNewLatencyTracker_Off(t *testing.T) {
+	if newLatencyTracker(Slowdown{Factor: -1}) != nil {
+		t.Error("expected no tracker when slowdown detection is off")
+	}
+}
+
+// ===================
+// latencyTracker tests
+// ===================
+
+// warmUp records enough normal searches for the tracker to trust its usual time.
+func warmUp(tr *latencyTracker, d time.Duration) {
+	for range slowdownMinSamples {
+		tr.record("202601", "0", d, nil)
+	}
+}
+
+func TestLatencyTracker_DetectsSlowdown(t *testing.T) {
+	tr := newLatencyTracker(Slowdown{Factor: 3})
+	warmUp(tr, time.Second)
+
+	for range slowdownWindow / 2 {
+		tr.record("202601", "0", 5*time.Second, nil)
+	}
+	if tr.slow() {
+		t.Fatal("a couple of slow searches shouldn't count as a slowdown")
+	}
+	tr.record("202601", "0", 5*time.Second, nil)
+
+	changes := tr.changes()
+	if len(changes) != 1 || !changes[0].Slow || changes[0].Term != "202601" || changes[0].Campus != "0" {
+		t.Fatalf("expected one slowdown for 202601/0, got %+v", changes)
+	}
+	if changes[0].Recent != 5*time.Second || changes[0].Usual != time.Second {
+		t.Errorf("expected 5s against a usual 1s, got %v against %v", changes[0].Recent, changes[0].Usual)
+	}
+	if !tr.slow() {
+		t.Error("expected searches to be slow")
+	}
+	if len(tr.changes()) != 0 {
+		t.Error("expected a change to be reported once")
+	}
+}
+
+func TestLatencyTracker_Recovers(t *testing.T) {
+	tr := newLatencyTracker(Slowdown{Factor: 3})
+	warmUp(tr, time.Second)
+	for range slowdownWindow {
+		tr.record("202601", "0", 5*time.Second, nil)
+	}
+	tr.changes()
+
+	for range slowdownWindow {
+		tr.record("202601", "0", time.Second, nil)
+	}
+	changes := tr.changes()
+	if len(changes) != 1 || changes[0].Slow {
+		t.Fatalf("expected the slowdown to end, got %+v", changes)
+	}
+	if changes[0].Usual != time.Second {
+		t.Errorf("slow searches shouldn't change the usual time, got %v", changes[0].Usual)
+	}
+}
+
+func TestLatencyTracker_IgnoresFastAndFailedSearches(t *testing.T) {
+	tr := newLatencyTracker(Slowdown{Factor: 3})
+	warmUp(tr, 100*time.Millisecond)
+
+	// much slower than usual, but still well under a noticeable delay
+	for range slowdownWindow {
+		tr.record("202601", "0", time.Second, nil)
+	}
+	for range slowdownWindow {
+		tr.record("202601", "0", 20*time.Second, ErrUnexpectedStatus)
+	}
+	if tr.slow() || len(tr.changes()) != 0 {
+		t.Error("expected no slowdown")
+	}
+}
+
+func TestLatencyTracker_PerTermAndCampus(t *testing.T) {
+	tr := newLatencyTracker(Slowdown{Factor: 3})
+	warmUp(tr, time.Second)
+	for range slowdownMinSamples {
+		tr.record("202601", "1", 10*time.Second, nil)
+	}
+	if tr.slow() {
+		t.Error("a campus that's always slow shouldn't count as slowing down")
+	}
+}
+
+func TestLatencyTracker_Nil(t *testing.T) {
+	var tr *latencyTracker
+	tr.record("202601", "0", time.Minute, nil)
+	if tr.slow() || tr.changes() != nil {
+		t.Error("expected a nil tracker to do nothing")
+	}
+}
+
+// ===================
+// slowdownInterval and checkSlowdowns tests
+// ===================
+
+func TestSlowdownInterval(t *testing.T) {
+	tr := newLatencyTracker(Slowdown{Factor: 3})
+	warmUp(tr, time.Second)
+	for range slowdownWindow {
+		tr.record("202601", "0", 5*time.Second, nil)
+	}
+
+	cfg := Config{latency: tr}
+	if got := cfg.slowdownInterval(30 * time.Second); got != 30*time.Second {
+		t.Errorf("expected the interval unchanged without relax, got %v", got)
+	}
+	cfg.Slowdown.Relax = true
+	if got := cfg.slowdownInterval(30 * time.Second); got != 60*time.Second {
+		t.Errorf("expected the interval doubled while slow, got %v", got)
+	}
+}
+
+func TestCheckSlowdowns_Alerts(t *testing.T) {
+	tr := newLatencyTracker(Slowdown{Factor: 3})
+	warmUp(tr, time.Second)
+	for range slowdownWindow {
+		tr.record("202601", "0", 5*time.Second, nil)
+	}
+	cfg := Config{Email: "test@example.com", Slowdown: Slowdown{Relax: true}, latency: tr}
+	sender := &MockEmailSender{}
+
+	cfg.checkSlowdowns(sender)
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "5s") {
+		t.Fatalf("expected one slowdown email, got %+v", sender.Sent)
+	}
+	if !strings.Contains(sender.Sent[0].Body, "further apart") {
+		t.Errorf("body should say checks were relaxed: %q", sender.Sent[0].Body)
+	}
+
+	for range slowdownWindow {
+		tr.record("202601", "0", time.Second, nil)
+	}
+	cfg.checkSlowdowns(sender)
+	if len(sender.Sent) != 1 {
+		t.Errorf("expected no email when searches recover, got %d", len(sender.Sent))
+	}
+}
+
+func TestAlertSeverity_Slowdown(t *testing.T) {
+	if got := (Alert{Event: AlertSlowdown}).severity(); got != SeverityWarning {
+		t.Errorf("expected warning, got %s", got)
+	}
+}
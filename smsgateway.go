@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// smsLimit is the length of a single text message; longer ones get split or dropped by some gateways
+const smsLimit = 160
+
+// carrierGateways maps carriers to the domain of their email-to-SMS gateway
+var carrierGateways = map[string]string{
+	"verizon":    "vtext.com",
+	"tmobile":    "tmomail.net",
+	"uscellular": "email.uscc.net",
+	"cricket":    "sms.cricketwireless.net",
+	"boost":      "sms.myboostmobile.com",
+	"metropcs":   "mymetropcs.com",
+	"googlefi":   "msg.fi.google.com",
+	"visible":    "vtext.com",
+	"mint":       "tmomail.net",
+}
+
+// SMSGateway configures free text alerts sent as email to a carrier's
+// email-to-SMS gateway, e.g. 5405551234@vtext.com, using the configured email provider.
+type SMSGateway struct {
+	Number   string            `json:"number"`   // 10-digit US phone number
+	Carrier  string            `json:"carrier"`  // e.g. "verizon" or "tmobile"
+	Gateways map[string]string `json:"gateways"` // gateway domain per carrier, adding to or replacing the built-in ones (optional)
+}
+
+// applyDefaults normalizes the phone number and checks that the carrier has a known gateway.
+func (s *SMSGateway) applyDefaults() error {
+	if s.Number == "" {
+		return nil
+	}
+	var digits strings.Builder
+	for _, r := range s.Number {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	number := digits.String()
+	if len(number) == 11 && number[0] == '1' {
+		number = number[1:] // country code
+	}
+	if len(number) != 10 {
+		return fmt.Errorf("invalid smsGateway number %q: expected a 10-digit phone number", s.Number)
+	}
+	s.Number = number
+
+	s.Carrier = strings.ToLower(s.Carrier)
+	if s.gateway() == "" {
+		return fmt.Errorf("unknown smsGateway carrier %q: add its gateway domain to smsGateway.gateways", s.Carrier)
+	}
+	return nil
+}
+
+// gateway returns the carrier's gateway domain, preferring one from the config.
+func (s SMSGateway) gateway() string {
+	if domain, ok := s.Gateways[s.Carrier]; ok {
+		return domain
+	}
+	return carrierGateways[s.Carrier]
+}
+
+// address returns the email address that texts the phone.
+func (s SMSGateway) address() string {
+	return s.Number + "@" + s.gateway()
+}
+
+// smsGatewayNotifier texts alerts by emailing a carrier's gateway
+type smsGatewayNotifier struct {
+	sender EmailSender
+	to     string
+}
+
+func (n *smsGatewayNotifier) Name() string { return "SMS" }
+
+// Notify sends a short plain-text version of the alert. The subject is left
+// empty, since gateways prepend it to the text and eat into the limit.
+func (n *smsGatewayNotifier) Notify(a Alert) error {
+	return n.sender.Send(EmailMessage{To: []string{n.to}, Body: smsText(a.Subject, a.Body)})
+}
+
+// smsText shortens an alert to fit one text message: the body's first line,
+// which carries the essentials, or the subject if the body is empty. Anything
+// past the limit is cut, and non-ASCII characters are dropped since many
+// gateways mangle them.
+func smsText(subject, body string) string {
+	text, _, _ := strings.Cut(strings.TrimSpace(body), "\n")
+	if text == "" {
+		text = subject
+	}
+
+	var b strings.Builder
+	for _, r := range text {
+		if r >= ' ' && r <= '~' {
+			b.WriteRune(r)
+		}
+	}
+	s := strings.TrimSpace(b.String())
+	if len(s) > smsLimit {
+		s = strings.TrimSpace(s[:smsLimit-3]) + "..."
+	}
+	return s
+}
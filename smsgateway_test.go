@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// ===================
+// SMS gateway config tests
+// ===================
+
+func TestSMSGatewayApplyDefaults(t *testing.T) {
+	s := SMSGateway{Number: "+1 (540) 555-1234", Carrier: "Verizon"}
+	if err := s.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.address(); got != "5405551234@vtext.com" {
+		t.Errorf("address() = %q, want 5405551234@vtext.com", got)
+	}
+}
+
+func TestSMSGatewayApplyDefaults_ConfiguredGateway(t *testing.T) {
+	s := SMSGateway{Number: "5405551234", Carrier: "mycarrier", Gateways: map[string]string{"mycarrier": "sms.mycarrier.com"}}
+	if err := s.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.address(); got != "5405551234@sms.mycarrier.com" {
+		t.Errorf("address() = %q", got)
+	}
+
+	// a configured gateway replaces the built-in one
+	s = SMSGateway{Number: "5405551234", Carrier: "verizon", Gateways: map[string]string{"verizon": "vzwpix.com"}}
+	s.applyDefaults()
+	if got := s.address(); got != "5405551234@vzwpix.com" {
+		t.Errorf("address() = %q", got)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidSMSGateway(t *testing.T) {
+	for _, gw := range []string{
+		`{"number": "555-1234", "carrier": "verizon"}`,
+		`{"number": "5405551234", "carrier": "carrier-pigeon"}`,
+		`{"number": "5405551234"}`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], "smsGateway": `+gw+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", gw)
+		}
+	}
+}
+
+// ===================
+// smsGatewayNotifier tests
+// ===================
+
+func TestSMSGatewayNotify(t *testing.T) {
+	sender := &MockEmailSender{}
+	n := &smsGatewayNotifier{sender: sender, to: "5405551234@vtext.com"}
+
+	if err := n.Notify(Alert{Subject: "VT Course Section Open!", Body: "OPEN SEAT: Intro to Testing (CRN: 12345, term 202601)\nAverage GPA 3.2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sender.Sent) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(sender.Sent))
+	}
+	sent := sender.Sent[0]
+	if len(sent.To) != 1 || sent.To[0] != "5405551234@vtext.com" || sent.Subject != "" {
+		t.Errorf("unexpected email: %+v", sent)
+	}
+	if sent.Body != "OPEN SEAT: Intro to Testing (CRN: 12345, term 202601)" {
+		t.Errorf("Body = %q", sent.Body)
+	}
+}
+
+func TestNewNotifiers_SMSGateway(t *testing.T) {
+	cfg := Config{SMSGateway: SMSGateway{Number: "5405551234", Carrier: "tmobile"}}
+	notifiers, err := cfg.newNotifiers(&MockEmailSender{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifiers) != 1 || notifiers[0].Name() != "SMS" {
+		t.Errorf("expected the SMS notifier, got %v", notifiers)
+	}
+}
+
+func TestSMSText(t *testing.T) {
+	if got := smsText("Subject", ""); got != "Subject" {
+		t.Errorf("empty body: got %q, want the subject", got)
+	}
+	if got := smsText("Subject", "Café — open"); got != "Caf  open" {
+		t.Errorf("non-ASCII: got %q", got)
+	}
+
+	got := smsText("Subject", strings.Repeat("x", 300))
+	if len(got) != smsLimit || !strings.HasSuffix(got, "...") {
+		t.Errorf("expected a %d-character text ending in ..., got %d: %q", smsLimit, len(got), got)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+// SNSTimeout bounds publishing one alert, including fetching credentials
+const SNSTimeout = 20 * time.Second
+
+// snsSubjectLimit is the longest subject SNS accepts
+const snsSubjectLimit = 99
+
+// SNS configures publishing alerts to an AWS SNS topic, so they can fan out to
+// whatever SMS, email, or Lambda subscribers the topic has. Credentials come from
+// the standard AWS chain: environment variables, ~/.aws, or an EC2/ECS role.
+type SNS struct {
+	TopicARN string `json:"topicArn"` // e.g. arn:aws:sns:us-east-1:123456789012:openseat
+	Region   string `json:"region"`   // defaults to the topic's region
+	Profile  string `json:"profile"`  // shared config profile to use instead of the default (optional)
+}
+
+// applyDefaults checks the topic ARN and takes the region from it if none is set.
+func (s *SNS) applyDefaults() error {
+	if s.TopicARN == "" {
+		return nil
+	}
+	parts := strings.Split(s.TopicARN, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "sns" || parts[3] == "" || parts[5] == "" {
+		return fmt.Errorf("invalid sns topicArn %q: expected arn:aws:sns:<region>:<account>:<topic>", s.TopicARN)
+	}
+	if s.Region == "" {
+		s.Region = parts[3]
+	}
+	return nil
+}
+
+// snsPublisher is the part of the SNS client used to send alerts
+type snsPublisher interface {
+	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+// snsNotifier publishes alerts to an SNS topic
+type snsNotifier struct {
+	client   snsPublisher
+	topicARN string
+}
+
+// newNotifier loads AWS credentials and creates the SNS client.
+func (s SNS) newNotifier() (*snsNotifier, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), SNSTimeout)
+	defer cancel()
+
+	opts := []func(*config.LoadOptions) error{config.WithRegion(s.Region)}
+	if s.Profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(s.Profile))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS credentials: %w", err)
+	}
+	return &snsNotifier{client: sns.NewFromConfig(cfg), topicARN: s.TopicARN}, nil
+}
+
+func (n *snsNotifier) Name() string { return "SNS" }
+
+// Notify publishes the alert. Email subscribers see the subject; SMS subscribers
+// only get the body, so the subject leads it.
+func (n *snsNotifier) Notify(a Alert) error {
+	ctx, cancel := context.WithTimeout(context.Background(), SNSTimeout)
+	defer cancel()
+
+	_, err := n.client.Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(n.topicARN),
+		Subject:  aws.String(snsSubject(a.Subject)),
+		Message:  aws.String(a.Subject + "\n\n" + a.Body),
+	})
+	return err
+}
+
+// snsSubject fits a subject to SNS's rules: printable ASCII on one line, under 100 characters.
+func snsSubject(subject string) string {
+	var b strings.Builder
+	for _, r := range subject {
+		switch {
+		case r >= ' ' && r <= '~':
+			b.WriteRune(r)
+		case r == '\n' || r == '\t':
+			b.WriteByte(' ')
+		}
+	}
+	s := strings.TrimSpace(b.String())
+	if len(s) > snsSubjectLimit {
+		s = strings.TrimSpace(s[:snsSubjectLimit])
+	}
+	return s
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+// mockPublisher records published messages instead of calling AWS
+type mockPublisher struct {
+	inputs []*sns.PublishInput
+	err    error
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	m.inputs = append(m.inputs, params)
+	return &sns.PublishOutput{}, m.err
+}
+
+// ===================
+// SNS config tests
+// ===================
+
+func TestSNSApplyDefaults_RegionFromARN(t *testing.T) {
+	s := SNS{TopicARN: "arn:aws:sns:us-east-2:123456789012:openseat"}
+	if err := s.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Region != "us-east-2" {
+		t.Errorf("Region = %q, want us-east-2", s.Region)
+	}
+
+	s = SNS{TopicARN: "arn:aws:sns:us-east-2:123456789012:openseat", Region: "us-west-1"}
+	s.applyDefaults()
+	if s.Region != "us-west-1" {
+		t.Errorf("configured region was replaced with %q", s.Region)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidTopicARN(t *testing.T) {
+	for _, arn := range []string{"openseat", "arn:aws:sqs:us-east-1:123456789012:openseat", "arn:aws:sns:us-east-1:123456789012"} {
+		path := createTempConfig(t, `{"crns": ["12345"], "sns": {"topicArn": "`+arn+`"}}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%q: expected error", arn)
+		}
+	}
+}
+
+// ===================
+// snsNotifier tests
+// ===================
+
+func TestSNSNotify(t *testing.T) {
+	publisher := &mockPublisher{}
+	n := &snsNotifier{client: publisher, topicARN: "arn:aws:sns:us-east-1:123456789012:openseat"}
+
+	if err := n.Notify(Alert{Subject: "VT Course Section Open!", Body: "OPEN SEAT: Intro to Testing (CRN: 12345)"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(publisher.inputs) != 1 {
+		t.Fatalf("expected one publish, got %d", len(publisher.inputs))
+	}
+	in := publisher.inputs[0]
+	if aws.ToString(in.TopicArn) != n.topicARN || aws.ToString(in.Subject) != "VT Course Section Open!" {
+		t.Errorf("unexpected input: %+v", in)
+	}
+	// SMS subscribers only see the message, so it leads with the subject
+	if msg := aws.ToString(in.Message); !strings.HasPrefix(msg, "VT Course Section Open!") || !strings.Contains(msg, "CRN: 12345") {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestSNSNotify_Error(t *testing.T) {
+	n := &snsNotifier{client: &mockPublisher{err: errors.New("AccessDenied")}, topicARN: "arn:aws:sns:us-east-1:123456789012:openseat"}
+	if err := n.Notify(Alert{Subject: "Subject", Body: "Body"}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSNSSubject(t *testing.T) {
+	tests := map[string]string{
+		"VT Course Section Open!": "VT Course Section Open!",
+		"Plätze frei → CRN 12345": "Pltze frei  CRN 12345",
+		"line one\nline two":      "line one line two",
+		strings.Repeat("x", 150):  strings.Repeat("x", snsSubjectLimit),
+	}
+
+	for in, want := range tests {
+		if got := snsSubject(in); got != want {
+			t.Errorf("snsSubject(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// AlertSound is a sound file played when a seat opens, so the alert is
+// unmistakable even from across the room.
+type AlertSound struct {
+	File   string `json:"file"`   // Path to a .wav or .mp3 file
+	Repeat int    `json:"repeat"` // How many times to play it (defaults to 1)
+	Volume int    `json:"volume"` // Playback volume from 1 to 100 percent (defaults to 100)
+}
+
+// applyDefaults fills in the repeat count and volume, and checks the sound can be played.
+func (s *AlertSound) applyDefaults() error {
+	if s.File == "" {
+		return nil
+	}
+	if s.Repeat == 0 {
+		s.Repeat = 1
+	}
+	if s.Volume == 0 {
+		s.Volume = 100
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(s.File)); ext {
+	case ".wav", ".mp3":
+	default:
+		return fmt.Errorf("alertSound.file must be a .wav or .mp3 file, got %q", s.File)
+	}
+	if _, err := os.Stat(s.File); err != nil {
+		return fmt.Errorf("alertSound.file: %w", err)
+	}
+	if s.Repeat < 0 {
+		return fmt.Errorf("alertSound.repeat must be positive, got %d", s.Repeat)
+	}
+	if s.Volume < 0 || s.Volume > 100 {
+		return fmt.Errorf("alertSound.volume must be between 1 and 100, got %d", s.Volume)
+	}
+	return nil
+}
+
+// playerCommand returns the command that plays the sound once on the given OS,
+// using the player that ships with it (afplay on macOS, PowerShell on Windows)
+// or the usual PulseAudio/mpg123 players on Linux.
+func (s AlertSound) playerCommand(goos string) ([]string, error) {
+	mp3 := strings.EqualFold(filepath.Ext(s.File), ".mp3")
+	switch goos {
+	case "darwin":
+		return []string{"afplay", "-v", strconv.FormatFloat(float64(s.Volume)/100, 'f', 2, 64), s.File}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if mp3 {
+			// mpg123 scales output by -f, where 32768 is full volume
+			return []string{"mpg123", "-q", "-f", strconv.Itoa(s.Volume * 32768 / 100), s.File}, nil
+		}
+		// paplay's volume runs from 0 to 65536
+		return []string{"paplay", "--volume=" + strconv.Itoa(s.Volume*65536/100), s.File}, nil
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName PresentationCore; `+
+			`$p = New-Object System.Windows.Media.MediaPlayer; $p.Volume = %.2f; $p.Open([uri]'%s'); `+
+			`while (-not $p.NaturalDuration.HasTimeSpan) { Start-Sleep -Milliseconds 50 }; $p.Play(); `+
+			`Start-Sleep -Milliseconds ([int]$p.NaturalDuration.TimeSpan.TotalMilliseconds)`,
+			float64(s.Volume)/100, strings.ReplaceAll(s.File, "'", "''"))
+		return []string{"powershell", "-NoProfile", "-Command", script}, nil
+	}
+	return nil, fmt.Errorf("playing alert sounds isn't supported on %s", goos)
+}
+
+// play plays the sound the configured number of times, one after another.
+func (s AlertSound) play() error {
+	if s.File == "" {
+		return nil
+	}
+	args, err := s.playerCommand(runtime.GOOS)
+	if err != nil {
+		return err
+	}
+	for range s.Repeat {
+		if output, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
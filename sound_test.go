@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func tempSound(t *testing.T, name string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte("RIFF"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// ===================
+// AlertSound tests
+// ===================
+
+func TestAlertSound_Defaults(t *testing.T) {
+	s := AlertSound{File: tempSound(t, "alarm.wav")}
+	if err := s.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Repeat != 1 || s.Volume != 100 {
+		t.Errorf("got repeat %d, volume %d; want 1, 100", s.Repeat, s.Volume)
+	}
+}
+
+func TestAlertSound_Invalid(t *testing.T) {
+	wav := tempSound(t, "alarm.wav")
+	tests := map[string]AlertSound{
+		"unsupported format": {File: tempSound(t, "alarm.ogg")},
+		"missing file":       {File: filepath.Join(t.TempDir(), "missing.wav")},
+		"volume too high":    {File: wav, Volume: 150},
+		"negative repeat":    {File: wav, Repeat: -2},
+	}
+
+	for name, s := range tests {
+		if err := s.applyDefaults(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestAlertSound_PlayerCommand(t *testing.T) {
+	tests := []struct {
+		goos  string
+		file  string
+		want  string
+		extra string
+	}{
+		{"darwin", "alarm.mp3", "afplay", "0.50"},
+		{"linux", "alarm.wav", "paplay", "--volume=32768"},
+		{"linux", "alarm.mp3", "mpg123", "16384"},
+		{"windows", "alarm.wav", "powershell", "$p.Volume = 0.50"},
+	}
+
+	for _, tt := range tests {
+		args, err := AlertSound{File: tt.file, Repeat: 1, Volume: 50}.playerCommand(tt.goos)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.goos, err)
+		}
+		if args[0] != tt.want {
+			t.Errorf("%s %s: player %q, want %q", tt.goos, tt.file, args[0], tt.want)
+		}
+		if !slices.ContainsFunc(args, func(a string) bool { return strings.Contains(a, tt.extra) }) {
+			t.Errorf("%s %s: args %q missing %q", tt.goos, tt.file, args, tt.extra)
+		}
+	}
+}
+
+func TestAlertSound_PlayWithoutFileIsNoop(t *testing.T) {
+	if err := (AlertSound{}).play(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
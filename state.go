@@ -0,0 +1,430 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultDedupeWindow is how long an alert claim blocks other instances from repeating it
+const DefaultDedupeWindow = 10 * time.Minute
+
+// StateStore is state shared between OpenSeat instances watching the same CRNs,
+// so redundant instances can coordinate instead of sending duplicate alerts or
+// each spending a channel's whole rate limit, and an instance starting up knows
+// which sections the others last saw open. Each instance still checks every CRN
+// itself, so the rest keep watching if one goes down.
+type StateStore interface {
+	// Claim records key as handled for ttl. Returns true if this call made the claim,
+	// or false if another instance already holds an unexpired claim on the key.
+	Claim(key string, ttl time.Duration) (bool, error)
+
+	// Spend counts one use of key's budget of max uses per window, at now. Returns
+	// false, without counting it, if max uses were already counted in the window
+	// before now, by any instance.
+	Spend(key string, max int, window time.Duration, now time.Time) (bool, error)
+
+	// SetOpen records whether the section key names was open when last checked.
+	SetOpen(key string, open bool) error
+
+	// WasOpen returns whether any instance last saw the section key names open,
+	// with known false if none has recorded it.
+	WasOpen(key string) (open, known bool, err error)
+}
+
+// newStateStore builds the state store selected by the config's stateBackend.
+func (c Config) newStateStore() (StateStore, error) {
+	switch c.StateBackend {
+	case "", "memory":
+		return newMemoryStateStore(), nil
+	case "file":
+		if c.StateDir == "" {
+			return nil, fmt.Errorf(`stateBackend "file" requires stateDir`)
+		}
+		return &fileStateStore{dir: c.StateDir}, nil
+	case "redis":
+		if c.RedisURL == "" {
+			return nil, fmt.Errorf(`stateBackend "redis" requires redisUrl`)
+		}
+		return newRedisStateStore(c.RedisURL)
+	default:
+		return nil, fmt.Errorf("unknown stateBackend %q", c.StateBackend)
+	}
+}
+
+// dedupeWindow returns how long alert claims last.
+func (c Config) dedupeWindow() time.Duration {
+	if c.DedupeWindowMinutes > 0 {
+		return time.Duration(c.DedupeWindowMinutes) * time.Minute
+	}
+	return DefaultDedupeWindow
+}
+
+// openEventID identifies a seat-open alert for a CRN. Every instance watching the
+// same CRN and terms derives the same ID, so they can dedupe alerts through a StateStore.
+func (c Config) openEventID(crn string) string {
+	return fmt.Sprintf("seat-open:%s:%s", strings.Join(c.forCRN(crn).termList(), ","), crn)
+}
+
+// watchStateID identifies a CRN's open or closed state, derived like openEventID.
+func (c Config) watchStateID(crn string) string {
+	return fmt.Sprintf("watch:%s:%s", strings.Join(c.forCRN(crn).termList(), ","), crn)
+}
+
+// loadWatchState marks the sections another instance last saw open as open, so
+// this one doesn't announce them again just for having started after them. A
+// store that can't be reached leaves every section closed, as without one.
+func (c Config) loadWatchState(store StateStore, courses []CourseStatus) {
+	for i := range courses {
+		open, known, err := store.WasOpen(c.watchStateID(courses[i].CRN))
+		if err != nil {
+			PrintWatchStateError(err)
+			return
+		}
+		if known && open {
+			courses[i].Open = true
+			PrintSharedOpen(courses[i].CRN)
+		}
+	}
+}
+
+// saveWatchState records a section's state for the other instances.
+func (c Config) saveWatchState(store StateStore, crn string, open bool) {
+	if err := store.SetOpen(c.watchStateID(crn), open); err != nil {
+		PrintWatchStateError(err)
+	}
+}
+
+// ===================
+// In-memory store
+// ===================
+
+// memoryStateStore keeps claims, budgets, and watch state in process, for a single instance
+type memoryStateStore struct {
+	mu      sync.Mutex
+	claims  map[string]time.Time   // key -> expiry
+	budgets map[string][]time.Time // key -> uses, oldest first
+	open    map[string]bool        // key -> last seen open
+}
+
+func newMemoryStateStore() *memoryStateStore {
+	return &memoryStateStore{claims: make(map[string]time.Time), budgets: make(map[string][]time.Time), open: make(map[string]bool)}
+}
+
+func (m *memoryStateStore) Claim(key string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if expiry, ok := m.claims[key]; ok && time.Now().Before(expiry) {
+		return false, nil
+	}
+	m.claims[key] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (m *memoryStateStore) Spend(key string, max int, window time.Duration, now time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	uses, ok := spendBudget(m.budgets[key], max, window, now)
+	m.budgets[key] = uses
+	return ok, nil
+}
+
+func (m *memoryStateStore) SetOpen(key string, open bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.open[key] = open
+	return nil
+}
+
+func (m *memoryStateStore) WasOpen(key string) (bool, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	open, known := m.open[key]
+	return open, known, nil
+}
+
+// spendBudget drops uses that have left the window before now, then counts a use
+// at now if fewer than max remain. Returns the uses left and whether now counted.
+func spendBudget(uses []time.Time, max int, window time.Duration, now time.Time) ([]time.Time, bool) {
+	for len(uses) > 0 && now.Sub(uses[0]) >= window {
+		uses = uses[1:]
+	}
+	if len(uses) >= max {
+		return uses, false
+	}
+	return append(uses, now), true
+}
+
+// ===================
+// File store
+// ===================
+
+// fileStateStore keeps one claim, budget, or state file per key in a directory
+// shared between instances (a network share or synced folder). Claims rely on
+// exclusive file creation, which is atomic on local filesystems and NFSv3+.
+type fileStateStore struct {
+	dir string
+}
+
+// unsafeKeyChars matches characters that aren't safe in claim file names
+var unsafeKeyChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+func (f *fileStateStore) Claim(key string, ttl time.Duration) (bool, error) {
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return false, fmt.Errorf("state: %w", err)
+	}
+	path := filepath.Join(f.dir, unsafeKeyChars.ReplaceAllString(key, "_")+".claim")
+
+	// two attempts: the second follows removing an expired claim
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			host, _ := os.Hostname()
+			fmt.Fprintf(file, "%s\n%s pid %d\n", time.Now().Add(ttl).Format(time.RFC3339), host, os.Getpid())
+			return true, file.Close()
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return false, fmt.Errorf("state: %w", err)
+		}
+
+		if !claimExpired(path) {
+			return false, nil
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return false, fmt.Errorf("state: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// fileLockWait is how long lockFile waits for another process to finish with a
+// file, and fileLockStale how old a lock must be to belong to a crashed one
+const (
+	fileLockWait  = 2 * time.Second
+	fileLockStale = 10 * time.Second
+)
+
+// Spend keeps each budget's uses in a file, one RFC 3339 time per line. A lock
+// file, created exclusively like a claim, keeps instances from counting at once.
+func (f *fileStateStore) Spend(key string, max int, window time.Duration, now time.Time) (bool, error) {
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return false, fmt.Errorf("state: %w", err)
+	}
+	path := filepath.Join(f.dir, unsafeKeyChars.ReplaceAllString(key, "_")+".budget")
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return false, fmt.Errorf("state: %w", err)
+	}
+	defer unlock()
+
+	var uses []time.Time
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("state: %w", err)
+	}
+	for _, line := range strings.Fields(string(data)) {
+		if t, err := time.Parse(time.RFC3339Nano, line); err == nil {
+			uses = append(uses, t)
+		}
+	}
+
+	uses, ok := spendBudget(uses, max, window, now)
+	var out strings.Builder
+	for _, t := range uses {
+		out.WriteString(t.Format(time.RFC3339Nano) + "\n")
+	}
+	if err := os.WriteFile(path, []byte(out.String()), 0o644); err != nil {
+		return false, fmt.Errorf("state: %w", err)
+	}
+	return ok, nil
+}
+
+// lockFile takes a lock file, created exclusively like a claim, waiting for
+// another process to release it and breaking locks left by one that crashed.
+// Returns the unlock func.
+func lockFile(path string) (func(), error) {
+	deadline := time.Now().Add(fileLockWait)
+	for {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > fileLockStale {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is locked by another process", filepath.Base(path))
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// SetOpen writes "open" or "closed" to the key's state file, through a temporary
+// file renamed into place so another instance never reads half of it.
+func (f *fileStateStore) SetOpen(key string, open bool) error {
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return fmt.Errorf("state: %w", err)
+	}
+	state := "closed"
+	if open {
+		state = "open"
+	}
+	path := filepath.Join(f.dir, unsafeKeyChars.ReplaceAllString(key, "_")+".state")
+	tmp, err := os.CreateTemp(f.dir, ".state-*")
+	if err != nil {
+		return fmt.Errorf("state: %w", err)
+	}
+	_, err = tmp.WriteString(state + "\n")
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("state: %w", err)
+	}
+	return nil
+}
+
+func (f *fileStateStore) WasOpen(key string) (bool, bool, error) {
+	data, err := os.ReadFile(filepath.Join(f.dir, unsafeKeyChars.ReplaceAllString(key, "_")+".state"))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("state: %w", err)
+	}
+	switch strings.TrimSpace(string(data)) {
+	case "open":
+		return true, true, nil
+	case "closed":
+		return false, true, nil
+	}
+	return false, false, nil
+}
+
+// claimExpired reports whether the claim file's recorded expiry has passed.
+// Unreadable claims are treated as held so a half-written claim is never stolen.
+func claimExpired(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Is(err, os.ErrNotExist)
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	expiry, err := time.Parse(time.RFC3339, line)
+	if err != nil {
+		return false
+	}
+	return time.Now().After(expiry)
+}
+
+// ===================
+// Redis store
+// ===================
+
+// redisKeyPrefix namespaces OpenSeat's keys in a shared Redis
+const redisKeyPrefix = "openseat:"
+
+// redisStateStore keeps claims, budgets, and watch state in Redis, so any number
+// of instances (on any machines that can reach the server) can coordinate
+type redisStateStore struct {
+	client *redis.Client
+}
+
+// newRedisStateStore connects to the Redis server at a redis:// or rediss:// URL.
+func newRedisStateStore(redisURL string) (*redisStateStore, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redisUrl: %w", err)
+	}
+	return &redisStateStore{client: redis.NewClient(opts)}, nil
+}
+
+func (r *redisStateStore) Claim(key string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	host, _ := os.Hostname()
+	owner := fmt.Sprintf("%s pid %d", host, os.Getpid())
+	// SET NX is atomic, so exactly one instance wins each claim
+	err := r.client.SetArgs(ctx, redisKeyPrefix+key, owner, redis.SetArgs{Mode: "NX", TTL: ttl}).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("state: %w", err)
+	}
+	return true, nil
+}
+
+// spendScript counts a use in a sorted set of use times, scored in milliseconds,
+// after dropping those that have left the window. It runs atomically, so instances
+// can't both take the last use.
+var spendScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1] - ARGV[2])
+if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], ARGV[1], ARGV[4])
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+return 1
+`)
+
+func (r *redisStateStore) Spend(key string, max int, window time.Duration, now time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	host, _ := os.Hostname()
+	// the member only has to be unique, so two instances' uses in the same millisecond both count
+	use := fmt.Sprintf("%d %s pid %d", now.UnixNano(), host, os.Getpid())
+	ok, err := spendScript.Run(ctx, r.client, []string{redisKeyPrefix + key}, now.UnixMilli(), window.Milliseconds(), max, use).Int()
+	if err != nil {
+		return false, fmt.Errorf("state: %w", err)
+	}
+	return ok == 1, nil
+}
+
+func (r *redisStateStore) SetOpen(key string, open bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	state := "closed"
+	if open {
+		state = "open"
+	}
+	if err := r.client.Set(ctx, redisKeyPrefix+key, state, 0).Err(); err != nil {
+		return fmt.Errorf("state: %w", err)
+	}
+	return nil
+}
+
+func (r *redisStateStore) WasOpen(key string) (bool, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	state, err := r.client.Get(ctx, redisKeyPrefix+key).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("state: %w", err)
+	}
+	return state == "open", state == "open" || state == "closed", nil
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// ===================
+// StateStore tests
+// ===================
+
+func TestMemoryStateStore_ClaimOnce(t *testing.T) {
+	store := newMemoryStateStore()
+
+	if ok, _ := store.Claim("k", time.Minute); !ok {
+		t.Error("expected first claim to succeed")
+	}
+	if ok, _ := store.Claim("k", time.Minute); ok {
+		t.Error("expected second claim to fail")
+	}
+}
+
+func TestMemoryStateStore_ClaimExpires(t *testing.T) {
+	store := newMemoryStateStore()
+	store.Claim("k", -time.Second)
+
+	if ok, _ := store.Claim("k", time.Minute); !ok {
+		t.Error("expected expired claim to be reclaimable")
+	}
+}
+
+func TestFileStateStore_SharedBetweenInstances(t *testing.T) {
+	dir := t.TempDir()
+	a := &fileStateStore{dir: dir}
+	b := &fileStateStore{dir: dir}
+
+	ok, err := a.Claim("seat-open:202601:12345", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("first instance claim = %v, %v; want true, nil", ok, err)
+	}
+	ok, err = b.Claim("seat-open:202601:12345", time.Minute)
+	if err != nil || ok {
+		t.Errorf("second instance claim = %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestFileStateStore_ExpiredClaimIsReplaced(t *testing.T) {
+	dir := t.TempDir()
+	store := &fileStateStore{dir: dir}
+	store.Claim("k", -time.Second)
+
+	if ok, err := store.Claim("k", time.Minute); err != nil || !ok {
+		t.Errorf("claim after expiry = %v, %v; want true, nil", ok, err)
+	}
+}
+
+func TestFileStateStore_UnreadableClaimIsHeld(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "k.claim"), []byte("garbage"), 0o644)
+	store := &fileStateStore{dir: dir}
+
+	if ok, _ := store.Claim("k", time.Minute); ok {
+		t.Error("expected unparseable claim to be treated as held")
+	}
+}
+
+func TestNewStateStore(t *testing.T) {
+	if _, err := (Config{}).newStateStore(); err != nil {
+		t.Errorf("default backend: unexpected error: %v", err)
+	}
+	if _, err := (Config{StateBackend: "file"}).newStateStore(); err == nil {
+		t.Error("expected error for file backend without stateDir")
+	}
+	if _, err := (Config{StateBackend: "redis"}).newStateStore(); err == nil {
+		t.Error("expected error for redis backend without redisUrl")
+	}
+	if _, err := (Config{StateBackend: "carrier-pigeon"}).newStateStore(); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
+
+func TestMemoryStateStore_SpendWindow(t *testing.T) {
+	store := newMemoryStateStore()
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	if ok, _ := store.Spend("sms", 1, 10*time.Minute, now); !ok {
+		t.Fatal("expected the first use to fit the budget")
+	}
+	if ok, _ := store.Spend("sms", 1, 10*time.Minute, now.Add(9*time.Minute)); ok {
+		t.Error("expected a second use inside the window to be refused")
+	}
+	if ok, _ := store.Spend("sms", 1, 10*time.Minute, now.Add(10*time.Minute)); !ok {
+		t.Error("expected a use once the first left the window")
+	}
+}
+
+func TestFileStateStore_SpendSharedBetweenInstances(t *testing.T) {
+	dir := t.TempDir()
+	a, b := &fileStateStore{dir: dir}, &fileStateStore{dir: dir}
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	if ok, err := a.Spend("ratelimit:sms", 2, 10*time.Minute, now); err != nil || !ok {
+		t.Fatalf("first use = %v, %v; want true, nil", ok, err)
+	}
+	if ok, err := b.Spend("ratelimit:sms", 2, 10*time.Minute, now.Add(time.Minute)); err != nil || !ok {
+		t.Fatalf("second use = %v, %v; want true, nil", ok, err)
+	}
+	if ok, err := a.Spend("ratelimit:sms", 2, 10*time.Minute, now.Add(2*time.Minute)); err != nil || ok {
+		t.Errorf("third use = %v, %v; want the shared budget spent", ok, err)
+	}
+	if ok, _ := b.Spend("ratelimit:sms", 2, 10*time.Minute, now.Add(10*time.Minute)); !ok {
+		t.Error("expected a use once the first left the window")
+	}
+}
+
+func TestFileStateStore_SpendBreaksStaleLock(t *testing.T) {
+	dir := t.TempDir()
+	store := &fileStateStore{dir: dir}
+	lock := filepath.Join(dir, "sms.budget.lock")
+	os.WriteFile(lock, nil, 0o644)
+	old := time.Now().Add(-time.Minute)
+	os.Chtimes(lock, old, old)
+
+	if ok, err := store.Spend("sms", 1, time.Minute, time.Now()); err != nil || !ok {
+		t.Errorf("Spend = %v, %v; want the crashed instance's lock broken", ok, err)
+	}
+}
+
+func TestStateStores_WatchStateSharedBetweenInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	dir := t.TempDir()
+	memory := newMemoryStateStore()
+	pairs := map[string][2]StateStore{
+		"memory": {memory, memory},
+		"file":   {&fileStateStore{dir: dir}, &fileStateStore{dir: dir}},
+	}
+	a, _ := newRedisStateStore("redis://" + server.Addr())
+	b, _ := newRedisStateStore("redis://" + server.Addr())
+	pairs["redis"] = [2]StateStore{a, b}
+
+	for name, pair := range pairs {
+		if _, known, err := pair[1].WasOpen("watch:202601:12345"); err != nil || known {
+			t.Errorf("%s: expected an unrecorded section unknown, got %v, %v", name, known, err)
+		}
+		if err := pair[0].SetOpen("watch:202601:12345", true); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if open, known, err := pair[1].WasOpen("watch:202601:12345"); err != nil || !known || !open {
+			t.Errorf("%s: expected the other instance to see it open, got %v, %v, %v", name, open, known, err)
+		}
+		pair[0].SetOpen("watch:202601:12345", false)
+		if open, known, _ := pair[1].WasOpen("watch:202601:12345"); !known || open {
+			t.Errorf("%s: expected the other instance to see it closed, got %v, %v", name, open, known)
+		}
+	}
+}
+
+func TestLoadWatchState_MarksSharedOpenSections(t *testing.T) {
+	cfg := Config{Term: "202601", StateBackend: "file", StateDir: t.TempDir()}
+	first, _ := cfg.newStateStore()
+	cfg.saveWatchState(first, "12345", true)
+	cfg.saveWatchState(first, "12346", false)
+
+	courses := []CourseStatus{{CRN: "12345"}, {CRN: "12346"}, {CRN: "12347"}}
+	second, _ := cfg.newStateStore()
+	cfg.loadWatchState(second, courses)
+	if !courses[0].Open || courses[1].Open || courses[2].Open {
+		t.Errorf("expected only 12345 open, got %+v", courses)
+	}
+}
+
+// ===================
+// notifyOpen tests
+// ===================
+
+func TestNotifyOpen_DedupesAcrossInstances(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{Email: "test@example.com", Term: "202601", StateBackend: "file", StateDir: dir}
+	course := CourseStatus{CRN: "12345", Name: "Intro to Testing"}
+
+	first, second := &MockEmailSender{}, &MockEmailSender{}
+	storeA, _ := cfg.newStateStore()
+	storeB, _ := cfg.newStateStore()
+	cfg.notifyOpen(storeA, first, course)
+	cfg.notifyOpen(storeB, second, course)
+
+	if len(first.Sent) != 1 {
+		t.Errorf("first instance sent %d emails, want 1", len(first.Sent))
+	}
+	if len(second.Sent) != 0 {
+		t.Errorf("second instance sent %d emails, want 0", len(second.Sent))
+	}
+}
+
+func TestRedisStateStore_SharedBetweenInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	cfg := Config{StateBackend: "redis", RedisURL: "redis://" + server.Addr()}
+
+	a, err := cfg.newStateStore()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := cfg.newStateStore()
+
+	if ok, err := a.Claim("seat-open:202601:12345", time.Minute); err != nil || !ok {
+		t.Fatalf("first instance claim = %v, %v; want true, nil", ok, err)
+	}
+	if ok, err := b.Claim("seat-open:202601:12345", time.Minute); err != nil || ok {
+		t.Errorf("second instance claim = %v, %v; want false, nil", ok, err)
+	}
+
+	server.FastForward(2 * time.Minute)
+	if ok, err := b.Claim("seat-open:202601:12345", time.Minute); err != nil || !ok {
+		t.Errorf("claim after expiry = %v, %v; want true, nil", ok, err)
+	}
+}
+
+func TestRedisStateStore_Unreachable(t *testing.T) {
+	store, err := newRedisStateStore("redis://127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Claim("k", time.Minute); err == nil {
+		t.Error("expected error for unreachable server")
+	}
+}
+
+func TestRedisStateStore_SpendSharedBetweenInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	a, _ := newRedisStateStore("redis://" + server.Addr())
+	b, _ := newRedisStateStore("redis://" + server.Addr())
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	if ok, err := a.Spend("ratelimit:sms", 1, 10*time.Minute, now); err != nil || !ok {
+		t.Fatalf("first use = %v, %v; want true, nil", ok, err)
+	}
+	if ok, err := b.Spend("ratelimit:sms", 1, 10*time.Minute, now.Add(time.Minute)); err != nil || ok {
+		t.Errorf("second instance's use = %v, %v; want the shared budget spent", ok, err)
+	}
+	if ok, err := b.Spend("ratelimit:sms", 1, 10*time.Minute, now.Add(10*time.Minute)); err != nil || !ok {
+		t.Errorf("use after the window = %v, %v; want true, nil", ok, err)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StatusWriteInterval is how often the status file is rewritten while waiting between checks,
+// so tools can tell a stalled or killed watcher from an idle one by its updated time
+const StatusWriteInterval = 10 * time.Second
+
+// Watcher states reported in the status file
+const (
+	StatusWatching = "watching"
+	StatusPaused   = "paused"
+	StatusStopped  = "stopped"
+)
+
+// watchStatus is the status file's contents
+type watchStatus struct {
+	State   string        `json:"state"` // watching, paused, or stopped
+	PID     int           `json:"pid"`
+	Started time.Time     `json:"started"`
+	Updated time.Time     `json:"updated"`
+	Uptime  int64         `json:"uptimeSeconds"`
+	Open    int           `json:"open"` // how many watched sections are open
+	CRNs    []crnStatus   `json:"crns"`
+	Courses []courseState `json:"courses,omitempty"`
+}
+
+// crnStatus is one watched CRN in the status file
+type crnStatus struct {
+	CRN       string    `json:"crn"`
+	Name      string    `json:"name"`
+	Term      string    `json:"term,omitempty"`
+	Open      bool      `json:"open"`
+	Done      bool      `json:"done"` // no longer watched, see afterOpen
+	Checks    int       `json:"checks"`
+	Errors    int       `json:"errors"`
+	LastCheck time.Time `json:"lastCheck,omitzero"`
+	NextCheck time.Time `json:"nextCheck,omitzero"`
+	LastError string    `json:"lastError,omitempty"`
+}
+
+// courseState is one course watched for new sections in the status file
+type courseState struct {
+	Code      string    `json:"code"`
+	Sections  int       `json:"sections"`
+	NextCheck time.Time `json:"nextCheck,omitzero"`
+}
+
+// checkCounts tracks one CRN's checks for the status file, over the whole run
+type checkCounts struct {
+	checks, errors int
+	last           time.Time
+	lastError      string
+}
+
+// statusWriter keeps the status file up to date, and tracks the status openseatctl asks for
+// even when no status file is configured.
+type statusWriter struct {
+	path      string
+	started   time.Time
+	counts    map[string]*checkCounts
+	lastWrite time.Time
+	failed    bool // a write failure has been reported
+}
+
+// newStatusWriter returns a writer for the configured status file, if any.
+func (c Config) newStatusWriter(now time.Time) *statusWriter {
+	return &statusWriter{path: c.StatusFile, started: now, counts: make(map[string]*checkCounts)}
+}
+
+// record counts one check of a CRN.
+func (w *statusWriter) record(crn string, err error, now time.Time) {
+	n, ok := w.counts[crn]
+	if !ok {
+		n = &checkCounts{}
+		w.counts[crn] = n
+	}
+	n.checks++
+	n.last = now
+	if err != nil {
+		n.errors++
+		n.lastError = err.Error()
+	} else {
+		n.lastError = ""
+	}
+}
+
+// due reports whether the file should be rewritten while waiting.
+func (w *statusWriter) due(now time.Time) bool {
+	return w.path != "" && now.Sub(w.lastWrite) >= StatusWriteInterval
+}
+
+// write replaces the status file with the current state. The first failure is
+// reported; later ones are not, so a bad path doesn't flood the terminal.
+func (w *statusWriter) write(state string, now time.Time, courses []CourseStatus, watches []*courseWatch) {
+	if w.path == "" {
+		return
+	}
+	w.lastWrite = now
+	if err := writeFileAtomic(w.path, w.status(state, now, courses, watches)); err != nil && !w.failed {
+		w.failed = true
+		PrintStatusFileError(err)
+	}
+}
+
+// status builds the status file's contents.
+func (w *statusWriter) status(state string, now time.Time, courses []CourseStatus, watches []*courseWatch) watchStatus {
+	s := watchStatus{
+		State:   state,
+		PID:     os.Getpid(),
+		Started: w.started,
+		Updated: now,
+		Uptime:  int64(now.Sub(w.started).Seconds()),
+		Open:    openCount(courses),
+		CRNs:    []crnStatus{},
+	}
+	for _, course := range courses {
+		cs := crnStatus{CRN: course.CRN, Name: course.Name, Term: course.Term, Open: course.Open, Done: course.Found}
+		if !course.Found {
+			cs.NextCheck = wallClock(course.nextCheck)
+		}
+		if n, ok := w.counts[course.CRN]; ok {
+			cs.Checks, cs.Errors, cs.LastCheck, cs.LastError = n.checks, n.errors, wallClock(n.last), n.lastError
+		}
+		s.CRNs = append(s.CRNs, cs)
+	}
+	for _, watch := range watches {
+		s.Courses = append(s.Courses, courseState{Code: watch.Code, Sections: len(watch.known), NextCheck: wallClock(watch.nextCheck)})
+	}
+	return s
+}
+
+// uptime is how long the watcher has been running.
+func (s watchStatus) uptime() time.Duration {
+	return time.Duration(s.Uptime) * time.Second
+}
+
+// statusState is the watcher state to report: paused while checks are on hold.
+func (ctl *controls) statusState(now time.Time) string {
+	if ctl.holding(now) {
+		return StatusPaused
+	}
+	return StatusWatching
+}
+
+// writeFileAtomic writes v as JSON to a temporary file and renames it into place,
+// so readers never see a half-written file.
+func writeFileAtomic(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
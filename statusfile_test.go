@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ===================
+// statusWriter tests
+// ===================
+
+func TestStatusWriter_NoFileWithoutPath(t *testing.T) {
+	t.Chdir(t.TempDir())
+	w := Config{}.newStatusWriter(time.Now())
+
+	w.record("12345", nil, time.Now())
+	w.write(StatusWatching, time.Now(), nil, nil)
+	if w.due(time.Now().Add(time.Hour)) {
+		t.Error("expected no rewrites without statusFile")
+	}
+	if entries, _ := os.ReadDir("."); len(entries) != 0 {
+		t.Errorf("expected no files written, got %d", len(entries))
+	}
+	// the status is still tracked for openseatctl
+	if got := w.status(StatusWatching, time.Now(), []CourseStatus{{CRN: "12345"}}, nil); got.CRNs[0].Checks != 1 {
+		t.Errorf("Checks = %d, want 1", got.CRNs[0].Checks)
+	}
+}
+
+func TestStatusWriter_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+	start := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	w := Config{StatusFile: path}.newStatusWriter(start)
+
+	w.record("12345", nil, start.Add(time.Minute))
+	w.record("12345", errors.New("timeout"), start.Add(2*time.Minute))
+	w.record("67890", nil, start.Add(2*time.Minute))
+	courses := []CourseStatus{
+		{CRN: "12345", Name: "Data Structures", nextCheck: start.Add(3 * time.Minute)},
+		{CRN: "67890", Name: "Algorithms", Open: true, Found: true},
+	}
+	watches := []*courseWatch{{Code: "CS-3114", known: map[string]bool{"11111": true, "22222": true}}}
+	w.write(StatusWatching, start.Add(2*time.Minute), courses, watches)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("status file not written: %v", err)
+	}
+	var got watchStatus
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if got.State != StatusWatching || got.PID != os.Getpid() || got.Uptime != 120 || got.Open != 1 {
+		t.Errorf("unexpected header: %+v", got)
+	}
+	if len(got.CRNs) != 2 {
+		t.Fatalf("expected 2 CRNs, got %d", len(got.CRNs))
+	}
+	first := got.CRNs[0]
+	if first.Checks != 2 || first.Errors != 1 || !first.NextCheck.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("unexpected CRN status: %+v", first)
+	}
+	if first.LastError != "timeout" {
+		t.Errorf("LastError = %q, want timeout", first.LastError)
+	}
+	if second := got.CRNs[1]; !second.Done || !second.Open || !second.NextCheck.IsZero() {
+		t.Errorf("unexpected CRN status: %+v", second)
+	}
+	if len(got.Courses) != 1 || got.Courses[0].Sections != 2 {
+		t.Errorf("unexpected courses: %+v", got.Courses)
+	}
+}
+
+func TestStatusWriter_Due(t *testing.T) {
+	now := time.Now()
+	w := Config{StatusFile: filepath.Join(t.TempDir(), "status.json")}.newStatusWriter(now)
+
+	w.write(StatusWatching, now, nil, nil)
+	if w.due(now.Add(time.Second)) {
+		t.Error("expected no rewrite right after a write")
+	}
+	if !w.due(now.Add(StatusWriteInterval)) {
+		t.Error("expected a rewrite after StatusWriteInterval")
+	}
+}
+
+func TestStatusState(t *testing.T) {
+	now := time.Now()
+	var ctl controls
+	if got := ctl.statusState(now); got != StatusWatching {
+		t.Errorf("got %q, want watching", got)
+	}
+	ctl.pauseUntil(now.Add(time.Minute))
+	if got := ctl.statusState(now); got != StatusPaused {
+		t.Errorf("got %q, want paused", got)
+	}
+}
+
+// ===================
+// writeFileAtomic tests
+// ===================
+
+func TestWriteFileAtomic_LeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "status.json")
+
+	for i := range 3 {
+		if err := writeFileAtomic(path, map[string]int{"n": i}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only status.json, got %d files", len(entries))
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AlertSuggestion suggests which of the open alternative sections to register for
+const AlertSuggestion = "suggestion"
+
+// avoidDayPenalty is how many minutes outside the preferred hours a meeting on
+// an avoided day counts as, on top of its length
+const avoidDayPenalty = 60
+
+// TimePreferences are when you'd rather have class, used to pick between open
+// alternative sections
+type TimePreferences struct {
+	Earliest  string `json:"earliest"`  // earliest time you'd like class to start, e.g. "9:00AM" (optional)
+	Latest    string `json:"latest"`    // latest time you'd like class to end, e.g. "5:00PM" (optional)
+	AvoidDays string `json:"avoidDays"` // days you'd rather not have class, e.g. "F" (optional)
+
+	earliest, latest int // minutes after midnight, latest 0 when unset
+}
+
+// applyDefaults parses the preferred hours and checks the avoided days.
+func (p *TimePreferences) applyDefaults() error {
+	for _, f := range []struct {
+		value string
+		into  *int
+	}{{p.Earliest, &p.earliest}, {p.Latest, &p.latest}} {
+		if f.value == "" {
+			continue
+		}
+		t, err := time.Parse("3:04PM", strings.ToUpper(strings.ReplaceAll(f.value, " ", "")))
+		if err != nil {
+			return fmt.Errorf("invalid preferences time %q: use a time like 9:00AM", f.value)
+		}
+		*f.into = t.Hour()*60 + t.Minute()
+	}
+	if p.latest != 0 && p.latest <= p.earliest {
+		return fmt.Errorf("preferences: latest must be after earliest")
+	}
+	p.AvoidDays = strings.ReplaceAll(strings.ToUpper(p.AvoidDays), " ", "")
+	if strings.Trim(p.AvoidDays, "MTWRFSU") != "" {
+		return fmt.Errorf("invalid preferences avoidDays %q: use the timetable's day letters, M T W R F S U", p.AvoidDays)
+	}
+	return nil
+}
+
+// penalty scores how far a meeting strays from the preferences, in minutes a
+// week outside the preferred hours. Lower is better.
+func (p TimePreferences) penalty(m meetingTime) int {
+	per := 0
+	if m.start < p.earliest {
+		per += p.earliest - m.start
+	}
+	if p.latest != 0 && m.end > p.latest {
+		per += m.end - p.latest
+	}
+	total := 0
+	for _, day := range m.days {
+		total += per
+		if strings.ContainsRune(p.AvoidDays, day) {
+			total += m.end - m.start + avoidDayPenalty
+		}
+	}
+	return total
+}
+
+// suggestion is a set of open sections that can all be taken together
+type suggestion struct {
+	picks   []CourseStatus
+	penalty int
+}
+
+// suggestSchedule picks one open section from each group of alternatives with
+// any open, so that none clash with each other or the schedule, filling as many
+// groups as possible and then straying least from the time preferences. It
+// returns nothing unless at least two alternatives are open, since with one
+// there's no choice to make.
+func (c Config) suggestSchedule(courses []CourseStatus) []CourseStatus {
+	var slots [][]CourseStatus
+	open := 0
+	for _, g := range c.Groups {
+		if !g.Any {
+			continue
+		}
+		var options []CourseStatus
+		for _, course := range courses {
+			if course.Open && !course.Found && slices.Contains(g.CRNs, course.CRN) {
+				options = append(options, course)
+			}
+		}
+		if len(options) > 0 {
+			slots = append(slots, options)
+			open += len(options)
+		}
+	}
+	if open < 2 {
+		return nil
+	}
+
+	var best suggestion
+	var pick func(slot int, s suggestion)
+	pick = func(slot int, s suggestion) {
+		if slot == len(slots) {
+			if len(s.picks) > len(best.picks) || (len(s.picks) == len(best.picks) && s.penalty < best.penalty) {
+				best = suggestion{picks: slices.Clone(s.picks), penalty: s.penalty}
+			}
+			return
+		}
+		for _, option := range slots[slot] {
+			if !c.fits(option, s.picks) {
+				continue
+			}
+			penalty := 0
+			if m, ok := parseMeeting(option.Meeting); ok {
+				penalty = c.Preferences.penalty(m)
+			}
+			pick(slot+1, suggestion{picks: append(s.picks, option), penalty: s.penalty + penalty})
+		}
+		pick(slot+1, s) // leave the group out if nothing in it fits
+	}
+	pick(0, suggestion{})
+	return best.picks
+}
+
+// fits reports whether a section can be taken alongside the picked ones and the schedule.
+func (c Config) fits(course CourseStatus, picked []CourseStatus) bool {
+	if _, clashes := c.conflict(course); clashes {
+		return false
+	}
+	m, ok := parseMeeting(course.Meeting)
+	if !ok {
+		return true
+	}
+	for _, other := range picked {
+		if o, ok := parseMeeting(other.Meeting); ok && m.overlaps(o) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkSuggestion suggests what to register for when several alternatives are
+// open, alerting when the suggestion differs from the last one. It returns the
+// suggested CRNs, to pass back in next round.
+func (c Config) checkSuggestion(courses []CourseStatus, last []string, sender EmailSender) []string {
+	picks := c.suggestSchedule(courses)
+	crns := crnsOf(picks)
+	if len(picks) == 0 || slices.Equal(crns, last) {
+		return crns
+	}
+
+	PrintSuggestion(picks)
+	if c.notifying() {
+		var body strings.Builder
+		fmt.Fprintf(&body, "%s\n\n", T("email.suggestion.body"))
+		for _, course := range picks {
+			fmt.Fprintf(&body, "%s  %s  %s\n", course.CRN, course.Name, course.Meeting)
+		}
+		c.notify(sender, Alert{Event: AlertSuggestion, Subject: T("email.suggestion.subject", strings.Join(crns, ", ")), Body: body.String(), Term: c.termOf(picks[0])})
+	}
+	return crns
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ===================
+// TimePreferences tests
+// ===================
+
+func TestLoadConfig_Preferences(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "preferences": {"earliest": "9:00AM", "latest": "5:00 pm", "avoidDays": "f"}}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := cfg.Preferences
+	if p.earliest != 9*60 || p.latest != 17*60 || p.AvoidDays != "F" {
+		t.Errorf("got %+v", p)
+	}
+}
+
+func TestLoadConfig_PreferencesErrors(t *testing.T) {
+	tests := map[string]string{
+		"time":      `{"crns": ["12345"], "preferences": {"earliest": "morning"}}`,
+		"order":     `{"crns": ["12345"], "preferences": {"earliest": "5:00PM", "latest": "9:00AM"}}`,
+		"avoidDays": `{"crns": ["12345"], "preferences": {"avoidDays": "Friday"}}`,
+	}
+	for name, config := range tests {
+		if _, err := loadConfig(createTempConfig(t, config)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTimePreferences_Penalty(t *testing.T) {
+	p := TimePreferences{Earliest: "9:00AM", AvoidDays: "F"}
+	if err := p.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	early, _ := parseMeeting("M W 8:00AM-8:50AM")
+	if got := p.penalty(early); got != 2*60 {
+		t.Errorf("penalty = %d, want an hour early on each of two days", got)
+	}
+	friday, _ := parseMeeting("F 10:00AM-10:50AM")
+	if got := p.penalty(friday); got != 50+avoidDayPenalty {
+		t.Errorf("penalty = %d for an avoided day", got)
+	}
+}
+
+// ===================
+// suggestSchedule tests
+// ===================
+
+func suggestConfig(t *testing.T, prefs TimePreferences) Config {
+	t.Helper()
+	cfg := Config{Preferences: prefs, Groups: []WatchGroup{
+		{Name: "STAT 3005", Any: true, CRNs: []string{"11111", "11112"}},
+		{Name: "CS 3114", Any: true, CRNs: []string{"22221", "22222"}},
+	}}
+	if err := cfg.Preferences.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return cfg
+}
+
+func TestSuggestSchedule_AvoidsClashes(t *testing.T) {
+	cfg := suggestConfig(t, TimePreferences{})
+	courses := []CourseStatus{
+		{CRN: "11111", Open: true, Meeting: "M W F 10:10AM-11:00AM"},
+		{CRN: "11112", Open: true, Meeting: "T R 9:30AM-10:45AM"},
+		{CRN: "22221", Open: true, Meeting: "T R 9:30AM-10:45AM"},
+	}
+	if got := crnsOf(cfg.suggestSchedule(courses)); !slices.Equal(got, []string{"11111", "22221"}) {
+		t.Errorf("got %v, want the STAT section that doesn't clash with the only open CS one", got)
+	}
+}
+
+func TestSuggestSchedule_Preferences(t *testing.T) {
+	cfg := suggestConfig(t, TimePreferences{Earliest: "10:00AM"})
+	courses := []CourseStatus{
+		{CRN: "11111", Open: true, Meeting: "M W F 8:00AM-8:50AM"},
+		{CRN: "11112", Open: true, Meeting: "M W F 1:25PM-2:15PM"},
+	}
+	if got := crnsOf(cfg.suggestSchedule(courses)); !slices.Equal(got, []string{"11112"}) {
+		t.Errorf("got %v, want the section after 10AM", got)
+	}
+}
+
+func TestSuggestSchedule_NeedsAChoice(t *testing.T) {
+	cfg := suggestConfig(t, TimePreferences{})
+	courses := []CourseStatus{{CRN: "11111", Open: true}, {CRN: "22221"}}
+	if got := cfg.suggestSchedule(courses); got != nil {
+		t.Errorf("got %v, want no suggestion with only one section open", got)
+	}
+}
+
+func TestCheckSuggestion_AlertsOnChange(t *testing.T) {
+	cfg := suggestConfig(t, TimePreferences{})
+	cfg.Email = "test@example.com"
+	courses := []CourseStatus{
+		{CRN: "11111", Name: "Statistics", Open: true, Meeting: "M W F 10:10AM-11:00AM"},
+		{CRN: "22221", Name: "Data Structures", Open: true, Meeting: "T R 9:30AM-10:45AM"},
+	}
+	sender := &MockEmailSender{}
+
+	last := cfg.checkSuggestion(courses, nil, sender)
+	cfg.checkSuggestion(courses, last, sender)
+	if len(sender.Sent) != 1 || sender.Sent[0].Subject != "Register for 11111, 22221" || !strings.Contains(sender.Sent[0].Body, "22221  Data Structures  T R 9:30AM-10:45AM") {
+		t.Fatalf("expected one suggestion, got %+v", sender.Sent)
+	}
+	if got := (Alert{Event: AlertSuggestion}).severity(); got != SeverityCritical {
+		t.Errorf("expected critical, got %s", got)
+	}
+}
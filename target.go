@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AlertAllClear is sent when every watch in the target schedule is open at once
+const AlertAllClear = "all_clear"
+
+// validateTargetSchedule checks that each target schedule entry is a CRN or a
+// group's name, adding target CRNs to the watched CRNs.
+func (c *Config) validateTargetSchedule() error {
+	for _, entry := range c.TargetSchedule {
+		if slices.ContainsFunc(c.Groups, func(g WatchGroup) bool { return g.Name == entry }) {
+			continue
+		}
+		if err := validateCRN(entry); err != nil {
+			return fmt.Errorf("targetSchedule lists %q, which is neither a CRN nor a group's name", entry)
+		}
+		if !slices.Contains(c.CRNs, entry) {
+			c.CRNs = append(c.CRNs, entry)
+		}
+	}
+	return nil
+}
+
+// targetEntry is one watch in the target schedule: a CRN, or a group whose
+// sections are all needed, or one of which will do
+type targetEntry struct {
+	name string
+	crns []string
+	any  bool
+}
+
+// targetEntries resolves the target schedule's CRNs and group names.
+func (c Config) targetEntries() []targetEntry {
+	var entries []targetEntry
+	for _, name := range c.TargetSchedule {
+		entry := targetEntry{name: name, crns: []string{name}}
+		for _, g := range c.Groups {
+			if g.Name == name {
+				entry = targetEntry{name: name, crns: g.CRNs, any: g.Any}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// inTarget reports whether a CRN is part of the target schedule, so it keeps
+// being watched until the whole schedule is open, even with afterOpen "stop".
+func (c Config) inTarget(crn string) bool {
+	return slices.ContainsFunc(c.targetEntries(), func(e targetEntry) bool { return slices.Contains(e.crns, crn) })
+}
+
+// targetWatch tracks whether the all-clear has been sent
+type targetWatch struct {
+	alerted bool // the whole target schedule was open and announced
+}
+
+// alignTarget makes every target schedule CRN due as soon as one is, so the
+// whole schedule is checked in the same round.
+func (c Config) alignTarget(courses []CourseStatus, now time.Time) {
+	if len(c.TargetSchedule) == 0 {
+		return
+	}
+	var crns []string
+	for _, e := range c.targetEntries() {
+		crns = append(crns, e.crns...)
+	}
+	alignGroups([]*groupWatch{{WatchGroup: WatchGroup{CRNs: crns}}}, courses, now)
+}
+
+// checkTarget sends the all-clear once every watch in the target schedule was
+// open in this round of checks, and sends it again only after the schedule
+// stops being all open. Sections no longer watched, like ones enrolled in, are
+// left out. With afterOpen "stop", the target CRNs stop being watched once it's
+// sent. It returns how many CRNs stopped.
+func (c Config) checkTarget(t *targetWatch, courses []CourseStatus, checked map[string]bool, muted bool, store StateStore, sender EmailSender) (stopped int) {
+	var open []CourseStatus
+	var members []int
+	allOpen := true
+	for _, e := range c.targetEntries() {
+		var entryOpen []CourseStatus
+		watched := 0
+		for i, course := range courses {
+			if course.Found || !slices.Contains(e.crns, course.CRN) {
+				continue
+			}
+			if !checked[course.CRN] {
+				return 0 // wait for a round that checks the whole schedule
+			}
+			watched++
+			members = append(members, i)
+			if course.Open {
+				entryOpen = append(entryOpen, course)
+			}
+		}
+		if watched == 0 {
+			continue
+		}
+		if len(entryOpen) == 0 || (!e.any && len(entryOpen) < watched) {
+			allOpen = false
+		}
+		open = append(open, entryOpen...)
+	}
+
+	if !allOpen || len(members) == 0 {
+		t.alerted = false
+		return 0
+	}
+	if t.alerted {
+		return 0
+	}
+	t.alerted = true
+
+	PrintAllClear(open, !muted)
+	if !muted {
+		go func() {
+			if err := c.AlertSound.play(); err != nil {
+				PrintSoundFailed(err)
+			}
+		}()
+	}
+	c.notifyAllClear(store, sender, open)
+	if c.AfterOpen == AfterOpenStop {
+		for _, i := range members {
+			courses[i].Found = true
+			stopped++
+		}
+	}
+	return stopped
+}
+
+// notifyAllClear sends the all-clear, claimed so redundant instances send it once.
+func (c Config) notifyAllClear(store StateStore, sender EmailSender, open []CourseStatus) {
+	if !c.notifying() {
+		return
+	}
+
+	claimed, err := store.Claim(c.openEventID("target:"+strings.Join(c.TargetSchedule, "+")), c.dedupeWindow())
+	if err != nil {
+		PrintStateError(err)
+	} else if !claimed {
+		PrintAlreadyNotified(T("watch.target"))
+		return
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\n\n", T("email.all_clear.body"))
+	for _, course := range open {
+		fmt.Fprintf(&body, "%s  %s  (%s)\n", course.CRN, course.Name, c.termOf(course))
+	}
+	c.notify(sender, Alert{Event: AlertAllClear, Subject: T("email.all_clear.subject"), Body: body.String(), Term: c.termOf(open[0])})
+}
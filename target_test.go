@@ -0,0 +1,92 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// ===================
+// Target schedule config tests
+// ===================
+
+func TestLoadConfig_TargetSchedule(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "groups": [{"name": "STAT", "any": true, "crns": ["22221", "22222"]}], "targetSchedule": ["12345", "33333", "STAT"]}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(cfg.CRNs, "33333") {
+		t.Errorf("expected target CRNs to be watched, got %v", cfg.CRNs)
+	}
+	if !cfg.inTarget("22222") || cfg.inTarget("44444") {
+		t.Error("expected the group's CRNs, and only those listed, in the target schedule")
+	}
+}
+
+func TestLoadConfig_TargetScheduleUnknownEntry(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "targetSchedule": ["Biology"]}`)
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected an error for an entry that's neither a CRN nor a group")
+	}
+}
+
+// ===================
+// checkTarget tests
+// ===================
+
+func TestCheckTarget_OnlyWhenAllOpen(t *testing.T) {
+	cfg := Config{
+		Email: "test@example.com", AfterOpen: AfterOpenStop,
+		Groups:         []WatchGroup{{Name: "STAT", Any: true, CRNs: []string{"22221", "22222"}}},
+		TargetSchedule: []string{"12345", "STAT"},
+	}
+	courses := []CourseStatus{{CRN: "12345", Open: true}, {CRN: "22221"}, {CRN: "22222"}, {CRN: "67890", Open: true}}
+	checked := map[string]bool{"12345": true, "22221": true, "22222": true}
+	sender := &MockEmailSender{}
+	var target targetWatch
+
+	cfg.checkTarget(&target, courses, checked, true, newMemoryStateStore(), sender)
+	if len(sender.Sent) != 0 {
+		t.Fatalf("expected no all-clear while STAT is full, got %+v", sender.Sent)
+	}
+
+	courses[2].Open = true
+	stopped := cfg.checkTarget(&target, courses, checked, true, newMemoryStateStore(), sender)
+	if len(sender.Sent) != 1 || sender.Sent[0].Subject != "All clear: your whole target schedule is open" {
+		t.Fatalf("expected the all-clear, got %+v", sender.Sent)
+	}
+	if stopped != 3 || courses[3].Found {
+		t.Errorf("expected the target CRNs, and only those, stopped; got %d", stopped)
+	}
+}
+
+func TestCheckTarget_WaitsForEveryCheck(t *testing.T) {
+	cfg := Config{Email: "test@example.com", TargetSchedule: []string{"12345", "12346"}}
+	courses := []CourseStatus{{CRN: "12345", Open: true}, {CRN: "12346", Open: true}}
+	sender := &MockEmailSender{}
+
+	cfg.checkTarget(&targetWatch{}, courses, map[string]bool{"12345": true}, true, newMemoryStateStore(), sender)
+	if len(sender.Sent) != 0 {
+		t.Errorf("expected no all-clear without the whole schedule checked in the same round, got %+v", sender.Sent)
+	}
+}
+
+func TestCheckTarget_AlertsAgainAfterClosing(t *testing.T) {
+	cfg := Config{Email: "test@example.com", AfterOpen: AfterOpenKeep, TargetSchedule: []string{"12345", "12346"}}
+	courses := []CourseStatus{{CRN: "12345", Open: true}, {CRN: "12346", Open: true}}
+	checked := map[string]bool{"12345": true, "12346": true}
+	store := newMemoryStateStore()
+	sender := &MockEmailSender{}
+	var target targetWatch
+
+	cfg.checkTarget(&target, courses, checked, true, store, sender)
+	cfg.checkTarget(&target, courses, checked, true, store, sender)
+	if len(sender.Sent) != 1 {
+		t.Fatalf("expected the all-clear once while the schedule stays open, got %d", len(sender.Sent))
+	}
+	courses[1].Open = false
+	cfg.checkTarget(&target, courses, checked, true, store, sender)
+	if target.alerted {
+		t.Error("expected the all-clear to rearm once part of the schedule closed")
+	}
+}
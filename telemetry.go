@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names OpenSeat's tracer and meter
+const instrumentationName = "github.com/brennanhumphrey/openseat"
+
+// Telemetry configures exporting traces and metrics over OTLP/HTTP
+type Telemetry struct {
+	Endpoint    string      `json:"endpoint"`    // collector URL, e.g. http://localhost:4318 (optional if OTEL_EXPORTER_OTLP_ENDPOINT is set)
+	ServiceName string      `json:"serviceName"` // service.name reported with every span and metric (defaults to "openseat")
+	Push        MetricsPush `json:"push"`        // also push metrics to InfluxDB or Graphite (optional)
+}
+
+// Spans and instruments come from the global providers, so they do nothing
+// until Telemetry.start installs real ones.
+var (
+	tracer = otel.Tracer(instrumentationName)
+	meter  = otel.Meter(instrumentationName)
+
+	checkCounter, _   = meter.Int64Counter("openseat.checks", metric.WithDescription("Section availability checks, by result"))
+	searchDuration, _ = meter.Float64Histogram("openseat.search.duration", metric.WithDescription("Time spent on one timetable search"), metric.WithUnit("s"))
+	notifyCounter, _  = meter.Int64Counter("openseat.notifications", metric.WithDescription("Notifications sent, by channel and outcome"))
+	limitedCounter, _ = meter.Int64Counter("openseat.notifications.limited", metric.WithDescription("Notifications skipped by a channel's rate limit"))
+)
+
+// applyDefaults fills in the service name and checks the endpoint and push settings.
+func (t *Telemetry) applyDefaults() error {
+	if t.ServiceName == "" {
+		t.ServiceName = "openseat"
+	}
+	if err := t.Push.applyDefaults(); err != nil {
+		return err
+	}
+	if t.Endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(t.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid telemetry endpoint %q: must be an http:// or https:// URL", t.Endpoint)
+	}
+	return nil
+}
+
+// enabled reports whether telemetry should be exported, either because an endpoint is
+// configured or because the standard OTLP environment variables name one.
+func (t Telemetry) enabled() bool {
+	return t.Endpoint != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != ""
+}
+
+// start installs OTLP trace and metric exporters, and the metrics push if
+// configured, as the global providers. The returned function flushes anything
+// still buffered and must be called before exiting.
+func (t Telemetry) start() (func(), error) {
+	if !t.enabled() && !t.Push.enabled() {
+		return func() {}, nil
+	}
+
+	res := resource.NewSchemaless(attribute.String("service.name", t.ServiceName))
+	var providers []interface{ Shutdown(context.Context) error }
+	metricOpts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+
+	if t.enabled() {
+		ctx := context.Background()
+		var traceOpts []otlptracehttp.Option
+		var exportOpts []otlpmetrichttp.Option
+		if t.Endpoint != "" {
+			traceOpts = append(traceOpts, otlptracehttp.WithEndpointURL(t.Endpoint+"/v1/traces"))
+			exportOpts = append(exportOpts, otlpmetrichttp.WithEndpointURL(t.Endpoint+"/v1/metrics"))
+		}
+		traceExporter, err := otlptracehttp.New(ctx, traceOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start trace exporter: %w", err)
+		}
+		metricExporter, err := otlpmetrichttp.New(ctx, exportOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start metric exporter: %w", err)
+		}
+
+		tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter), sdktrace.WithResource(res))
+		otel.SetTracerProvider(tracerProvider)
+		providers = append(providers, tracerProvider)
+		metricOpts = append(metricOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)))
+	}
+	if t.Push.enabled() {
+		exporter := &pushExporter{MetricsPush: t.Push, service: t.ServiceName}
+		metricOpts = append(metricOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(t.Push.interval()))))
+	}
+
+	meterProvider := sdkmetric.NewMeterProvider(metricOpts...)
+	otel.SetMeterProvider(meterProvider)
+	providers = append(providers, meterProvider)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var errs []error
+		for _, p := range providers {
+			errs = append(errs, p.Shutdown(ctx))
+		}
+		if err := errors.Join(errs...); err != nil {
+			PrintTelemetryError(err)
+		}
+	}, nil
+}
+
+// startSpan starts a span beneath the config's current span and returns a copy
+// of the config carrying it, so work done through the copy is traced beneath it.
+func (c Config) startSpan(name string, attrs ...attribute.KeyValue) (Config, trace.Span) {
+	ctx, span := tracer.Start(c.context(), name, trace.WithAttributes(attrs...))
+	c.traceCtx = ctx
+	return c, span
+}
+
+// endSpan marks the span failed if err is set, then ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// recordCheck counts one availability check by its result: open, closed, or error.
+func (c Config) recordCheck(open bool, err error) {
+	result := "closed"
+	switch {
+	case err != nil:
+		result = "error"
+	case open:
+		result = "open"
+	}
+	checkCounter.Add(c.context(), 1, metric.WithAttributes(attribute.String("result", result)))
+}
+
+// recordSearch records how long a timetable search took and whether it failed.
+func (c Config) recordSearch(start time.Time, err error) {
+	searchDuration.Record(c.context(), time.Since(start).Seconds(), metric.WithAttributes(
+		attribute.String("backend", c.Backend), attribute.Bool("error", err != nil)))
+}
+
+// recordNotify counts one notification sent (or attempted) on a channel.
+func (c Config) recordNotify(channel string, err error) {
+	notifyCounter.Add(c.context(), 1, metric.WithAttributes(
+		attribute.String("channel", channel), attribute.Bool("error", err != nil)))
+}
+
+// recordNotifyLimited counts one notification a channel's rate limit held back.
+func (c Config) recordNotifyLimited(channel string) {
+	limitedCounter.Add(c.context(), 1, metric.WithAttributes(attribute.String("channel", channel)))
+}
+
+// context returns the config's current trace context.
+func (c Config) context() context.Context {
+	if c.traceCtx == nil {
+		return context.Background()
+	}
+	return c.traceCtx
+}
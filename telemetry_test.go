@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// ===================
+// telemetry config tests
+// ===================
+
+func TestTelemetryApplyDefaults(t *testing.T) {
+	var tel Telemetry
+	if err := tel.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tel.ServiceName != "openseat" {
+		t.Errorf("ServiceName = %q, want openseat", tel.ServiceName)
+	}
+}
+
+func TestTelemetryApplyDefaults_ErrorInvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"localhost:4318", "ftp://collector", "http://"} {
+		tel := Telemetry{Endpoint: endpoint}
+		if err := tel.applyDefaults(); err == nil {
+			t.Errorf("%q: expected error", endpoint)
+		}
+	}
+}
+
+func TestTelemetryEnabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	if (Telemetry{}).enabled() {
+		t.Error("expected telemetry off with no endpoint")
+	}
+	if !(Telemetry{Endpoint: "http://localhost:4318"}).enabled() {
+		t.Error("expected telemetry on with an endpoint")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	if !(Telemetry{}).enabled() {
+		t.Error("expected telemetry on with OTEL_EXPORTER_OTLP_ENDPOINT set")
+	}
+}
+
+func TestTelemetryStart_DisabledIsNoop(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	stop, err := (Telemetry{}).start()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stop()
+}
+
+// ===================
+// span tests
+// ===================
+
+func TestCheckSectionOpen_Spans(t *testing.T) {
+	recorder := recordSpans()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<table class="dataentrytable"><tr><td>12345</td><td>CS-3114</td><td>Data Structures</td></tr></table>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Campus: "0", Term: "202601"}
+	if _, err := cfg.checkSectionOpen("12345"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := cfg.getSection("12345"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = append(spans[s.Name()], s)
+	}
+	assertChild(t, spans, "check", "search")
+	assertChild(t, spans, "lookup", "parse")
+}
+
+var (
+	spanRecorder     *tracetest.SpanRecorder
+	spanRecorderOnce sync.Once
+)
+
+// recordSpans sends spans to a recorder shared by the tests. The global tracer
+// only ever hands spans to the first provider set, so it's set up just once;
+// tests pick their spans out from the others'.
+func recordSpans() *tracetest.SpanRecorder {
+	spanRecorderOnce.Do(func() {
+		spanRecorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	})
+	return spanRecorder
+}
+
+// assertChild checks that some recorded span named child has a recorded parent span named parent.
+func assertChild(t *testing.T, spans map[string][]sdktrace.ReadOnlySpan, parent, child string) {
+	t.Helper()
+	for _, p := range spans[parent] {
+		for _, c := range spans[child] {
+			if c.Parent().SpanID() == p.SpanContext().SpanID() {
+				return
+			}
+		}
+	}
+	t.Errorf("no %s span recorded beneath a %s span", child, parent)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// AutoTerm is the term value that asks OpenSeat to pick the registration term itself
+const AutoTerm = "auto"
+
+// termsURL returns the timetable request page that carries the term dropdown.
+// The dropdown lives on P_DispRequest, the form that posts to P_ProcRequest.
+func (c Config) termsURL() string {
+	return strings.Replace(c.getBaseURL(), "P_ProcRequest", "P_DispRequest", 1)
+}
+
+// termOption is one entry in the timetable's term dropdown
+type termOption struct {
+	Code string // e.g. "202609"
+	Name string // e.g. "Fall 2026"
+}
+
+// fetchTerms retrieves the term codes offered in the timetable's term dropdown,
+// in the order the timetable lists them.
+func (c Config) fetchTerms() ([]string, error) {
+	options, err := c.fetchTermOptions()
+	if err != nil {
+		return nil, err
+	}
+	terms := make([]string, len(options))
+	for i, opt := range options {
+		terms[i] = opt.Code
+	}
+	return terms, nil
+}
+
+// fetchTermOptions retrieves the terms offered in the timetable's term dropdown,
+// in the order the timetable lists them.
+func (c Config) fetchTermOptions() ([]termOption, error) {
+	doc, err := c.fetchRequestForm()
+	if err != nil {
+		return nil, err
+	}
+
+	var terms []termOption
+	doc.Find(`select[name="TERMYEAR"] option`).Each(func(i int, opt *goquery.Selection) {
+		value := strings.TrimSpace(opt.AttrOr("value", ""))
+		if isTermCode(value) {
+			terms = append(terms, termOption{Code: value, Name: strings.Join(strings.Fields(opt.Text()), " ")})
+		}
+	})
+
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("no terms found in timetable term dropdown")
+	}
+	return terms, nil
+}
+
+// fetchRequestForm retrieves and parses the timetable's search form page.
+func (c Config) fetchRequestForm() (*goquery.Document, error) {
+	resp, err := c.httpClient().Get(c.termsURL())
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, resp.StatusCode, resp.Status)
+	}
+
+	raw, err := readResponse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseResponse(raw)
+}
+
+// isTermCode reports whether s looks like a YYYYMM term code.
+func isTermCode(s string) bool {
+	if len(s) != 6 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// expectedTerm guesses which term students are registering for on the given date.
+// Spring registration runs in the fall, and fall registration runs in the spring,
+// so October onward looks ahead to next spring and March onward looks ahead to fall.
+func expectedTerm(now time.Time) string {
+	year := now.Year()
+	switch month := now.Month(); {
+	case month >= time.October:
+		return fmt.Sprintf("%d01", year+1)
+	case month >= time.March:
+		return fmt.Sprintf("%d09", year)
+	default:
+		return fmt.Sprintf("%d01", year)
+	}
+}
+
+// pickTerm chooses the expected term if the timetable offers it, otherwise the
+// latest offered term that isn't past the expected one, otherwise the first offered term.
+func pickTerm(available []string, expected string) string {
+	if slices.Contains(available, expected) {
+		return expected
+	}
+
+	best := ""
+	for _, term := range available {
+		// term codes are YYYYMM, so string order matches chronological order
+		if term <= expected && term > best {
+			best = term
+		}
+	}
+	if best != "" {
+		return best
+	}
+	return available[0]
+}
+
+// resolveAutoTerms replaces any "auto" term with the term detected from the timetable.
+func (c *Config) resolveAutoTerms(now time.Time) error {
+	if c.Term != AutoTerm && !slices.Contains(c.Terms, AutoTerm) && !slices.Contains(slices.Collect(maps.Values(c.TermOverrides)), AutoTerm) {
+		return nil
+	}
+
+	available, err := c.fetchTerms()
+	if err != nil {
+		return fmt.Errorf("failed to detect current term: %w", err)
+	}
+	term := pickTerm(available, expectedTerm(now))
+
+	if c.Term == AutoTerm {
+		c.Term = term
+	}
+	for i := range c.Terms {
+		if c.Terms[i] == AutoTerm {
+			c.Terms[i] = term
+		}
+	}
+	for crn, t := range c.TermOverrides {
+		if t == AutoTerm {
+			c.TermOverrides[crn] = term
+		}
+	}
+	return nil
+}
+
+// validateTermOverrides checks that every term override names a watched CRN and a term code.
+func (c Config) validateTermOverrides() error {
+	for crn, term := range c.TermOverrides {
+		if term != AutoTerm && !isTermCode(term) {
+			return fmt.Errorf("invalid term %q for CRN %s in termOverrides: must be a term code like 202601 or \"auto\"", term, crn)
+		}
+		if !slices.Contains(c.CRNs, crn) {
+			return fmt.Errorf("termOverrides lists CRN %s, which is not in crns", crn)
+		}
+	}
+	return nil
+}
+
+// watchedTerms returns every term being watched: the configured terms followed by
+// any other terms named in termOverrides, in sorted order, then the hosts' terms.
+func (c Config) watchedTerms() []string {
+	terms := slices.Clone(c.termList())
+	overrides := slices.Sorted(maps.Values(c.TermOverrides))
+	for _, h := range c.Hosts {
+		overrides = append(overrides, h.Term)
+	}
+	for _, term := range overrides {
+		if term != "" && !slices.Contains(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// termOf returns the term a watched course was found in, falling back to its configured term.
+func (c Config) termOf(course CourseStatus) string {
+	if course.Term != "" {
+		return course.Term
+	}
+	return c.forCRN(course.CRN).Term
+}
+
+// termLabel returns the term to show beside a watched course, or "" when only
+// one term is watched and the config box already shows it.
+func (c Config) termLabel(term string) string {
+	if len(c.watchedTerms()) < 2 {
+		return ""
+	}
+	return term
+}
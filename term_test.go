@@ -0,0 +1,192 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===================
+// expectedTerm tests
+// ===================
+
+func TestExpectedTerm(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2025-10-15", "202601"},
+		{"2025-12-31", "202601"},
+		{"2026-01-20", "202601"},
+		{"2026-02-28", "202601"},
+		{"2026-03-01", "202609"},
+		{"2026-09-05", "202609"},
+	}
+
+	for _, tt := range tests {
+		now, _ := time.Parse("2006-01-02", tt.date)
+		if got := expectedTerm(now); got != tt.want {
+			t.Errorf("expectedTerm(%s) = %q, want %q", tt.date, got, tt.want)
+		}
+	}
+}
+
+// ===================
+// pickTerm tests
+// ===================
+
+func TestPickTerm_ExpectedAvailable(t *testing.T) {
+	if got := pickTerm([]string{"202609", "202606", "202601"}, "202609"); got != "202609" {
+		t.Errorf("got %q, want %q", got, "202609")
+	}
+}
+
+func TestPickTerm_FallsBackToLatestBeforeExpected(t *testing.T) {
+	// Fall not yet published: summer is the latest term at or before it
+	if got := pickTerm([]string{"202607", "202606", "202601"}, "202609"); got != "202607" {
+		t.Errorf("got %q, want %q", got, "202607")
+	}
+}
+
+func TestPickTerm_AllInFuture(t *testing.T) {
+	if got := pickTerm([]string{"202701", "202609"}, "202601"); got != "202701" {
+		t.Errorf("got %q, want first offered term %q", got, "202701")
+	}
+}
+
+// ===================
+// resolveAutoTerms tests
+// ===================
+
+const termDropdownHTML = `<html><form>
+	<select name="TERMYEAR">
+		<option value="">Select a Term</option>
+		<option value="202601">Spring 2026</option>
+		<option value="202509">Fall 2025</option>
+	</select>
+</form></html>`
+
+func TestResolveAutoTerms_ReplacesAuto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		w.Write([]byte(termDropdownHTML))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Term: AutoTerm, Terms: []string{AutoTerm, "202606"}}
+	now, _ := time.Parse("2006-01-02", "2025-11-01")
+	if err := cfg.resolveAutoTerms(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Term != "202601" {
+		t.Errorf("Term = %q, want %q", cfg.Term, "202601")
+	}
+	if cfg.Terms[0] != "202601" || cfg.Terms[1] != "202606" {
+		t.Errorf("Terms = %v, want [202601 202606]", cfg.Terms)
+	}
+}
+
+func TestResolveAutoTerms_NoAutoSkipsRequest(t *testing.T) {
+	cfg := Config{BaseURL: "http://localhost:99999", Term: "202601"}
+	if err := cfg.resolveAutoTerms(time.Now()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestResolveAutoTerms_EmptyDropdown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><select name="TERMYEAR"></select></html>`))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Term: AutoTerm}
+	if err := cfg.resolveAutoTerms(time.Now()); err == nil {
+		t.Error("expected error when dropdown has no terms")
+	}
+}
+
+func TestTermsURL(t *testing.T) {
+	cfg := Config{BaseURL: DefaultTimetableURL}
+	want := "https://selfservice.banner.vt.edu/ssb/HZSKVTSC.P_DispRequest"
+	if got := cfg.termsURL(); got != want {
+		t.Errorf("termsURL() = %q, want %q", got, want)
+	}
+}
+
+// ===================
+// termOverrides tests
+// ===================
+
+func TestLoadConfig_TermOverrides(t *testing.T) {
+	cfg, err := loadConfig(createTempConfig(t, `{"crns": ["12345", "67890"], "term": "202601", "termOverrides": {"67890": "202512"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := cfg.forCRN("67890").variants(); len(got) != 1 || got[0].Term != "202512" {
+		t.Errorf("override: got %d variants, want one for 202512", len(got))
+	}
+	if got := cfg.forCRN("12345").Term; got != "202601" {
+		t.Errorf("no override: term = %q, want %q", got, "202601")
+	}
+	if got := cfg.watchedTerms(); len(got) != 2 || got[0] != "202601" || got[1] != "202512" {
+		t.Errorf("watchedTerms() = %v, want [202601 202512]", got)
+	}
+}
+
+func TestLoadConfig_ErrorInvalidTermOverride(t *testing.T) {
+	tests := map[string]string{
+		"bad term":      `{"crns": ["12345"], "termOverrides": {"12345": "Spring"}}`,
+		"unwatched CRN": `{"crns": ["12345"], "termOverrides": {"67890": "202601"}}`,
+	}
+
+	for name, content := range tests {
+		if _, err := loadConfig(createTempConfig(t, content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestResolveAutoTerms_ReplacesAutoOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(termDropdownHTML))
+	}))
+	defer server.Close()
+
+	cfg := Config{BaseURL: server.URL, Term: "202509", TermOverrides: map[string]string{"12345": AutoTerm}}
+	now, _ := time.Parse("2006-01-02", "2025-11-01")
+	if err := cfg.resolveAutoTerms(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.TermOverrides["12345"]; got != "202601" {
+		t.Errorf("override = %q, want %q", got, "202601")
+	}
+}
+
+func TestTermLabel_OnlyWithSeveralTerms(t *testing.T) {
+	cfg := Config{Term: "202601"}
+	if got := cfg.termLabel("202601"); got != "" {
+		t.Errorf("one term: got %q, want no label", got)
+	}
+
+	cfg.TermOverrides = map[string]string{"12345": "202512"}
+	if got := cfg.termLabel("202512"); got != "202512" {
+		t.Errorf("several terms: got %q, want %q", got, "202512")
+	}
+}
+
+func TestNotifyOpen_IncludesTerm(t *testing.T) {
+	cfg := Config{Email: "test@example.com", Term: "202601", TermOverrides: map[string]string{"12345": "202512"}}
+	sender := &MockEmailSender{}
+
+	cfg.notifyOpen(newMemoryStateStore(), sender, CourseStatus{CRN: "12345", Name: "Intro to Testing"})
+
+	if len(sender.Sent) != 1 || !strings.Contains(sender.Sent[0].Body, "202512") {
+		t.Errorf("expected one email naming term 202512, got %+v", sender.Sent)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Built-in color themes
+const (
+	ThemeVT         = "vt"         // Virginia Tech maroon and orange (default)
+	ThemeColorblind = "colorblind" // Okabe-Ito palette, distinguishable with any color vision
+	ThemeMono       = "mono"       // no colors, only bold and dim
+)
+
+// palette assigns a color to each role the terminal UI uses
+type palette struct {
+	Success string // seats found, checks passed
+	Failure string // errors, missing CRNs
+	Warning string // notices that need attention
+	Accent  string // CRNs, spinners, highlights
+	Brand   string // boxes and dividers
+	Text    string // course names
+}
+
+// rgb returns the true color ANSI sequence for a color
+func rgb(r, g, b int) string {
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+}
+
+var themes = map[string]palette{
+	ThemeVT: {
+		Success: "\033[32m",
+		Failure: "\033[31m",
+		Warning: "\033[33m",
+		Accent:  rgb(207, 68, 32), // Burnt Orange #CF4420
+		Brand:   rgb(99, 0, 49),   // Chicago Maroon #630031
+		Text:    "\033[37m",
+	},
+	ThemeColorblind: {
+		Success: rgb(0, 114, 178),  // blue #0072B2
+		Failure: rgb(213, 94, 0),   // vermillion #D55E00
+		Warning: rgb(240, 228, 66), // yellow #F0E442
+		Accent:  rgb(230, 159, 0),  // orange #E69F00
+		Brand:   rgb(86, 180, 233), // sky blue #56B4E9
+		Text:    "\033[37m",
+	},
+	ThemeMono: {},
+}
+
+// bold returns the bold form of a color, or plain bold for no color.
+func bold(color string) string {
+	if color == "" {
+		return Bold
+	}
+	return strings.Replace(color, "\033[", "\033[1;", 1)
+}
+
+// themeNames returns the built-in theme names, sorted.
+func themeNames() []string {
+	var names []string
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// applyTheme sets the terminal UI colors from a built-in theme. With no theme
+// configured, NO_COLOR (https://no-color.org) selects the mono theme.
+func applyTheme(name string) error {
+	if name == "" {
+		name = ThemeVT
+		if os.Getenv("NO_COLOR") != "" {
+			name = ThemeMono
+		}
+	}
+	p, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q: available themes are %s", name, strings.Join(themeNames(), ", "))
+	}
+
+	Green, BoldGreen = p.Success, bold(p.Success)
+	Red, BoldRed = p.Failure, bold(p.Failure)
+	Yellow, BoldYellow = p.Warning, bold(p.Warning)
+	VTOrange, BoldVTOrange = p.Accent, bold(p.Accent)
+	VTMaroon, BoldVTMaroon = p.Brand, bold(p.Brand)
+	White, BoldWhite = p.Text, bold(p.Text)
+	return nil
+}
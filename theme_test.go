@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+)
+
+// ===================
+// theme tests
+// ===================
+
+func TestApplyTheme_Mono(t *testing.T) {
+	defer applyTheme(ThemeVT)
+
+	if err := applyTheme(ThemeMono); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if Green != "" || VTOrange != "" || VTMaroon != "" {
+		t.Errorf("mono theme left colors set: %q %q %q", Green, VTOrange, VTMaroon)
+	}
+	if BoldGreen != Bold {
+		t.Errorf("BoldGreen = %q, want plain bold", BoldGreen)
+	}
+}
+
+func TestApplyTheme_Colorblind(t *testing.T) {
+	defer applyTheme(ThemeVT)
+
+	applyTheme(ThemeColorblind)
+	if Green == Red || Green == "\033[32m" {
+		t.Errorf("success color %q should replace green and differ from failure", Green)
+	}
+	if BoldVTOrange != "\033[1;38;2;230;159;0m" {
+		t.Errorf("BoldVTOrange = %q", BoldVTOrange)
+	}
+}
+
+func TestApplyTheme_NoColor(t *testing.T) {
+	defer applyTheme(ThemeVT)
+	t.Setenv("NO_COLOR", "1")
+
+	applyTheme("")
+	if Green != "" {
+		t.Errorf("NO_COLOR should select the mono theme, got Green = %q", Green)
+	}
+
+	// an explicit theme wins over NO_COLOR
+	applyTheme(ThemeVT)
+	if Green == "" {
+		t.Error("explicit theme should keep colors")
+	}
+}
+
+func TestApplyTheme_Unknown(t *testing.T) {
+	if err := applyTheme("neon"); err == nil {
+		t.Error("expected error for an unknown theme")
+	}
+}
+
+func TestApplyTheme_VTMatchesDefaults(t *testing.T) {
+	applyTheme(ThemeVT)
+	if VTOrange != "\033[38;2;207;68;32m" || BoldVTMaroon != "\033[1;38;2;99;0;49m" {
+		t.Errorf("vt theme changed the VT colors: %q %q", VTOrange, BoldVTMaroon)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// timelineEntry is one line of the history command's output
+type timelineEntry struct {
+	Time time.Time
+	CRN  string
+	Name string
+	Text string
+}
+
+// runHistory prints the history file's events, optionally for one CRN and a recent window.
+func runHistory(args []string) error {
+	if len(args) > 0 && args[0] == "prune" {
+		return runHistoryPrune(args[1:])
+	}
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "path to the config `file`")
+	crn := fs.String("crn", "", "only show events for this `CRN`")
+	since := fs.String("since", "", "only show events after this long ago, like 7d or 12h, or a date like 2026-01-15")
+	fs.Parse(args)
+
+	if *crn != "" {
+		if err := validateCRN(*crn); err != nil {
+			return configError(err)
+		}
+	}
+	var from time.Time
+	if *since != "" {
+		var err error
+		if from, err = parseSince(*since, time.Now()); err != nil {
+			return configError(err)
+		}
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load config: %w", err))
+	}
+
+	events, err := readHistory(cfg.HistoryFile)
+	if err != nil {
+		return err
+	}
+	PrintTimeline(cfg.HistoryFile, buildTimeline(events, *crn, from))
+	PrintOpeningStats(buildOpeningStats(events, *crn), time.Now())
+	return nil
+}
+
+// parseSince reads the --since flag: a duration back from now with a d (days), w (weeks),
+// h, m, or s unit, or a YYYY-MM-DD date in local time.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if n, unit := s[:len(s)-1], s[len(s)-1:]; unit == "d" || unit == "w" {
+		days, err := strconv.Atoi(n)
+		if err == nil && days > 0 {
+			if unit == "w" {
+				days *= 7
+			}
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a duration like 7d, 2w, or 12h, or a date like 2026-01-15", s)
+}
+
+// buildTimeline describes the events for crn (or every CRN when empty) from the given
+// time on. Closings include how long the section stayed open, and seat counts are only
+// shown when they changed, so the output reads as each section's trajectory.
+func buildTimeline(events []HistoryEvent, crn string, from time.Time) []timelineEntry {
+	opened := make(map[string]time.Time)
+	watching := make(map[string]time.Time) // until the CRN's first opening
+	lastSeats := make(map[string]string)
+
+	var entries []timelineEntry
+	for _, e := range events {
+		if crn != "" && e.CRN != crn {
+			continue
+		}
+
+		// track state from every event so durations and changes are right at the window's edge
+		var text string
+		switch e.Event {
+		case EventWatching:
+			if _, ok := watching[e.CRN]; !ok {
+				watching[e.CRN] = e.Time
+				text = T("history.watching")
+			}
+		case EventOpen:
+			opened[e.CRN] = e.Time
+			text = T("history.opened")
+			if at, ok := watching[e.CRN]; ok && !at.IsZero() {
+				text = T("history.first_opened", humanDuration(e.Time.Sub(at)))
+				watching[e.CRN] = time.Time{}
+			}
+		case EventClosed:
+			if at, ok := opened[e.CRN]; ok {
+				text = T("history.closed_after", humanDuration(e.Time.Sub(at)))
+				delete(opened, e.CRN)
+			} else {
+				text = T("history.closed")
+			}
+		case EventObserved:
+			seats := T("history.seats", e.Seats, e.Capacity)
+			if seats != lastSeats[e.CRN] {
+				text = seats
+				lastSeats[e.CRN] = seats
+			}
+		case EventEnrolled:
+			text = T("history.enrolled")
+		case EventInstructorChanged:
+			text = T("history.instructor", e.Previous, e.Current)
+		case EventMeetingChanged:
+			text = T("history.meeting", e.Previous, e.Current)
+		case EventSectionAdded:
+			text = T("history.section_added")
+		case EventTermPublished:
+			text = T("history.term_published", e.Term)
+		default:
+			text = e.Event
+		}
+
+		if text == "" || e.Time.Before(from) {
+			continue
+		}
+		entries = append(entries, timelineEntry{Time: e.Time, CRN: e.CRN, Name: e.Name, Text: text})
+	}
+	return entries
+}
+
+// humanDuration formats a duration to its two largest units, e.g. "2d 4h", "3h 12m", or "45s".
+func humanDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	minutes := int(d/time.Minute) % 60
+	seconds := int(d/time.Second) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// ===================
+// parseSince tests
+// ===================
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.Local)
+	tests := map[string]time.Time{
+		"7d":         time.Date(2026, 1, 13, 12, 0, 0, 0, time.Local),
+		"2w":         time.Date(2026, 1, 6, 12, 0, 0, 0, time.Local),
+		"12h":        time.Date(2026, 1, 20, 0, 0, 0, 0, time.Local),
+		"2026-01-15": time.Date(2026, 1, 15, 0, 0, 0, 0, time.Local),
+	}
+
+	for s, want := range tests {
+		got, err := parseSince(s, now)
+		if err != nil {
+			t.Errorf("parseSince(%q): unexpected error: %v", s, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("parseSince(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestParseSince_Errors(t *testing.T) {
+	for _, s := range []string{"d", "0d", "-3d", "yesterday", "-1h"} {
+		if _, err := parseSince(s, time.Now()); err == nil {
+			t.Errorf("parseSince(%q): expected error", s)
+		}
+	}
+}
+
+// ===================
+// buildTimeline tests
+// ===================
+
+func TestBuildTimeline(t *testing.T) {
+	start := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	events := []HistoryEvent{
+		{Time: start, Event: EventObserved, CRN: "12345", Seats: "0", Capacity: "30"},
+		{Time: start.Add(15 * time.Minute), Event: EventObserved, CRN: "12345", Seats: "0", Capacity: "30"},
+		{Time: start.Add(30 * time.Minute), Event: EventOpen, CRN: "12345"},
+		{Time: start.Add(30 * time.Minute), Event: EventObserved, CRN: "12345", Seats: "2", Capacity: "30"},
+		{Time: start.Add(40 * time.Minute), Event: EventOpen, CRN: "67890"},
+		{Time: start.Add(2*time.Hour + 45*time.Minute), Event: EventClosed, CRN: "12345"},
+	}
+
+	got := buildTimeline(events, "12345", time.Time{})
+	want := []string{"seats 0 of 30", "opened", "seats 2 of 30", "closed after 2h 15m open"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries %+v, want %d", len(got), got, len(want))
+	}
+	for i, text := range want {
+		if got[i].Text != text {
+			t.Errorf("entry %d = %q, want %q", i, got[i].Text, text)
+		}
+	}
+}
+
+func TestBuildTimeline_SinceKeepsEarlierState(t *testing.T) {
+	start := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	events := []HistoryEvent{
+		{Time: start, Event: EventOpen, CRN: "12345"},
+		{Time: start.Add(3 * 24 * time.Hour), Event: EventClosed, CRN: "12345"},
+	}
+
+	got := buildTimeline(events, "", start.Add(24*time.Hour))
+	if len(got) != 1 || got[0].Text != "closed after 3d 0h open" {
+		t.Errorf("got %+v, want only the closing, with the full time open", got)
+	}
+}
+
+// ===================
+// humanDuration tests
+// ===================
+
+func TestHumanDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		45 * time.Second:               "45s",
+		12*time.Minute + 5*time.Second: "12m 5s",
+		3*time.Hour + 12*time.Minute:   "3h 12m",
+		50*time.Hour + 30*time.Minute:  "2d 2h",
+	}
+
+	for d, want := range tests {
+		if got := humanDuration(d); got != want {
+			t.Errorf("humanDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// TwilioSMS configures alerts texted through Twilio. Unlike an email-to-SMS
+// gateway, Twilio delivers to any carrier and reports failures. The account SID
+// and auth token come from TWILIO_ACCOUNT_SID and TWILIO_AUTH_TOKEN, as for WhatsApp.
+type TwilioSMS struct {
+	From                string   `json:"from"`                // Twilio number texts are sent from, e.g. +15405550100
+	MessagingServiceSID string   `json:"messagingServiceSid"` // Messaging service to send through instead of from, e.g. MG... (optional)
+	To                  []string `json:"to"`                  // Numbers to text, e.g. +15405551234
+}
+
+// applyDefaults checks there's a sender, and the numbers are in the E.164 format Twilio expects.
+func (s *TwilioSMS) applyDefaults() error {
+	if s.From == "" && s.MessagingServiceSID == "" && len(s.To) == 0 {
+		return nil
+	}
+	if len(s.To) == 0 {
+		return fmt.Errorf("twilioSms: to needs at least one number")
+	}
+	if s.From == "" && s.MessagingServiceSID == "" {
+		return fmt.Errorf("twilioSms: from or messagingServiceSid is required")
+	}
+	numbers := s.To
+	if s.From != "" {
+		numbers = append([]string{s.From}, s.To...)
+	}
+	for _, number := range numbers {
+		if !isE164(number) {
+			return fmt.Errorf("invalid twilioSms number %q: expected the international format, e.g. +15405551234", number)
+		}
+	}
+	if s.MessagingServiceSID != "" && !strings.HasPrefix(s.MessagingServiceSID, "MG") {
+		return fmt.Errorf("invalid twilioSms messagingServiceSid %q: expected a Twilio messaging service SID starting with MG", s.MessagingServiceSID)
+	}
+	return nil
+}
+
+// twilioSMSNotifier texts alerts through Twilio
+type twilioSMSNotifier struct {
+	TwilioSMS
+	accountSID string
+	authToken  string
+}
+
+// newNotifier reads the Twilio credentials from the environment.
+func (s TwilioSMS) newNotifier() (*twilioSMSNotifier, error) {
+	sid, token, err := twilioCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return &twilioSMSNotifier{TwilioSMS: s, accountSID: sid, authToken: token}, nil
+}
+
+func (n *twilioSMSNotifier) Name() string { return "Twilio" }
+
+// Notify texts every number a short version of the alert, trying them all even if
+// one fails.
+func (n *twilioSMSNotifier) Notify(a Alert) error {
+	var errs []error
+	for _, to := range n.To {
+		form := url.Values{"To": {to}, "Body": {smsText(a.Subject, a.Body)}}
+		if n.MessagingServiceSID != "" {
+			form.Set("MessagingServiceSid", n.MessagingServiceSID)
+		} else {
+			form.Set("From", n.From)
+		}
+		if err := sendTwilioMessage(n.accountSID, n.authToken, form); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", to, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// ===================
+// Twilio SMS config tests
+// ===================
+
+func TestLoadConfig_ErrorInvalidTwilioSMS(t *testing.T) {
+	for _, sms := range []string{
+		`{"from": "+15405550100"}`,
+		`{"to": ["+15405551234"]}`,
+		`{"from": "5405550100", "to": ["+15405551234"]}`,
+		`{"from": "+15405550100", "to": ["540-555-1234"]}`,
+		`{"messagingServiceSid": "service", "to": ["+15405551234"]}`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], "twilioSms": `+sms+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", sms)
+		}
+	}
+}
+
+func TestTwilioSMSNewNotifier_NeedsCredentials(t *testing.T) {
+	t.Setenv("TWILIO_ACCOUNT_SID", "")
+	t.Setenv("TWILIO_AUTH_TOKEN", "")
+	if _, err := (TwilioSMS{From: "+15405550100", To: []string{"+15405551234"}}).newNotifier(); err == nil {
+		t.Error("expected error without Twilio credentials")
+	}
+}
+
+// ===================
+// twilioSMSNotifier tests
+// ===================
+
+func TestTwilioSMSNotify(t *testing.T) {
+	forms := twilioServer(t, http.StatusCreated)
+	n := &twilioSMSNotifier{TwilioSMS: TwilioSMS{From: "+15405550100", To: []string{"+15405551234", "+15405559876"}}, accountSID: "ACtest", authToken: "token"}
+
+	if err := n.Notify(Alert{Event: EventOpen, Subject: "VT Course Section Open!", Body: "OPEN SEAT: Intro to Testing (CRN: 12345)\nRegister now"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*forms) != 2 {
+		t.Fatalf("expected a text to each number, got %d", len(*forms))
+	}
+	form := (*forms)[1]
+	if form.Get("From") != "+15405550100" || form.Get("To") != "+15405559876" {
+		t.Errorf("unexpected numbers: %v", form)
+	}
+	if body := form.Get("Body"); body != "OPEN SEAT: Intro to Testing (CRN: 12345)" {
+		t.Errorf("expected the body's first line, got %q", body)
+	}
+}
+
+func TestTwilioSMSNotify_MessagingService(t *testing.T) {
+	forms := twilioServer(t, http.StatusCreated)
+	n := &twilioSMSNotifier{TwilioSMS: TwilioSMS{MessagingServiceSID: "MGtest", To: []string{"+15405551234"}}, accountSID: "ACtest", authToken: "token"}
+
+	if err := n.Notify(Alert{Subject: "Seat open"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if form := (*forms)[0]; form.Get("MessagingServiceSid") != "MGtest" || form.Get("From") != "" {
+		t.Errorf("expected the messaging service to send, got %v", form)
+	}
+}
+
+func TestTwilioSMSNotify_Error(t *testing.T) {
+	twilioServer(t, http.StatusBadRequest)
+	n := &twilioSMSNotifier{TwilioSMS: TwilioSMS{From: "+15405550100", To: []string{"+15405551234"}}, accountSID: "ACtest", authToken: "token"}
+
+	if err := n.Notify(Alert{Subject: "Seat open"}); err == nil || !strings.Contains(err.Error(), "+15405551234") {
+		t.Errorf("expected the failed number in the error, got %v", err)
+	}
+}
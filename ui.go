@@ -2,11 +2,13 @@ package main
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 )
 
-// ANSI color codes
-const (
+// ANSI color codes. The colors are set from the selected theme; see applyTheme.
+var (
 	Reset      = "\033[0m"
 	Bold       = "\033[1m"
 	Dim        = "\033[2m"
@@ -30,8 +32,8 @@ const (
 	BoldVTOrange = "\033[1;38;2;207;68;32m"  // Bold Burnt Orange
 )
 
-// Nerd Font icons (requires a Nerd Font to display correctly)
-const (
+// Nerd Font icons (requires a Nerd Font to display correctly). See disableIcons.
+var (
 	IconSearch   = "\uf002" //  (nf-fa-search)
 	IconEmail    = "\uf0e0" //  (nf-fa-envelope)
 	IconClock    = "\uf017" //  (nf-fa-clock)
@@ -61,9 +63,16 @@ const banner = `
 // Box drawing width
 const boxWidth = 50
 
-// PrintBanner displays the ASCII art banner with VT colors
+// PrintBanner displays the ASCII art banner with VT colors, or the custom banner if one is set
 func PrintBanner() {
-	fmt.Printf(banner,
+	if bannerHidden {
+		return
+	}
+	if customBanner != "" {
+		fmt.Fprintf(out, "%s%s%s\n\n", BoldVTOrange, customBanner, Reset)
+		return
+	}
+	fmt.Fprintf(out, banner,
 		BoldVTOrange, Reset,
 		BoldVTOrange, Reset,
 		VTOrange, Reset,
@@ -71,7 +80,7 @@ func PrintBanner() {
 		VTMaroon, Reset,
 		VTMaroon, Reset,
 	)
-	fmt.Printf("%s%s  Virginia Tech Course Availability Monitor%s\n\n", Dim, IconGrad, Reset)
+	fmt.Fprintf(out, "%s%s  %s%s\n\n", Dim, IconGrad, T("banner.tagline"), Reset)
 }
 
 // Box drawing helpers (open-right style to avoid alignment issues with variable-width icons)
@@ -89,86 +98,845 @@ func boxLine(color string, content string) string {
 }
 
 // PrintConfigBox displays the configuration summary in a styled box
-func PrintConfigBox(crnCount int, email string, interval int, term string) {
-	fmt.Println(boxTop(VTMaroon))
-	fmt.Println(boxLine(VTMaroon, fmt.Sprintf("%s%s  Monitoring %s%d CRNs%s", VTOrange, IconTarget, BoldWhite, crnCount, Reset)))
+func PrintConfigBox(crnCount int, email string, interval int, term, session string) {
+	fmt.Fprintln(out, boxTop(VTMaroon))
+	fmt.Fprintln(out, boxLine(VTMaroon, fmt.Sprintf("%s%s  %s%s%s", VTOrange, IconTarget, BoldWhite, T("config.monitoring", crnCount), Reset)))
 	if email != "" {
-		fmt.Println(boxLine(VTMaroon, fmt.Sprintf("%s%s  %s%s%s", VTOrange, IconEmail, White, truncateString(email, 35), Reset)))
+		fmt.Fprintln(out, boxLine(VTMaroon, fmt.Sprintf("%s%s  %s%s%s", VTOrange, IconEmail, White, truncateString(email, 35), Reset)))
 	}
-	fmt.Println(boxLine(VTMaroon, fmt.Sprintf("%s%s  Interval: %s%ds%s  %s%s  Term: %s%s%s", VTOrange, IconClock, BoldWhite, interval, Reset, VTOrange, IconCalendar, BoldWhite, term, Reset)))
-	fmt.Println(boxBottom(VTMaroon))
-	fmt.Println()
+	fmt.Fprintln(out, boxLine(VTMaroon, fmt.Sprintf("%s%s  %s %s%ds%s  %s%s  %s %s%s%s", VTOrange, IconClock, T("config.interval"), BoldWhite, interval, Reset, VTOrange, IconCalendar, T("config.term"), BoldWhite, term, Reset)))
+	if session != "" {
+		fmt.Fprintln(out, boxLine(VTMaroon, fmt.Sprintf("%s%s  %s %s%s%s", VTOrange, IconCalendar, T("config.session"), BoldWhite, session, Reset)))
+	}
+	fmt.Fprintln(out, boxBottom(VTMaroon))
+	fmt.Fprintln(out)
+}
+
+// PrintSimulationNotice displays a reminder that checks are running against the fake timetable
+func PrintSimulationNotice() {
+	fmt.Fprintf(out, "%s%s  %s%s\n\n", BoldYellow, IconBell, T("watch.simulation"), Reset)
+}
+
+// PrintDuplicateCRN displays a warning for a CRN listed more than once in the config
+func PrintDuplicateCRN(crn string) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s: %s%s%s\n", Yellow, IconX, Reset, Dim, crn, Reset, Yellow, T("watch.duplicate_crn"), Reset)
 }
 
 // PrintFetchingHeader displays the "Fetching course information" message
 func PrintFetchingHeader() {
-	fmt.Printf("%s%s  Fetching course information...%s\n\n", Dim, IconSearch, Reset)
+	fmt.Fprintf(out, "%s%s  %s%s\n\n", Dim, IconSearch, T("watch.fetching"), Reset)
 }
 
 // PrintCourseFound displays a successfully found course
-func PrintCourseFound(crn, name string) {
-	fmt.Printf("  %s%s%s %s%s%s %s▸%s %s\n", Green, IconCheck, Reset, VTOrange, crn, Reset, Dim, Reset, name)
+// The term is shown after the name when one is given.
+func PrintCourseFound(crn, name, term string) {
+	if term != "" {
+		name = fmt.Sprintf("%s %s· %s%s", name, Dim, term, Reset)
+	}
+	fmt.Fprintf(out, "  %s%s%s %s%s%s %s▸%s %s\n", Green, IconCheck, Reset, VTOrange, crn, Reset, Dim, Reset, name)
+}
+
+// PrintCourseWatch displays a course being watched for new sections
+func PrintCourseWatch(course string, sections int) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s %s▸%s %s\n", Green, IconCheck, Reset, VTOrange, course, Reset, Dim, Reset, T("watch.course_watch", sections))
+}
+
+// PrintTermWatch displays that the term list is watched for new terms
+func PrintTermWatch(terms int) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s %s▸%s %s\n", Green, IconCheck, Reset, VTOrange, T("watch.terms"), Reset, Dim, Reset, T("watch.term_watch", terms))
+}
+
+// PrintTermPublished displays a newly published term
+func PrintTermPublished(name, code string) {
+	ClearLine()
+	fmt.Fprintf(out, "\n  %s%s  %s%s\n\n", BoldGreen, IconCalendar, T("watch.term_published", name, code), Reset)
+}
+
+// PrintNewSection displays a section added to a watched course
+func PrintNewSection(course string, s Section) {
+	ClearLine()
+	fmt.Fprintf(out, "\n  %s%s%s %s\n", BoldGreen, IconCheck, Reset, T("watch.new_section", course, s.Title, s.CRN))
+	if meeting := s.Meeting(); meeting != "" {
+		fmt.Fprintf(out, "    %s%s%s\n", Dim, meeting, Reset)
+	}
+	fmt.Fprintln(out)
 }
 
 // PrintCourseNotFound displays a course that wasn't found
 func PrintCourseNotFound(crn string) {
-	fmt.Printf("  %s%s%s %s%s%s: %snot found, skipping%s\n", Red, IconX, Reset, Dim, crn, Reset, Red, Reset)
+	fmt.Fprintf(out, "  %s%s%s %s%s%s: %s%s%s\n", Red, IconX, Reset, Dim, crn, Reset, Red, T("watch.course_not_found"), Reset)
 }
 
 // PrintDivider displays a horizontal divider line
 func PrintDivider() {
-	fmt.Printf("\n%s────────────────────────────────────────────────────%s\n\n", VTMaroon, Reset)
+	fmt.Fprintf(out, "\n%s────────────────────────────────────────────────────%s\n\n", VTMaroon, Reset)
 }
 
-// PrintCheckingStatus displays the current checking status with spinner
+// PrintCheckingStatus displays the current checking status with spinner.
+// Accessible output skips it; the waiting status that follows covers the round.
 func PrintCheckingStatus(spinnerIdx, attempt int, crn string) {
-	fmt.Printf("\r%s%s%s %sAttempt #%d%s %s│%s Checking %s%s%s...                              ",
-		VTOrange, Spinner[spinnerIdx%len(Spinner)], Reset, Bold, attempt, Reset, Dim, Reset, VTOrange, crn, Reset)
+	if accessible {
+		return
+	}
+	fmt.Fprintf(out, "\r%s%s%s %s%s%s %s│%s %s                              ",
+		VTOrange, Spinner[spinnerIdx%len(Spinner)], Reset, Bold, T("watch.attempt", attempt), Reset, Dim, Reset, T("watch.checking", VTOrange+crn+Reset))
 }
 
 // PrintCheckError displays an error that occurred while checking a CRN
 func PrintCheckError(checkTime, crn string, err error) {
-	fmt.Printf("\r%s%s%s %s[%s]%s Error checking %s: %v\n",
-		Red, IconX, Reset, Dim, checkTime, Reset, crn, err)
+	fmt.Fprintf(out, "\r%s%s%s %s[%s]%s %s\n",
+		Red, IconX, Reset, Dim, checkTime, Reset, T("watch.check_error", crn, err))
+}
+
+// PrintSeatAvailable displays the seat available success box.
+// Urgent alerts also ring the terminal bell.
+func PrintSeatAvailable(name, crn string, urgent bool) {
+	ClearLine()
+	if urgent {
+		fmt.Fprint(out, "\a")
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, boxTop(Green))
+	fmt.Fprintln(out, boxLine(Green, fmt.Sprintf("%s%s  %s%s", BoldGreen, IconCheck, T("watch.seat_available"), Reset)))
+	fmt.Fprintln(out, boxLine(Green, fmt.Sprintf("  %s%s%s", White, name, Reset)))
+	fmt.Fprintln(out, boxLine(Green, fmt.Sprintf("  %s%s%s", Dim, T("watch.crn", crn), Reset)))
+	fmt.Fprintln(out, boxBottom(Green))
+}
+
+// PrintAllClear displays that every watch in the target schedule is open
+func PrintAllClear(open []CourseStatus, bell bool) {
+	ClearLine()
+	if bell {
+		fmt.Fprint(out, "\a")
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, boxTop(Green))
+	fmt.Fprintln(out, boxLine(Green, fmt.Sprintf("%s%s  %s%s", BoldGreen, IconCheck, T("watch.all_clear"), Reset)))
+	for _, course := range open {
+		fmt.Fprintln(out, boxLine(Green, fmt.Sprintf("  %s%s%s  %s%s%s", White, course.Name, Reset, Dim, T("watch.crn", course.CRN), Reset)))
+	}
+	fmt.Fprintln(out, boxBottom(Green))
+}
+
+// PrintSuggestion displays the open sections suggested to register for together
+func PrintSuggestion(picks []CourseStatus) {
+	ClearLine()
+	fmt.Fprintln(out, boxTop(Green))
+	fmt.Fprintln(out, boxLine(Green, fmt.Sprintf("%s%s  %s%s", BoldGreen, IconCalendar, T("watch.suggestion"), Reset)))
+	for _, course := range picks {
+		fmt.Fprintln(out, boxLine(Green, fmt.Sprintf("  %s%s%s  %s  %s%s%s", VTOrange, course.CRN, Reset, truncateString(course.Name, 24), Dim, course.Meeting, Reset)))
+	}
+	fmt.Fprintln(out, boxBottom(Green))
+}
+
+// PrintConflict displays that a section that opened clashes with the schedule
+func PrintConflict(clash string) {
+	fmt.Fprintf(out, "  %s%s%s %s\n\n", Yellow, IconCalendar, Reset, T("watch.conflict", clash))
+}
+
+// PrintConflictSuppressed displays a section that opened but wasn't alerted because it clashes with the schedule
+func PrintConflictSuppressed(name, crn, clash string) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconCalendar, Reset, Dim, T("watch.conflict_suppressed", name, crn, clash), Reset)
+}
+
+// PrintScheduleNotFound displays a schedule CRN whose meeting times couldn't be looked up
+func PrintScheduleNotFound(crn string, err error) {
+	fmt.Fprintf(out, "  %s%s%s %s\n", Yellow, IconX, Reset, T("watch.schedule_not_found", crn, err))
+}
+
+// PrintGroupMemberMissing displays a group section that isn't watched, so the group can't open
+func PrintGroupMemberMissing(name, crn string) {
+	fmt.Fprintf(out, "  %s%s%s %s\n", Yellow, IconX, Reset, T("watch.group_member_missing", name, crn))
+}
+
+// PrintExportNoMeeting displays a section left off the exported calendar for having no set meeting times
+func PrintExportNoMeeting(crn string) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("export.no_meeting", crn), Reset)
+}
+
+// PrintExportNoExam displays a section whose exam code has no slot in calendar.exams
+func PrintExportNoExam(crn, code string) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("export.no_exam", crn, code), Reset)
+}
+
+// PrintExported displays where the calendar was written
+func PrintExported(path string, events, sections int) {
+	fmt.Fprintf(out, "%s%s%s  %s\n", Green, IconCheck, Reset, T("export.written", events, sections, path))
+}
+
+// PrintGroupOpen displays that every section in a watch group is open
+func PrintGroupOpen(name string, open []CourseStatus, bell bool) {
+	ClearLine()
+	if bell {
+		fmt.Fprint(out, "\a")
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, boxTop(Green))
+	fmt.Fprintln(out, boxLine(Green, fmt.Sprintf("%s%s  %s%s", BoldGreen, IconCheck, T("watch.group_open", name), Reset)))
+	for _, course := range open {
+		fmt.Fprintln(out, boxLine(Green, fmt.Sprintf("  %s%s%s  %s%s%s", White, course.Name, Reset, Dim, T("watch.crn", course.CRN), Reset)))
+	}
+	fmt.Fprintln(out, boxBottom(Green))
 }
 
-// PrintSeatAvailable displays the seat available success box
-func PrintSeatAvailable(name, crn string) {
+// PrintGroupPartial displays that some of a watch group's sections are open and the rest still full
+func PrintGroupPartial(name, parts string) {
 	ClearLine()
-	fmt.Println()
-	fmt.Println(boxTop(Green))
-	fmt.Println(boxLine(Green, fmt.Sprintf("%s%s  SEAT AVAILABLE!%s", BoldGreen, IconCheck, Reset)))
-	fmt.Println(boxLine(Green, fmt.Sprintf("  %s%s%s", White, name, Reset)))
-	fmt.Println(boxLine(Green, fmt.Sprintf("  %sCRN: %s%s", Dim, crn, Reset)))
-	fmt.Println(boxBottom(Green))
+	fmt.Fprintf(out, "  %s%s%s %s\n", Yellow, IconTarget, Reset, T("watch.group_partial", name, parts))
+}
+
+// PrintSoundFailed displays an alert sound that could not be played
+func PrintSoundFailed(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconBell, Reset, Dim, T("watch.sound_failed", err), Reset)
+}
+
+// PrintHint displays a sign that a section may open soon
+func PrintHint(name, crn, hint string) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s\n", VTOrange, IconTarget, Reset, T("watch.hint", name, crn, hint))
+}
+
+// PrintInstructorChanged displays a change to a watched section's instructor
+func PrintInstructorChanged(name, crn, previous, current string) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s\n", Yellow, IconGrad, Reset, T("watch.instructor_changed", name, crn, previous, current))
+}
+
+// PrintMeetingChanged displays a change to when or where a watched section meets
+func PrintMeetingChanged(name, crn, previous, current string) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s\n", Yellow, IconCalendar, Reset, T("watch.meeting_changed", name, crn, current, previous))
+}
+
+// PrintSeatClosed displays that a section that had opened has filled again
+func PrintSeatClosed(name, crn string) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s%s\n\n", Yellow, IconX, Reset, T("watch.seat_closed", name, crn), Reset)
+}
+
+// PrintEnrolled displays that a CRN was marked as enrolled and is no longer watched
+func PrintEnrolled(name, crn string) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s%s\n\n", Green, IconGrad, Reset, T("watch.enrolled", name, crn), Reset)
+}
+
+// PrintRetired displays that a CRN is no longer watched because the user enrolled in an alternative to it
+func PrintRetired(name, crn, instead string) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s%s\n\n", Green, IconGrad, Reset, T("watch.retired", name, crn, instead), Reset)
+}
+
+// PrintMarkedEnrolled displays the confirmation of the enrolled command
+func PrintMarkedEnrolled(crn, historyFile string) {
+	fmt.Fprintf(out, "%s%s%s  %s\n", Green, IconGrad, Reset, T("enrolled.marked", crn, historyFile))
+}
+
+// PrintAlternativesRetired displays the alternatives the enrolled command retired
+func PrintAlternativesRetired(group string, crns []string) {
+	fmt.Fprintf(out, "%s%s%s  %s\n", Green, IconGrad, Reset, T("enrolled.retired", strings.Join(crns, ", "), group))
+}
+
+// PrintBanner9Fallback displays that the Banner 9 API failed and the HTML timetable is used instead
+func PrintBanner9Fallback(err error) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("watch.banner9_fallback", err), Reset)
+}
+
+// PrintChallenge displays that checks are paused for a bot check or CAPTCHA
+func PrintChallenge(err error, until string) {
+	ClearLine()
+	fmt.Fprintf(out, "\n%s%s  %s%s\n\n", BoldRed, IconX, T("watch.challenge", err, until), Reset)
+}
+
+// PrintHistoryError displays a failure to read or write the history file
+func PrintHistoryError(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("watch.history_error", err), Reset)
+}
+
+// PrintHistoryPruned displays that a running watcher pruned the history file
+func PrintHistoryPruned(removed int) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Dim, IconCheck, Reset, Dim, T("watch.history_pruned", removed), Reset)
+}
+
+// PrintHistoryPruneResult displays what the history prune command removed
+func PrintHistoryPruneResult(historyFile string, removed, kept int) {
+	fmt.Fprintf(out, "%s%s%s  %s\n", Green, IconCheck, Reset, T("history.pruned", removed, historyFile, kept))
+}
+
+// PrintAuditError displays a failure to write the audit log
+func PrintAuditError(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("watch.audit_error", err), Reset)
+}
+
+// PrintKeyHelp displays the keys available while watching
+func PrintKeyHelp() {
+	fmt.Fprintf(out, "%s%s  %s%s\n\n", Dim, IconTarget, T("keys.help"), Reset)
+}
+
+// PrintAddFailed displays a CRN typed while watching that couldn't be added
+func PrintAddFailed(crn string, err error) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s\n", Red, IconX, Reset, T("keys.add_failed", crn, err))
+}
+
+// PrintBrowse lists what the course browser shows: the subject's courses, or the
+// highlighted course's sections with their seats
+func PrintBrowse(b *browser) {
+	ClearLine()
+	if len(b.courses) == 0 {
+		fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("browse.none", b.subject), Reset)
+		return
+	}
+	marker := func(highlighted bool) string {
+		if highlighted {
+			return VTOrange + "▸" + Reset
+		}
+		return " "
+	}
+
+	fmt.Fprintln(out)
+	if b.stage == browseSections {
+		course := b.courses[b.course]
+		fmt.Fprintf(out, "  %s%s%s %s\n", BoldWhite, course.Code, Reset, course.Title)
+		for i, s := range course.Sections {
+			color := Dim
+			if b.open[s.CRN] {
+				color = Green
+			}
+			fmt.Fprintf(out, "  %s %s%s%s  %s%-10s%s %-20s %s\n", marker(i == b.section), VTOrange, s.CRN, Reset, color, b.seats(s), Reset, truncateString(s.Instructor, 20), s.Meeting())
+		}
+	} else {
+		fmt.Fprintf(out, "  %s%s%s %s\n", BoldWhite, b.subject, Reset, T("browse.courses", len(b.courses)))
+		for i, course := range b.courses {
+			color := Dim
+			if course.Open > 0 {
+				color = Green
+			}
+			fmt.Fprintf(out, "  %s %-10s %-35s %s%s%s\n", marker(i == b.course), course.Code, truncateString(course.Title, 35), color, T("browse.open_count", course.Open, len(course.Sections)), Reset)
+		}
+	}
+	fmt.Fprintf(out, "  %s%s%s\n\n", Dim, T("browse.help"), Reset)
+}
+
+// PrintBrowseFailed displays a subject the course browser couldn't search
+func PrintBrowseFailed(subject string, err error) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s\n", Red, IconX, Reset, T("browse.failed", subject, err))
+}
+
+// PrintCRNRemoved displays a CRN removed from the watch from the keyboard
+func PrintCRNRemoved(crn string) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("keys.removed", crn), Reset)
 }
 
 // PrintEmailSent displays the email notification confirmation
 func PrintEmailSent(email string) {
-	fmt.Printf("  %s%s%s %sNotification sent to %s%s\n\n", VTOrange, IconEmail, Reset, Dim, email, Reset)
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n\n", VTOrange, IconEmail, Reset, Dim, T("watch.email_sent", email), Reset)
+}
+
+// PrintEmailFailed displays a notification email that could not be sent
+func PrintEmailFailed(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Red, IconEmail, Reset, Dim, T("watch.email_failed", err), Reset)
+}
+
+// PrintNotified displays that an alert was sent over a channel other than email
+func PrintNotified(channel string) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n\n", VTOrange, IconEmail, Reset, Dim, T("watch.notified", channel), Reset)
+}
+
+// PrintNotifyFailed displays an alert that could not be sent over a channel other than email
+func PrintNotifyFailed(channel string, err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Red, IconEmail, Reset, Dim, T("watch.notify_failed", channel, err), Reset)
+}
+
+// PrintNotifyLimited displays that an alert was held back by a channel's rate limit
+func PrintNotifyLimited(channel string) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", VTOrange, IconEmail, Reset, Dim, T("watch.notify_limited", channel), Reset)
+}
+
+// PrintAlreadyNotified displays that another instance already sent the alert for a CRN
+func PrintAlreadyNotified(crn string) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n\n", VTOrange, IconEmail, Reset, Dim, T("watch.already_notified", crn), Reset)
 }
 
-// PrintWaitingStatus displays the waiting status with spinner
-func PrintWaitingStatus(spinnerIdx, attempt, found, total int, timeLeft, checkTime string) {
-	fmt.Printf("\r%s%s%s %sAttempt #%d%s %s│%s Found: %s%d%s/%s%d%s %s│%s Next: %s%s%s %s[%s]%s          ",
+// PrintStateError displays a failure to reach the shared state store
+func PrintStateError(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("watch.state_error", err), Reset)
+}
+
+// PrintWatchStateError displays a failure to read or record a section's shared state
+func PrintWatchStateError(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("watch.watch_state_error", err), Reset)
+}
+
+// PrintSharedOpen displays a section another instance last saw open
+func PrintSharedOpen(crn string) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", VTOrange, IconBell, Reset, Dim, T("watch.shared_open", crn), Reset)
+}
+
+// PrintTelemetryError displays a warning that traces or metrics couldn't be exported
+func PrintTelemetryError(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("watch.telemetry_error", err), Reset)
+}
+
+// PrintStatusFileError displays a warning that the status file couldn't be written
+func PrintStatusFileError(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("watch.status_file_error", err), Reset)
+}
+
+// PrintControlUnavailable warns that the control socket couldn't be created, so openseatctl can't reach this watcher
+func PrintControlUnavailable(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("watch.control_unavailable", err), Reset)
+}
+
+// PrintServerListening displays where the server is waiting for agents
+func PrintServerListening(addr string, crns int) {
+	fmt.Fprintf(out, "%s%s  %s%s\n\n", Dim, IconSearch, T("server.listening", addr, crns), Reset)
+}
+
+// PrintAgentJoined displays an agent's first report to the server
+func PrintAgentJoined(name string) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Green, IconCheck, Reset, Dim, T("server.agent_joined", name), Reset)
+}
+
+// PrintAgentCheckError displays a check an agent reported as failed
+func PrintAgentCheckError(checkTime, agent, crn, msg string) {
+	fmt.Fprintf(out, "\r%s%s%s %s[%s]%s %s\n",
+		Red, IconX, Reset, Dim, checkTime, Reset, T("server.check_error", agent, crn, msg))
+}
+
+// PrintServerWatchAdded displays a CRN added through the server's API
+func PrintServerWatchAdded(crn string) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Green, IconCheck, Reset, Dim, T("server.watch_added", crn), Reset)
+}
+
+// PrintAgentStarted displays which server an agent reports to
+func PrintAgentStarted(name, server string) {
+	fmt.Fprintf(out, "%s%s  %s%s\n\n", Dim, IconSearch, T("agent.started", name, server), Reset)
+}
+
+// PrintAgentRound displays a round of checks reported to the server
+func PrintAgentRound(checkTime string, checked, failed int) {
+	fmt.Fprintf(out, "  %s%s%s %s[%s]%s %s\n", Green, IconCheck, Reset, Dim, checkTime, Reset, T("agent.round", checked, failed))
+}
+
+// PrintAgentError displays a round that couldn't be fetched or reported
+func PrintAgentError(err error, retry time.Duration) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("agent.error", err, retry), Reset)
+}
+
+// PrintControlStatus displays a running watcher's status for openseatctl
+func PrintControlStatus(s watchStatus) {
+	state := T("ctl.watching")
+	switch s.State {
+	case StatusPaused:
+		state = T("ctl.paused")
+	case StatusStopped:
+		state = T("ctl.stopped")
+	}
+	fmt.Fprintf(out, "%s%s  %s%s\n\n", BoldVTOrange, IconClock, T("ctl.header", state, humanDuration(s.uptime()), s.PID), Reset)
+
+	for _, c := range s.CRNs {
+		result, color := T("ctl.closed"), Dim
+		switch {
+		case c.Done:
+			result = T("ctl.done")
+		case c.Open:
+			result, color = T("ctl.open"), BoldGreen
+		}
+		line := T("ctl.checks", c.Checks, c.Errors)
+		if !c.NextCheck.IsZero() {
+			line += " · " + T("ctl.next", c.NextCheck.Local().Format("15:04:05"))
+		}
+		fmt.Fprintf(out, "  %s%-5s%s  %-30s  %s%-6s%s  %s%s%s\n", VTOrange, c.CRN, Reset, truncateString(c.Name, 30), color, result, Reset, Dim, line, Reset)
+		if c.LastError != "" {
+			fmt.Fprintf(out, "         %s%s%s\n", Red, T("ctl.last_error", c.LastError), Reset)
+		}
+	}
+	for _, c := range s.Courses {
+		fmt.Fprintf(out, "  %s%-9s%s  %s%s%s\n", VTOrange, c.Code, Reset, Dim, T("ctl.sections", c.Sections), Reset)
+	}
+}
+
+// PrintWaitingStatus displays the waiting status with spinner.
+// A non-empty note, such as the drop/add countdown, is shown at the end.
+// Accessible output prints it once per wait, as a single line.
+func PrintWaitingStatus(spinnerIdx, attempt, found, total int, timeLeft, checkTime, note string) {
+	if accessible {
+		if spinnerIdx == 0 {
+			printAccessibleStatus(attempt, found, total, timeLeft, checkTime, note)
+		}
+		return
+	}
+	if note != "" {
+		note = fmt.Sprintf(" %s│%s %s%s%s", Dim, Reset, Yellow, note, Reset)
+	}
+	fmt.Fprintf(out, "\r%s%s%s %s%s%s %s│%s %s %s%d%s/%s%d%s %s│%s %s %s%s%s %s[%s]%s%s          ",
 		VTOrange, Spinner[spinnerIdx%len(Spinner)], Reset,
-		Bold, attempt, Reset,
+		Bold, T("watch.attempt", attempt), Reset,
 		Dim, Reset,
-		Green, found, Reset,
+		T("watch.found"), Green, found, Reset,
 		Dim, total, Reset,
 		Dim, Reset,
-		VTOrange, timeLeft, Reset,
-		Dim, checkTime, Reset)
+		T("watch.next"), VTOrange, timeLeft, Reset,
+		Dim, checkTime, Reset,
+		note)
+}
+
+// printAccessibleStatus displays the waiting status as one line for screen readers
+func printAccessibleStatus(attempt, found, total int, timeLeft, checkTime, note string) {
+	parts := []string{
+		T("watch.attempt", attempt),
+		fmt.Sprintf("%s %d/%d", T("watch.found"), found, total),
+		fmt.Sprintf("%s %s [%s]", T("watch.next"), timeLeft, checkTime),
+	}
+	if note != "" {
+		parts = append(parts, note)
+	}
+	fmt.Fprintln(out, strings.Join(parts, ", "))
+}
+
+// PrintBurstStarted displays that a burst of fast checks has begun
+func PrintBurstStarted(until string) {
+	fmt.Fprintf(out, "\r%s%s%s %s%s%s          \n", BoldYellow, IconClock, Reset, Bold, T("watch.burst_started", until), Reset)
+}
+
+// PrintSlowdown displays that timetable searches have become much slower than usual
+func PrintSlowdown(where string, recent, usual time.Duration, relaxed bool) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s\n", Yellow, IconClock, Reset, T("watch.slowdown", where, recent, usual))
+	if relaxed {
+		fmt.Fprintf(out, "    %s%s%s\n", Dim, T("watch.slowdown_relaxed", SlowdownRelaxFactor), Reset)
+	}
+}
+
+// PrintSlowdownEnded displays that timetable searches are back to their usual speed
+func PrintSlowdownEnded(where string, recent time.Duration) {
+	ClearLine()
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Dim, IconClock, Reset, Dim, T("watch.slowdown_ended", where, recent), Reset)
+}
+
+// PrintBurstEnded displays that a burst has ended and the normal schedule resumed
+func PrintBurstEnded() {
+	fmt.Fprintf(out, "\r%s%s%s %s%s%s          \n", Dim, IconClock, Reset, Dim, T("watch.burst_ended"), Reset)
+}
+
+// PrintClockJump displays that the clock jumped, usually because the machine slept, and that everything is being checked now
+func PrintClockJump(gap string) {
+	ClearLine()
+	fmt.Fprintf(out, "%s%s  %s%s\n", BoldYellow, IconClock, T("watch.clock_jump", gap), Reset)
+}
+
+// PrintBeforeRegistration warns that monitoring started before registration opens
+func PrintBeforeRegistration(opens string) {
+	fmt.Fprintf(out, "%s%s  %s%s\n\n", BoldYellow, IconCalendar, T("watch.before_registration", opens), Reset)
+}
+
+// PrintDropAddOver displays that monitoring stopped because drop/add has ended
+func PrintDropAddOver(ended string) {
+	fmt.Fprintf(out, "\n%s%s  %s%s\n", BoldVTOrange, IconCalendar, T("watch.drop_add_over", ended), Reset)
+}
+
+// PrintNoSeat displays that a one-shot check found no open seats
+func PrintNoSeat() {
+	fmt.Fprintf(out, "%s%s%s %s\n", Yellow, IconX, Reset, T("watch.no_seat"))
 }
 
 // PrintAllCoursesFound displays the completion message
 func PrintAllCoursesFound() {
-	fmt.Printf("\n%s%s  All courses found! Exiting...%s\n", BoldVTOrange, IconCheck, Reset)
+	fmt.Fprintf(out, "\n%s%s  %s%s\n", BoldVTOrange, IconCheck, T("watch.all_found"), Reset)
+}
+
+// PrintOpenSections displays the sections of a subject that have open seats
+func PrintOpenSections(subject, term string, sections []Section) {
+	fmt.Fprintln(out, boxTop(VTMaroon))
+	fmt.Fprintln(out, boxLine(VTMaroon, fmt.Sprintf("%s%s  %s %s%s%s  %s%s  %s %s%s%s",
+		VTOrange, IconSearch, T("open.header"), BoldWhite, subject, Reset, VTOrange, IconCalendar, T("config.term"), BoldWhite, term, Reset)))
+	fmt.Fprintln(out, boxBottom(VTMaroon))
+	fmt.Fprintln(out)
+
+	if len(sections) == 0 {
+		fmt.Fprintf(out, "  %s%s  %s%s\n", Dim, IconX, T("open.none"), Reset)
+		return
+	}
+
+	for _, s := range sections {
+		fmt.Fprintf(out, "  %s%s%s %-10s %s%s%s %s\n", Green, IconCheck, Reset, s.Course, VTOrange, s.CRN, Reset, truncateString(s.Title, 35))
+		var details []string
+		if s.Seats != "" {
+			details = append(details, T("open.seats", s.Seats))
+		}
+		if s.Instructor != "" {
+			details = append(details, s.Instructor)
+		}
+		if meeting := s.Meeting(); meeting != "" {
+			details = append(details, meeting)
+		}
+		if s.GPA != "" {
+			details = append(details, s.GPA)
+		}
+		if s.Rating != "" {
+			details = append(details, s.Rating)
+		}
+		if len(details) > 0 {
+			fmt.Fprintf(out, "    %s%s%s\n", Dim, strings.Join(details, "  │  "), Reset)
+		}
+	}
+	fmt.Fprintf(out, "\n%s%s%s\n", Dim, T("open.count", len(sections)), Reset)
+}
+
+// PrintImported lists the CRNs found in a schedule export, marking those already
+// watched, or already in the schedule
+func PrintImported(found, added []string, schedule bool) {
+	already := T("import.already")
+	if schedule {
+		already = T("import.already_schedule")
+	}
+	for _, crn := range found {
+		if slices.Contains(added, crn) {
+			fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Green, IconCheck, Reset, VTOrange, crn, Reset)
+		} else {
+			fmt.Fprintf(out, "  %s%s %s  %s%s\n", Dim, IconCheck, crn, already, Reset)
+		}
+	}
+	fmt.Fprintf(out, "\n%s%s%s\n", Dim, T("import.found", len(found), len(added)), Reset)
+}
+
+// PrintImportSaved confirms the imported CRNs were added to the config file
+func PrintImportSaved(count int, configPath string) {
+	fmt.Fprintf(out, "%s%s  %s%s\n", Green, IconCheck, T("import.saved", count, configPath), Reset)
+}
+
+// PrintSectionInfo displays everything known about a section as a card.
+// Fields the timetable didn't list are left out.
+func PrintSectionInfo(s Section) {
+	fmt.Fprintln(out, boxTop(VTMaroon))
+	fmt.Fprintln(out, boxLine(VTMaroon, fmt.Sprintf("%s%s  %s%s%s  %s%s%s",
+		VTOrange, IconBook, BoldWhite, s.Course, Reset, VTOrange, s.CRN, Reset)))
+	fmt.Fprintln(out, boxLine(VTMaroon, fmt.Sprintf("%s%s%s", White, truncateString(s.Title, 50), Reset)))
+	fmt.Fprintln(out, boxBottom(VTMaroon))
+	fmt.Fprintln(out)
+
+	seats := s.Seats
+	if s.Capacity != "" {
+		seats = T("info.seats_of", s.Seats, s.Capacity)
+	}
+	rows := []struct{ label, value string }{
+		{T("info.term"), s.Term},
+		{T("info.instructor"), s.Instructor},
+		{T("info.schedule"), s.Meeting()},
+		{T("info.location"), s.Location},
+		{T("info.modality"), s.Modality},
+		{T("info.type"), s.Type},
+		{T("info.credits"), s.Credits},
+		{T("info.seats"), seats},
+		{T("info.waitlist"), s.Waitlist},
+		{T("info.exam"), s.Exam},
+		{T("info.gpa"), s.GPA},
+		{T("info.rating"), s.Rating},
+		{T("info.comments"), s.Comments},
+	}
+	for _, row := range rows {
+		if row.value != "" {
+			fmt.Fprintf(out, "  %s%-12s%s %s\n", Dim, row.label, Reset, row.value)
+		}
+	}
+}
+
+// PrintTermDiff displays how a course's sections changed between two terms
+func PrintTermDiff(course, fromTerm, toTerm string, d termDiff) {
+	fmt.Fprintln(out, boxTop(VTMaroon))
+	fmt.Fprintln(out, boxLine(VTMaroon, fmt.Sprintf("%s%s  %s%s%s  %s%s → %s%s",
+		VTOrange, IconBook, BoldWhite, course, Reset, Dim, fromTerm, toTerm, Reset)))
+	fmt.Fprintln(out, boxBottom(VTMaroon))
+	fmt.Fprintln(out)
+
+	if len(d.To) == 0 {
+		fmt.Fprintf(out, "  %s%s  %s%s\n\n", Yellow, IconX, T("diff.no_sections", toTerm), Reset)
+	}
+
+	fmt.Fprintf(out, "  %-12s %s%d%s → %s%d%s\n", T("diff.sections"), BoldWhite, len(d.From), Reset, BoldWhite, len(d.To), Reset)
+	fmt.Fprintf(out, "  %-12s %s%d%s → %s%d%s\n", T("diff.capacity"), BoldWhite, d.FromCapacity, Reset, BoldWhite, d.ToCapacity, Reset)
+	printDiffValues(T("diff.instructors"), d.InstructorsAdded, d.InstructorsRemoved)
+	printDiffValues(T("diff.meetings"), d.MeetingsAdded, d.MeetingsRemoved)
+	fmt.Fprintln(out)
+
+	for _, s := range d.To {
+		fmt.Fprintf(out, "  %s%s%s %s  %s%s%s\n", VTOrange, s.CRN, Reset, truncateString(s.Instructor, 20), Dim, s.Meeting(), Reset)
+	}
+}
+
+// printDiffValues displays values added (+) and removed (-) between terms under a label
+func printDiffValues(label string, added, removed []string) {
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Fprintf(out, "  %-12s %s%s%s\n", label, Dim, T("diff.unchanged"), Reset)
+		return
+	}
+	fmt.Fprintf(out, "  %s\n", label)
+	for _, v := range added {
+		fmt.Fprintf(out, "    %s+ %s%s\n", Green, v, Reset)
+	}
+	for _, v := range removed {
+		fmt.Fprintf(out, "    %s- %s%s\n", Red, v, Reset)
+	}
+}
+
+// PrintTimeline displays history events oldest first, one per line
+func PrintTimeline(historyFile string, entries []timelineEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintf(out, "  %s%s  %s%s\n", Dim, IconX, T("history.none", historyFile), Reset)
+		return
+	}
+	for _, e := range entries {
+		fmt.Fprintf(out, "  %s%s%s  %s%-5s%s  %-30s  %s\n", Dim, e.Time.Format("Jan 02 15:04"), Reset, VTOrange, e.CRN, Reset, truncateString(e.Name, 30), e.Text)
+	}
+	fmt.Fprintf(out, "\n%s%s%s\n", Dim, T("history.count", len(entries)), Reset)
+}
+
+// PrintAuditLog displays audit log entries oldest first, one per line
+func PrintAuditLog(auditFile string, entries []AuditEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintf(out, "  %s%s  %s%s\n", Dim, IconX, T("audit.none", auditFile), Reset)
+		return
+	}
+	for _, e := range entries {
+		var text string
+		switch e.Action {
+		case AuditNotification:
+			if e.Result == "sent" {
+				text = T("audit.sent", e.Event, e.Channel)
+			} else {
+				text = Red + T("audit.failed", e.Event, e.Channel, e.Result) + Reset
+			}
+			if e.Recipient != "" {
+				text += " " + T("audit.to", e.Recipient)
+			}
+		case AuditWatchAdded:
+			text = T("audit.watch_added", e.Actor, e.Via)
+		case AuditWatchRemoved:
+			text = T("audit.watch_removed", e.Actor, e.Via)
+		case AuditEnrolled:
+			text = T("audit.enrolled", e.Actor, e.Via)
+		default:
+			text = e.Action
+		}
+		fmt.Fprintf(out, "  %s%s%s  %s%-5s%s  %s\n", Dim, e.Time.Format("Jan 02 15:04"), Reset, VTOrange, e.CRN, Reset, text)
+	}
+	fmt.Fprintf(out, "\n%s%s%s\n", Dim, T("audit.count", len(entries)), Reset)
+}
+
+// PrintOpeningStats lists, for each CRN with any, how long it took to first open
+// and how long its openings lasted.
+func PrintOpeningStats(stats []*openingStats, now time.Time) {
+	printed := false
+	for _, s := range stats {
+		lines := s.describe(now)
+		if len(lines) == 0 {
+			continue
+		}
+		if !printed {
+			fmt.Fprintf(out, "\n%s%s%s\n", Bold, T("openings.title"), Reset)
+			printed = true
+		}
+		fmt.Fprintf(out, "  %s%-5s%s  %s\n", VTOrange, s.CRN, Reset, truncateString(s.Name, 30))
+		for _, line := range lines {
+			fmt.Fprintf(out, "         %s\n", line)
+		}
+	}
+}
+
+// heatShades draws heatmap cells from fewest to most openings
+var heatShades = []string{"░░", "▒▒", "▓▓", "██"}
+
+// PrintHeatmap draws openings by day of week and hour of day, darker cells having seen more.
+// Accessible output lists the hours that saw openings instead.
+func PrintHeatmap(title, historyFile string, h heatmap) {
+	fmt.Fprintf(out, "%s%s  %s%s\n\n", BoldVTOrange, IconCalendar, title, Reset)
+
+	total := h.total()
+	if total == 0 {
+		fmt.Fprintf(out, "  %s%s  %s%s\n", Dim, IconX, T("heatmap.none", historyFile), Reset)
+		return
+	}
+	_, _, most := h.peak()
+
+	if accessible {
+		for _, d := range heatmapDays {
+			for hour, count := range h[d] {
+				if count > 0 {
+					fmt.Fprintln(out, T("heatmap.cell", d.String()[:3], hour, count))
+				}
+			}
+		}
+	} else {
+		fmt.Fprintf(out, "   %s", Dim)
+		for hour := range 24 {
+			fmt.Fprintf(out, " %02d", hour)
+		}
+		fmt.Fprintf(out, "%s\n", Reset)
+
+		for _, d := range heatmapDays {
+			fmt.Fprintf(out, "%s", d.String()[:3])
+			for _, count := range h[d] {
+				if count == 0 {
+					fmt.Fprintf(out, " %s··%s", Dim, Reset)
+					continue
+				}
+				shade := heatShades[(count*len(heatShades)-1)/most]
+				fmt.Fprintf(out, " %s%s%s", VTOrange, shade, Reset)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	day, hour, count := h.peak()
+	fmt.Fprintf(out, "\n%s%s%s %s\n", Green, IconCheck, Reset, T("heatmap.peak", day.String(), hour, count, total))
+}
+
+// PrintDoctorCheck displays the outcome of one doctor check
+func PrintDoctorCheck(name string, err error) {
+	if err == nil {
+		fmt.Fprintf(out, "  %s%s%s %s\n", Green, IconCheck, Reset, name)
+		return
+	}
+	fmt.Fprintf(out, "  %s%s%s %s\n", Red, IconX, Reset, name)
+	fmt.Fprintf(out, "    %s%v%s\n", Dim, err, Reset)
+}
+
+// PrintBenchResult displays what `openseat bench` measured
+func PrintBenchResult(r benchResult) {
+	fmt.Fprintf(out, "%sBenchmark:%s %d watches, %d rounds, %d sections per page\n\n", Bold, Reset, r.Watches, r.Rounds, r.Rows)
+	fmt.Fprintf(out, "  %sChecks%s  %d (%d open)\n", Bold, Reset, r.Checks, r.Open)
+	fmt.Fprintf(out, "    %-12s %.1f checks/s\n", "throughput", r.ChecksPerSec)
+	fmt.Fprintf(out, "    %-12s %d allocs, %s per check\n", "memory", r.CheckAllocs, benchSize(r.CheckBytes))
+	fmt.Fprintf(out, "  %sParsing%s %d pages (%d sections each)\n", Bold, Reset, r.Pages, r.ParseSections)
+	fmt.Fprintf(out, "    %-12s %.0f µs per page\n", "time", r.ParseMicros)
+	fmt.Fprintf(out, "    %-12s %d allocs, %s per page\n", "memory", r.ParseAllocs, benchSize(r.ParseBytes))
+	fmt.Fprintf(out, "\n%sCheck numbers include the mock timetable, which runs in the same process.%s\n", Dim, Reset)
+}
+
+// PrintReportError displays a failure to write the exit report
+func PrintReportError(err error) {
+	fmt.Fprintf(out, "  %s%s%s %s%s%s\n", Yellow, IconX, Reset, Dim, T("watch.report_error", err), Reset)
+}
+
+// PrintDoctorVerdict displays the doctor's overall conclusion
+func PrintDoctorVerdict(healthy bool, message string) {
+	color := BoldGreen
+	if !healthy {
+		color = BoldRed
+	}
+	fmt.Fprintf(out, "\n%s%s%s\n", color, message, Reset)
 }
 
 // ClearLine clears the current terminal line
 func ClearLine() {
-	fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
+	if accessible {
+		return
+	}
+	fmt.Fprintf(out, "\r%s\r", strings.Repeat(" ", 80))
 }
 
 // truncateString truncates a string to maxLen, adding "..." if truncated
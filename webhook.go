@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultWebhookName names a webhook's channel when it isn't given a name
+const DefaultWebhookName = "webhook"
+
+// webhookFuncs are the functions webhook templates can use besides Go's built-ins:
+// json writes a value as JSON, quoting and escaping strings, and env reads an
+// environment variable, so tokens in headers stay out of the config.
+var webhookFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		var b strings.Builder
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false) // "Law & Society", not "Law \u0026 Society"
+		err := enc.Encode(v)
+		return strings.TrimSuffix(b.String(), "\n"), err
+	},
+	"env": os.Getenv,
+}
+
+// Webhook configures alerts POSTed as JSON to a URL, for services OpenSeat doesn't
+// support itself. By default the body is the same JSON notify commands read; Body
+// and Headers are Go templates over it for services that expect their own format.
+type Webhook struct {
+	Name    string            `json:"name"`    // channel name, for rateLimits and minSeverity (defaults to "webhook")
+	URL     string            `json:"url"`     // where alerts are POSTed
+	Body    string            `json:"body"`    // template for the JSON body, e.g. {"text": {{json .Subject}}} (optional)
+	Headers map[string]string `json:"headers"` // extra headers, each a template, e.g. {"Authorization": "Bearer {{env \"HOOK_TOKEN\"}}"} (optional)
+	Secret  string            `json:"secret"`  // key the body is signed with, a template like the headers, e.g. {{env "HOOK_SECRET"}} (optional)
+
+	body    *template.Template
+	headers map[string]*template.Template
+	secret  *template.Template
+}
+
+// Headers of a signed webhook request. The signature is "sha256=" and the hex
+// HMAC-SHA256 of the timestamp, a dot, and the body, keyed with the secret, so a
+// receiver can check the alert came from OpenSeat and turn away old ones replayed.
+const (
+	webhookTimestampHeader = "X-OpenSeat-Timestamp"
+	webhookSignatureHeader = "X-OpenSeat-Signature"
+)
+
+// applyWebhookDefaults checks each webhook, and that no two share a channel name.
+func applyWebhookDefaults(hooks []Webhook) error {
+	names := make(map[string]bool)
+	for i := range hooks {
+		if err := hooks[i].applyDefaults(); err != nil {
+			return err
+		}
+		name := channelKey(hooks[i].Name)
+		if names[name] {
+			return fmt.Errorf("webhooks: more than one is named %q; give each a different name", hooks[i].Name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// checkWebhookNames checks, once the channels are set up, that no webhook has
+// another channel's name, such as "email" or "slack", since it would share that
+// channel's rate limit and minimum severity.
+func (c Config) checkWebhookNames() error {
+	channels := c.channels()
+	for _, n := range c.notifiers {
+		if _, ok := n.(*webhookNotifier); !ok {
+			continue
+		}
+		same := 0
+		for _, name := range channels {
+			if channelKey(name) == channelKey(n.Name()) {
+				same++
+			}
+		}
+		if same > 1 {
+			return fmt.Errorf("webhooks: %q is already the name of another channel; give the webhook its own name", n.Name())
+		}
+	}
+	return nil
+}
+
+// applyDefaults fills in the name, checks the URL, and parses the templates. The
+// body template is tried on a sample alert, so a typo shows at startup rather than
+// when a seat opens.
+func (w *Webhook) applyDefaults() error {
+	if w.Name == "" {
+		w.Name = DefaultWebhookName
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		// the URL may hold a token, so it's left out of the error
+		return fmt.Errorf("webhooks: %s: url must be an http or https URL", w.Name)
+	}
+	if w.Body != "" {
+		if w.body, err = template.New("body").Funcs(webhookFuncs).Parse(w.Body); err != nil {
+			return fmt.Errorf("webhooks: %s: body: %w", w.Name, err)
+		}
+		body, err := w.render(sampleWebhookEvent)
+		if err != nil {
+			return fmt.Errorf("webhooks: %s: body: %w", w.Name, err)
+		}
+		var v any
+		if err := json.Unmarshal(body, &v); err != nil {
+			return fmt.Errorf("webhooks: %s: body is not valid JSON: %w", w.Name, err)
+		}
+	}
+	w.headers = make(map[string]*template.Template, len(w.Headers))
+	for name, value := range w.Headers {
+		if w.headers[name], err = template.New(name).Funcs(webhookFuncs).Parse(value); err != nil {
+			return fmt.Errorf("webhooks: %s: header %s: %w", w.Name, name, err)
+		}
+	}
+	if w.Secret != "" {
+		if w.secret, err = template.New("secret").Funcs(webhookFuncs).Parse(w.Secret); err != nil {
+			return fmt.Errorf("webhooks: %s: secret: %w", w.Name, err)
+		}
+	}
+	return nil
+}
+
+// sign returns the signature of a body sent at a timestamp (Unix seconds).
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sampleWebhookEvent is the alert body templates are checked against
+var sampleWebhookEvent = commandEvent{
+	Version:  commandEventVersion,
+	Time:     time.Date(2026, time.January, 12, 8, 0, 0, 0, time.UTC),
+	Severity: SeverityCritical,
+	Alert: Alert{
+		Event: EventOpen, Subject: "VT Course Section Open!", Body: "OPEN SEAT: Intro to Testing (CRN: 12345)",
+		CRN: "12345", Name: "Intro to Testing", Course: "CS-1014", Term: "202601", Instructor: "Smith", Seats: "1",
+	},
+}
+
+// render returns the body for an event: the template's output, or the event as JSON.
+func (w Webhook) render(event commandEvent) ([]byte, error) {
+	if w.body == nil {
+		return json.Marshal(event)
+	}
+	var body bytes.Buffer
+	if err := w.body.Execute(&body, event); err != nil {
+		return nil, err
+	}
+	return body.Bytes(), nil
+}
+
+// host is where the webhook's alerts go, for the audit log. The rest of the URL
+// may hold a token, so it's left out.
+func (w Webhook) host() string {
+	if u, err := url.Parse(w.URL); err == nil {
+		return u.Host
+	}
+	return ""
+}
+
+// webhookNotifier POSTs alerts to a webhook
+type webhookNotifier struct {
+	Webhook
+}
+
+func (n *webhookNotifier) Name() string { return n.Webhook.Name }
+
+// Notify POSTs the alert, with its headers filled in from the same event as the
+// body, and signed when the webhook has a secret.
+func (n *webhookNotifier) Notify(a Alert) error {
+	event := commandEvent{Version: commandEventVersion, Time: time.Now(), Severity: a.severity(), Alert: a}
+	body, err := n.render(event)
+	if err != nil {
+		return fmt.Errorf("%s: body: %w", n.Name(), err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	for name, tmpl := range n.headers {
+		var value strings.Builder
+		if err := tmpl.Execute(&value, event); err != nil {
+			return fmt.Errorf("%s: header %s: %w", n.Name(), name, err)
+		}
+		req.Header.Set(name, value.String())
+	}
+	if n.secret != nil {
+		var secret strings.Builder
+		if err := n.secret.Execute(&secret, event); err != nil {
+			return fmt.Errorf("%s: secret: %w", n.Name(), err)
+		}
+		if secret.Len() == 0 {
+			// signing with an empty key would look signed but prove nothing
+			return fmt.Errorf("%s: secret is empty", n.Name())
+		}
+		timestamp := strconv.FormatInt(event.Time.Unix(), 10)
+		req.Header.Set(webhookTimestampHeader, timestamp)
+		req.Header.Set(webhookSignatureHeader, sign(secret.String(), timestamp, body))
+	}
+	return sendAPIRequest(n.Name(), req)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// webhookRequest is one request a webhook server received
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookServer records every request POSTed to it
+func webhookServer(t *testing.T) (string, *[]webhookRequest) {
+	var requests []webhookRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, webhookRequest{r.Header, body})
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &requests
+}
+
+// newWebhook returns a webhook notifier set up as it would be from the config
+func newWebhook(t *testing.T, w Webhook) *webhookNotifier {
+	t.Helper()
+	if err := w.applyDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &webhookNotifier{Webhook: w}
+}
+
+// ===================
+// Webhook config tests
+// ===================
+
+func TestLoadConfig_ErrorInvalidWebhook(t *testing.T) {
+	for _, hooks := range []string{
+		`[{"body": "{}"}]`,
+		`[{"url": "hooks.example.com/seat"}]`,
+		`[{"url": "https://hooks.example.com/seat", "body": "{\"text\": {{.Subject}"}]`,
+		`[{"url": "https://hooks.example.com/seat", "body": "{\"text\": {{.Subject}}}"}]`,
+		`[{"url": "https://hooks.example.com/seat", "body": "{\"text\": {{json .Nope}}}"}]`,
+		`[{"url": "https://hooks.example.com/seat", "headers": {"X-Token": "{{env"}}]`,
+		`[{"url": "https://hooks.example.com/a"}, {"url": "https://hooks.example.com/b"}]`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], "webhooks": `+hooks+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", hooks)
+		}
+	}
+}
+
+func TestLoadConfig_WebhookChannels(t *testing.T) {
+	path := createTempConfig(t, `{"crns": ["12345"], "webhooks": [
+		{"url": "https://hooks.example.com/a"},
+		{"name": "discord", "url": "https://discord.com/api/webhooks/1/x", "body": "{\"content\": {{json .Subject}}}"}
+	], "minSeverity": {"discord": "critical"}}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.notifiers, err = cfg.newNotifiers(&MockEmailSender{}); err != nil || len(cfg.notifiers) != 2 {
+		t.Fatalf("expected two webhooks, got %v, %v", cfg.notifiers, err)
+	}
+	if cfg.notifiers[0].Name() != DefaultWebhookName || cfg.notifiers[1].Name() != "discord" {
+		t.Errorf("unexpected channel names %q and %q", cfg.notifiers[0].Name(), cfg.notifiers[1].Name())
+	}
+	if err := cfg.checkChannelNames(); err != nil {
+		t.Errorf("expected the webhook's name to name the channel, got %v", err)
+	}
+	if got := notifierRecipient(cfg.notifiers[1]); got != "discord.com" {
+		t.Errorf("expected only the host audited, got %q", got)
+	}
+}
+
+func TestCheckWebhookNames_OtherChannels(t *testing.T) {
+	for _, cfg := range []Config{
+		{Email: "test@example.com", notifiers: []Notifier{&webhookNotifier{Webhook{Name: "Email"}}}},
+		{notifiers: []Notifier{&slackNotifier{}, &webhookNotifier{Webhook{Name: "slack"}}}},
+	} {
+		if err := cfg.checkChannelNames(); err == nil {
+			t.Errorf("expected error for a webhook named like another channel, %q", cfg.notifiers[len(cfg.notifiers)-1].Name())
+		}
+	}
+
+	cfg := Config{Email: "test@example.com", notifiers: []Notifier{&slackNotifier{}, &webhookNotifier{Webhook{Name: "discord"}}}}
+	if err := cfg.checkChannelNames(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// ===================
+// webhookNotifier tests
+// ===================
+
+func TestWebhookNotify_DefaultBody(t *testing.T) {
+	server, requests := webhookServer(t)
+	n := newWebhook(t, Webhook{URL: server})
+
+	if err := n.Notify(Alert{Event: EventOpen, Subject: "VT Course Section Open!", CRN: "12345", Name: "Intro to Testing", Term: "202601", Seats: "2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var event commandEvent
+	if err := json.Unmarshal((*requests)[0].body, &event); err != nil {
+		t.Fatalf("expected JSON, got %s", (*requests)[0].body)
+	}
+	if event.Version != commandEventVersion || event.Time.IsZero() || event.Severity != SeverityCritical {
+		t.Errorf("expected the notify command event, got %+v", event)
+	}
+	if event.CRN != "12345" || event.Name != "Intro to Testing" || event.Term != "202601" || event.Seats != "2" {
+		t.Errorf("expected the section's details, got %+v", event.Alert)
+	}
+	if ct := (*requests)[0].header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("expected a JSON content type, got %q", ct)
+	}
+}
+
+func TestWebhookNotify_Templates(t *testing.T) {
+	server, requests := webhookServer(t)
+	t.Setenv("HOOK_TOKEN", "secret")
+	n := newWebhook(t, Webhook{
+		URL:     server,
+		Body:    `{"content": {{json .Subject}}, "crn": {{json .CRN}}, "urgent": {{eq .Severity "critical"}}}`,
+		Headers: map[string]string{"Authorization": `Bearer {{env "HOOK_TOKEN"}}`, "X-Event": "{{.Event}}"},
+	})
+
+	if err := n.Notify(Alert{Event: EventOpen, Subject: `"Law & Society" open`, CRN: "12345"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := (*requests)[0]
+	if got := string(req.body); got != `{"content": "\"Law & Society\" open", "crn": "12345", "urgent": true}` {
+		t.Errorf("unexpected body %s", got)
+	}
+	if req.header.Get("Authorization") != "Bearer secret" || req.header.Get("X-Event") != EventOpen {
+		t.Errorf("expected the headers filled in, got %v", req.header)
+	}
+}
+
+func TestWebhookNotify_Signed(t *testing.T) {
+	server, requests := webhookServer(t)
+	t.Setenv("HOOK_SECRET", "s3cret")
+	n := newWebhook(t, Webhook{URL: server, Secret: `{{env "HOOK_SECRET"}}`})
+
+	before := time.Now().Unix()
+	if err := n.Notify(Alert{Event: EventOpen, Subject: "Seat open", CRN: "12345"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := (*requests)[0]
+	timestamp := req.header.Get(webhookTimestampHeader)
+	if ts, err := strconv.ParseInt(timestamp, 10, 64); err != nil || ts < before || ts > time.Now().Unix() {
+		t.Errorf("expected the time sent, got %q", timestamp)
+	}
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(timestamp + "." + string(req.body)))
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); req.header.Get(webhookSignatureHeader) != want {
+		t.Errorf("expected signature %s, got %q", want, req.header.Get(webhookSignatureHeader))
+	}
+}
+
+func TestWebhookNotify_Unsigned(t *testing.T) {
+	server, requests := webhookServer(t)
+	if err := newWebhook(t, Webhook{URL: server}).Notify(Alert{Subject: "Seat open"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h := (*requests)[0].header; h.Get(webhookSignatureHeader) != "" || h.Get(webhookTimestampHeader) != "" {
+		t.Errorf("expected no signature without a secret, got %v", h)
+	}
+
+	err := newWebhook(t, Webhook{URL: server, Secret: `{{env "UNSET_HOOK_SECRET"}}`}).Notify(Alert{Subject: "Seat open"})
+	if err == nil || len(*requests) != 1 {
+		t.Errorf("expected an empty secret refused before sending, got %v", err)
+	}
+}
+
+func TestWebhookNotify_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such hook", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := newWebhook(t, Webhook{Name: "zapier", URL: server.URL}).Notify(Alert{Subject: "Seat open"})
+	if err == nil || !strings.Contains(err.Error(), "zapier") || !strings.Contains(err.Error(), "no such hook") {
+		t.Errorf("expected the webhook's error, got %v", err)
+	}
+}
+
+func TestWebhookNotify_ConnectionErrorHidesURL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	n := newWebhook(t, Webhook{URL: "http://127.0.0.1:1/hooks/SECRETTOKEN?key=SECRETKEY"})
+
+	err := n.Notify(Alert{Subject: "Seat open"})
+	if err == nil || strings.Contains(err.Error(), "SECRET") || !strings.Contains(err.Error(), "127.0.0.1:1") {
+		t.Errorf("expected an error naming only the host, got %v", err)
+	}
+
+	cfg := Config{AuditFile: path, notifiers: []Notifier{n}}
+	cfg.notify(&MockEmailSender{}, Alert{Event: EventOpen, CRN: "12345", Subject: "Seat open"})
+	audit, _ := os.ReadFile(path)
+	if len(audit) == 0 || strings.Contains(string(audit), "SECRET") || strings.Contains(string(audit), "/hooks/") {
+		t.Errorf("expected the failure audited without the webhook path, got %s", audit)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// twilioAPIURL is Twilio's REST API, overridden in tests
+var twilioAPIURL = "https://api.twilio.com/2010-04-01"
+
+// WhatsApp configures alerts sent as WhatsApp messages through Twilio. The account
+// SID and auth token come from TWILIO_ACCOUNT_SID and TWILIO_AUTH_TOKEN.
+//
+// WhatsApp only allows free-form messages within 24 hours of the recipient last
+// messaging the sender, so outside the Twilio sandbox alerts need an approved
+// template. With ContentSID set, seat-open alerts are sent with the template and
+// its variables filled in: {{1}} the course name, {{2}} the CRN, and {{3}} the seats.
+type WhatsApp struct {
+	From       string   `json:"from"`       // Twilio WhatsApp sender number, e.g. +14155238886
+	To         []string `json:"to"`         // Numbers to message, e.g. +15405551234
+	ContentSID string   `json:"contentSid"` // Approved message template, e.g. HX... (optional)
+}
+
+// applyDefaults checks the numbers are in the E.164 format Twilio expects.
+func (w *WhatsApp) applyDefaults() error {
+	if w.From == "" && len(w.To) == 0 {
+		return nil
+	}
+	if len(w.To) == 0 {
+		return fmt.Errorf("whatsapp: to needs at least one number")
+	}
+	for _, number := range append([]string{w.From}, w.To...) {
+		if !isE164(number) {
+			return fmt.Errorf("invalid whatsapp number %q: expected the international format, e.g. +15405551234", number)
+		}
+	}
+	if w.ContentSID != "" && !strings.HasPrefix(w.ContentSID, "HX") {
+		return fmt.Errorf("invalid whatsapp contentSid %q: expected a Twilio content SID starting with HX", w.ContentSID)
+	}
+	return nil
+}
+
+// isE164 reports whether number is a phone number like +15405551234.
+func isE164(number string) bool {
+	digits, ok := strings.CutPrefix(number, "+")
+	if !ok || len(digits) < 8 || len(digits) > 15 {
+		return false
+	}
+	return strings.Trim(digits, "0123456789") == ""
+}
+
+// whatsAppNotifier sends alerts through Twilio's WhatsApp API
+type whatsAppNotifier struct {
+	WhatsApp
+	accountSID string
+	authToken  string
+}
+
+// newNotifier reads the Twilio credentials from the environment.
+func (w WhatsApp) newNotifier() (*whatsAppNotifier, error) {
+	sid, token, err := twilioCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return &whatsAppNotifier{WhatsApp: w, accountSID: sid, authToken: token}, nil
+}
+
+// twilioCredentials reads the Twilio account SID and auth token from the environment.
+func twilioCredentials() (sid, token string, err error) {
+	sid, token = os.Getenv("TWILIO_ACCOUNT_SID"), os.Getenv("TWILIO_AUTH_TOKEN")
+	if sid == "" || token == "" {
+		return "", "", fmt.Errorf("TWILIO_ACCOUNT_SID and TWILIO_AUTH_TOKEN must be set")
+	}
+	return sid, token, nil
+}
+
+func (n *whatsAppNotifier) Name() string { return "WhatsApp" }
+
+// Notify messages every recipient, trying them all even if one fails. With a
+// template, only seat-open alerts are sent, since other alerts have no template
+// and free-form messages are rejected outside the 24-hour window.
+func (n *whatsAppNotifier) Notify(a Alert) error {
+	if n.ContentSID != "" && a.Event != EventOpen {
+		return nil
+	}
+
+	var errs []error
+	for _, to := range n.To {
+		if err := n.send(to, a); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", to, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// send sends one message.
+func (n *whatsAppNotifier) send(to string, a Alert) error {
+	form := url.Values{
+		"From": {"whatsapp:" + n.From},
+		"To":   {"whatsapp:" + to},
+	}
+	if n.ContentSID != "" {
+		vars, err := json.Marshal(whatsAppVariables(a))
+		if err != nil {
+			return err
+		}
+		form.Set("ContentSid", n.ContentSID)
+		form.Set("ContentVariables", string(vars))
+	} else {
+		form.Set("Body", a.Subject+"\n\n"+a.Body)
+	}
+
+	return sendTwilioMessage(n.accountSID, n.authToken, form)
+}
+
+// sendTwilioMessage sends a message through Twilio's Messages API, which sends
+// both WhatsApp messages and texts.
+func sendTwilioMessage(accountSID, authToken string, form url.Values) error {
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", twilioAPIURL, url.PathEscape(accountSID))
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(accountSID, authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return sendAPIRequest("twilio", req)
+}
+
+// whatsAppVariables fills in the template's variables for an alert. The seat count
+// isn't known for every alert, so it falls back to saying there's at least one.
+func whatsAppVariables(a Alert) map[string]string {
+	seats := a.Seats
+	if seats == "" {
+		seats = T("whatsapp.seats_unknown")
+	}
+	return map[string]string{"1": a.Name, "2": a.CRN, "3": seats}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// twilioServer records the form of every message sent to it
+func twilioServer(t *testing.T, status int) *[]url.Values {
+	var forms []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "ACtest" || pass != "token" || r.URL.Path != "/Accounts/ACtest/Messages.json" {
+			t.Errorf("unexpected request to %s as %s:%s", r.URL.Path, user, pass)
+		}
+		r.ParseForm()
+		forms = append(forms, r.PostForm)
+		w.WriteHeader(status)
+		if status >= 300 {
+			w.Write([]byte(`{"code": 63016, "message": "Failed to send freeform message because you are outside the allowed window"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	old := twilioAPIURL
+	twilioAPIURL = server.URL
+	t.Cleanup(func() { twilioAPIURL = old })
+	return &forms
+}
+
+// ===================
+// WhatsApp config tests
+// ===================
+
+func TestLoadConfig_ErrorInvalidWhatsApp(t *testing.T) {
+	for _, wa := range []string{
+		`{"from": "+14155238886"}`,
+		`{"from": "4155238886", "to": ["+15405551234"]}`,
+		`{"from": "+14155238886", "to": ["540-555-1234"]}`,
+		`{"from": "+14155238886", "to": ["+15405551234"], "contentSid": "template"}`,
+	} {
+		path := createTempConfig(t, `{"crns": ["12345"], "whatsapp": `+wa+`}`)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("%s: expected error", wa)
+		}
+	}
+}
+
+func TestWhatsAppNewNotifier_NeedsCredentials(t *testing.T) {
+	t.Setenv("TWILIO_ACCOUNT_SID", "")
+	t.Setenv("TWILIO_AUTH_TOKEN", "")
+	if _, err := (WhatsApp{From: "+14155238886", To: []string{"+15405551234"}}).newNotifier(); err == nil {
+		t.Error("expected error without Twilio credentials")
+	}
+}
+
+// ===================
+// whatsAppNotifier tests
+// ===================
+
+func TestWhatsAppNotify_FreeForm(t *testing.T) {
+	forms := twilioServer(t, http.StatusCreated)
+	n := &whatsAppNotifier{WhatsApp: WhatsApp{From: "+14155238886", To: []string{"+15405551234", "+15405559876"}}, accountSID: "ACtest", authToken: "token"}
+
+	if err := n.Notify(Alert{Event: AlertStarted, Subject: "OpenSeat is watching", Body: "Watching 1 CRN"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*forms) != 2 {
+		t.Fatalf("expected a message to each number, got %d", len(*forms))
+	}
+	form := (*forms)[0]
+	if form.Get("From") != "whatsapp:+14155238886" || form.Get("To") != "whatsapp:+15405551234" {
+		t.Errorf("unexpected numbers: %v", form)
+	}
+	if body := form.Get("Body"); !strings.HasPrefix(body, "OpenSeat is watching") || !strings.Contains(body, "Watching 1 CRN") {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestWhatsAppNotify_Template(t *testing.T) {
+	forms := twilioServer(t, http.StatusCreated)
+	n := &whatsAppNotifier{WhatsApp: WhatsApp{From: "+14155238886", To: []string{"+15405551234"}, ContentSID: "HXtest"}, accountSID: "ACtest", authToken: "token"}
+
+	// only seat openings have a template
+	if err := n.Notify(Alert{Event: AlertHint, Subject: "CRN 12345 may open soon"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := n.Notify(Alert{Event: EventOpen, Subject: "VT Course Section Open!", CRN: "12345", Name: "Intro to Testing", Seats: "3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*forms) != 1 {
+		t.Fatalf("expected only the opening to be sent, got %d", len(*forms))
+	}
+	form := (*forms)[0]
+	if form.Get("ContentSid") != "HXtest" || form.Get("Body") != "" {
+		t.Errorf("expected a template message, got %v", form)
+	}
+	var vars map[string]string
+	json.Unmarshal([]byte(form.Get("ContentVariables")), &vars)
+	if vars["1"] != "Intro to Testing" || vars["2"] != "12345" || vars["3"] != "3" {
+		t.Errorf("unexpected variables %v", vars)
+	}
+}
+
+func TestWhatsAppNotify_Error(t *testing.T) {
+	twilioServer(t, http.StatusBadRequest)
+	n := &whatsAppNotifier{WhatsApp: WhatsApp{From: "+14155238886", To: []string{"+15405551234"}}, accountSID: "ACtest", authToken: "token"}
+
+	err := n.Notify(Alert{Subject: "Subject", Body: "Body"})
+	if err == nil || !strings.Contains(err.Error(), "+15405551234") || !strings.Contains(err.Error(), "outside the allowed window") {
+		t.Errorf("expected the number and Twilio's explanation in the error, got %v", err)
+	}
+}
+
+func TestWhatsAppVariables_UnknownSeats(t *testing.T) {
+	if vars := whatsAppVariables(Alert{CRN: "12345", Name: "Intro to Testing"}); vars["3"] != "at least 1" {
+		t.Errorf("seats = %q, want at least 1", vars["3"])
+	}
+}